@@ -0,0 +1,183 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/segmentio/parquet-go/format"
+)
+
+// OpenAppender opens the parquet file f and returns a Writer which appends new
+// row groups to it.
+//
+// The footer and page index of the file are truncated, the rows written to the
+// returned writer are then added as new row groups after the existing ones. The
+// file remains invalid until the writer is closed, at which point a new footer
+// referencing both the existing and the new row groups is written, along with
+// the page index of all row groups.
+//
+// The schema of the file is used to write the new rows, unless a schema is
+// passed in the options, in which case it must be equal to the schema of the
+// file. Programs that write Go values with the Write method of the returned
+// writer should pass the schema of their Go type, for example:
+//
+//	writer, err := parquet.OpenAppender(f, parquet.SchemaOf(new(RowType)))
+//
+// Key/value metadata of the file are retained, and values passed in the
+// options take precedence over the existing ones.
+//
+// The function returns an error if the file has bloom filters written after
+// its column chunks, since they would be overwritten by the new row groups.
+//
+// The file must be opened for both reading and writing.
+func OpenAppender(f *os.File, options ...WriterOption) (*Writer, error) {
+	s, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := OpenFile(f, s.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	fileSchema := NewSchema(file.root.Name(), file.root)
+	switch {
+	case config.Schema == nil:
+		config.Schema = fileSchema
+	case !nodesAreEqual(config.Schema, fileSchema):
+		return nil, ErrRowGroupSchemaMismatch
+	}
+
+	// New row groups are written where the sections following the column
+	// chunks start, usually the page index or the footer; both are written
+	// again when the writer is closed.
+	offset, err := appendOffsetOf(file)
+	if err != nil {
+		return nil, err
+	}
+
+	w := NewWriter(f, config)
+	if err := w.writer.appendTo(file, offset); err != nil {
+		return nil, err
+	}
+
+	if err := f.Truncate(offset); err != nil {
+		return nil, err
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// appendOffsetOf returns the offset in the file where new row groups can be
+// written, which is the start of the first section following the column chunks
+// of the file: the page index, bloom filters, or the footer.
+//
+// The file is truncated at this offset. The page index is written again when
+// the writer is closed, but the bloom filters are not, so the function returns
+// an error if a bloom filter, or any column chunk, would be overwritten.
+func appendOffsetOf(file *File) (int64, error) {
+	b := make([]byte, 4)
+	if _, err := file.reader.ReadAt(b, file.size-8); err != nil {
+		return 0, fmt.Errorf("reading footer size of parquet file: %w", err)
+	}
+	footerSize := int64(binary.LittleEndian.Uint32(b))
+	offset := file.size - (footerSize + 8)
+
+	metadata := &file.metadata
+	columnChunksEnd := int64(len("PAR1"))
+	for i := range metadata.RowGroups {
+		for j := range metadata.RowGroups[i].Columns {
+			c := &metadata.RowGroups[i].Columns[j].MetaData
+			start := c.DataPageOffset
+			if c.DictionaryPageOffset > 0 && c.DictionaryPageOffset < start {
+				start = c.DictionaryPageOffset
+			}
+			if end := start + c.TotalCompressedSize; end > columnChunksEnd {
+				columnChunksEnd = end
+			}
+		}
+	}
+
+	for i := range metadata.RowGroups {
+		for j := range metadata.RowGroups[i].Columns {
+			c := &metadata.RowGroups[i].Columns[j]
+			for _, sectionOffset := range [...]int64{c.ColumnIndexOffset, c.OffsetIndexOffset, c.MetaData.BloomFilterOffset} {
+				if sectionOffset >= columnChunksEnd && sectionOffset < offset {
+					offset = sectionOffset
+				}
+			}
+		}
+	}
+
+	if columnChunksEnd > offset {
+		return 0, fmt.Errorf("cannot append to parquet file: column chunks end at offset %d, past the start of the footer at offset %d", columnChunksEnd, offset)
+	}
+	for i := range metadata.RowGroups {
+		for j := range metadata.RowGroups[i].Columns {
+			if bloomFilterOffset := metadata.RowGroups[i].Columns[j].MetaData.BloomFilterOffset; bloomFilterOffset >= offset {
+				return 0, fmt.Errorf("cannot append to parquet file: the bloom filter of column %d of row group %d at offset %d would be overwritten", j, i, bloomFilterOffset)
+			}
+		}
+	}
+	return offset, nil
+}
+
+// appendTo configures w to produce row groups after those of the file passed
+// as argument, starting at the given offset.
+func (w *writer) appendTo(file *File, offset int64) error {
+	metadata := &file.metadata
+	numColumns := len(w.columns)
+
+	w.rowGroups = append(w.rowGroups[:0], metadata.RowGroups...)
+	w.columnIndexes = w.columnIndexes[:0]
+	w.offsetIndexes = w.offsetIndexes[:0]
+
+	for i := range w.rowGroups {
+		rowGroup := &w.rowGroups[i]
+		if len(rowGroup.Columns) != numColumns {
+			return fmt.Errorf("row group %d of appended file has %d columns but the schema has %d", i, len(rowGroup.Columns), numColumns)
+		}
+
+		// Copy the columns since the offsets of the page index are rewritten
+		// when the writer is closed.
+		rowGroup.Columns = append([]format.ColumnChunk{}, rowGroup.Columns...)
+		for j := range rowGroup.Columns {
+			c := &rowGroup.Columns[j]
+			c.ColumnIndexOffset, c.ColumnIndexLength = 0, 0
+			c.OffsetIndexOffset, c.OffsetIndexLength = 0, 0
+		}
+
+		var columnIndex []format.ColumnIndex
+		var offsetIndex []format.OffsetIndex
+		if file.hasIndexes() {
+			j := i * numColumns
+			columnIndex = file.columnIndexes[j : j+numColumns : j+numColumns]
+			offsetIndex = file.offsetIndexes[j : j+numColumns : j+numColumns]
+		}
+		w.columnIndexes = append(w.columnIndexes, columnIndex)
+		w.offsetIndexes = append(w.offsetIndexes, offsetIndex)
+	}
+
+	w.schemaElements = append(w.schemaElements[:0], metadata.Schema...)
+
+	configured := w.metadata[:len(w.metadata):len(w.metadata)]
+	for _, kv := range metadata.KeyValueMetadata {
+		if _, exists := lookupKeyValueMetadata(configured, kv.Key); !exists {
+			w.metadata = append(w.metadata, kv)
+		}
+	}
+	sortKeyValueMetadata(w.metadata)
+
+	w.writer.offset = offset
+	return nil
+}
@@ -0,0 +1,235 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/segmentio/encoding/thrift"
+	"github.com/segmentio/parquet-go"
+	"github.com/segmentio/parquet-go/format"
+)
+
+func TestOpenAppender(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name,dict"`
+	}
+
+	path := filepath.Join(t.TempDir(), "data.parquet")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	writer := parquet.NewWriter(f, parquet.KeyValueMetadata("hour", "10"))
+	for i := 0; i < 100; i++ {
+		if err := writer.Write(&Row{ID: int64(i), Name: "initial"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := parquet.SchemaOf(Row{})
+
+	for n := 1; n <= 2; n++ {
+		options := []parquet.WriterOption{parquet.KeyValueMetadata("appended", "true")}
+		if n == 1 {
+			options = append(options, schema)
+		}
+		appender, err := parquet.OpenAppender(f, options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 50; i++ {
+			row := &Row{ID: int64(100*n + i), Name: "appended"}
+			if n == 1 {
+				err = appender.Write(row)
+			} else {
+				// Without a schema, the appender uses the schema of the file
+				// and rows have to be written in their parquet representation.
+				err = appender.WriteRow(schema.Deconstruct(nil, row))
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := appender.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	s, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := parquet.OpenFile(f, s.Size())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := p.NumRowGroups(); n != 3 {
+		t.Fatalf("wrong number of row groups: want=3 got=%d", n)
+	}
+	if columnIndexes, offsetIndexes := p.ColumnIndexes(), p.OffsetIndexes(); len(columnIndexes) != 6 || len(offsetIndexes) != 6 {
+		t.Fatalf("wrong number of page indexes: column=%d offset=%d", len(columnIndexes), len(offsetIndexes))
+	}
+	for key, want := range map[string]string{"hour": "10", "appended": "true"} {
+		if value, ok := p.Lookup(key); !ok || value != want {
+			t.Errorf("wrong metadata value for %q: want=%q got=%q", key, want, value)
+		}
+	}
+
+	reader := parquet.NewReader(p)
+	ids := make([]int64, 0, 200)
+	for {
+		row := Row{}
+		if err := reader.Read(&row); err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+		want := "initial"
+		if row.ID >= 100 {
+			want = "appended"
+		}
+		if row.Name != want {
+			t.Fatalf("wrong name for row %d: want=%q got=%q", row.ID, want, row.Name)
+		}
+		ids = append(ids, row.ID)
+	}
+
+	if len(ids) != 200 {
+		t.Fatalf("wrong number of rows: want=200 got=%d", len(ids))
+	}
+	for i, id := range ids {
+		want := int64(i)
+		if i >= 100 {
+			want = int64(100*((i-100)/50+1) + (i-100)%50)
+		}
+		if id != want {
+			t.Fatalf("wrong id at index %d: want=%d got=%d", i, want, id)
+		}
+	}
+}
+
+func TestOpenAppenderSchemaMismatch(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+	type OtherRow struct {
+		Name string `parquet:"name"`
+	}
+
+	f, err := os.Create(filepath.Join(t.TempDir(), "data.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	writer := parquet.NewWriter(f)
+	if err := writer.Write(&Row{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	s, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := parquet.OpenAppender(f, parquet.SchemaOf(OtherRow{})); err != parquet.ErrRowGroupSchemaMismatch {
+		t.Fatalf("expected a schema mismatch error, got %v", err)
+	}
+	if s2, err := f.Stat(); err != nil {
+		t.Fatal(err)
+	} else if s2.Size() != s.Size() {
+		t.Fatalf("the file was modified after failing to open the appender")
+	}
+}
+
+func TestOpenAppenderBloomFilterAfterPageIndex(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, parquet.BloomFilters(parquet.SplitBlockFilter("name")))
+	for i := 0; i < 100; i++ {
+		if err := writer.Write(&Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Move the bloom filter after the page index, where some writers place
+	// it; the file is left unchanged otherwise.
+	data := buffer.Bytes()
+	footerSize := int(binary.LittleEndian.Uint32(data[len(data)-8:]))
+	footerOffset := len(data) - (footerSize + 8)
+	metadata := format.FileMetaData{}
+	if err := thrift.Unmarshal(new(thrift.CompactProtocol), data[footerOffset:len(data)-8], &metadata); err != nil {
+		t.Fatal(err)
+	}
+
+	columns := metadata.RowGroups[0].Columns
+	bloomFilterEnd := columns[0].MetaData.DataPageOffset
+	bloomFilter := &columns[0].MetaData
+	for i := range columns {
+		if columns[i].MetaData.BloomFilterOffset != 0 {
+			bloomFilter = &columns[i].MetaData
+		}
+		if offset := columns[i].MetaData.DataPageOffset; offset < bloomFilterEnd {
+			bloomFilterEnd = offset
+		}
+	}
+	if bloomFilter.BloomFilterOffset == 0 {
+		t.Fatal("the file has no bloom filter")
+	}
+
+	file := append([]byte{}, data[:footerOffset]...)
+	file = append(file, data[bloomFilter.BloomFilterOffset:bloomFilterEnd]...)
+	bloomFilter.BloomFilterOffset = int64(footerOffset)
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &metadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file = append(file, footer...)
+	file = append(file, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(file[len(file)-4:], uint32(len(footer)))
+	file = append(file, "PAR1"...)
+
+	path := filepath.Join(t.TempDir(), "data.parquet")
+	if err := os.WriteFile(path, file, 0644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if _, err := parquet.OpenFile(f, int64(len(file))); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parquet.OpenAppender(f); err == nil {
+		t.Fatal("opening an appender which would overwrite the bloom filter did not fail")
+	}
+	if s, err := f.Stat(); err != nil {
+		t.Fatal(err)
+	} else if s.Size() != int64(len(file)) {
+		t.Fatalf("the file was modified after failing to open the appender")
+	}
+}
@@ -0,0 +1,141 @@
+package parquet
+
+// asyncRowGroups wraps the row groups passed as arguments so that pages of
+// their columns are read and decoded in background goroutines, with at most
+// concurrency pages being decoded at the same time.
+//
+// When a reader starts consuming the pages of a column, the pages of the same
+// column in the next row group start being prefetched, which allows the reader
+// to move from one row group to the next without waiting on the storage.
+func asyncRowGroups(rowGroups []RowGroup, concurrency int) []RowGroup {
+	sem := make(chan struct{}, concurrency)
+	async := make([]RowGroup, len(rowGroups))
+
+	var prev *asyncRowGroup
+	for i, rowGroup := range rowGroups {
+		g := &asyncRowGroup{
+			base:    rowGroup,
+			columns: make([]asyncColumnChunk, rowGroup.NumColumns()),
+		}
+		for j := range g.columns {
+			g.columns[j].base = rowGroup.Column(j)
+			g.columns[j].sem = sem
+			if prev != nil {
+				prev.columns[j].next = &g.columns[j]
+			}
+		}
+		async[i], prev = g, g
+	}
+
+	return async
+}
+
+type asyncRowGroup struct {
+	base    RowGroup
+	columns []asyncColumnChunk
+}
+
+func (g *asyncRowGroup) NumRows() int64                  { return g.base.NumRows() }
+func (g *asyncRowGroup) NumColumns() int                 { return len(g.columns) }
+func (g *asyncRowGroup) Column(i int) ColumnChunk        { return &g.columns[i] }
+func (g *asyncRowGroup) SortingColumns() []SortingColumn { return g.base.SortingColumns() }
+func (g *asyncRowGroup) Schema() *Schema                 { return g.base.Schema() }
+func (g *asyncRowGroup) Rows() Rows                      { return &rowGroupRowReader{rowGroup: g} }
+
+type asyncColumnChunk struct {
+	base ColumnChunk
+	sem  chan struct{}
+	// The column chunk of the next row group, and the pages prefetched from
+	// this column chunk, which are returned by the next call to Pages.
+	next       *asyncColumnChunk
+	prefetched *asyncPages
+}
+
+func (c *asyncColumnChunk) Type() Type               { return c.base.Type() }
+func (c *asyncColumnChunk) Column() int              { return c.base.Column() }
+func (c *asyncColumnChunk) ColumnIndex() ColumnIndex { return c.base.ColumnIndex() }
+func (c *asyncColumnChunk) OffsetIndex() OffsetIndex { return c.base.OffsetIndex() }
+func (c *asyncColumnChunk) BloomFilter() BloomFilter { return c.base.BloomFilter() }
+func (c *asyncColumnChunk) NumValues() int64         { return c.base.NumValues() }
+
+func (c *asyncColumnChunk) Pages() Pages {
+	if next := c.next; next != nil && next.prefetched == nil {
+		next.prefetched = next.pages()
+		next.prefetched.start()
+	}
+	if pages := c.prefetched; pages != nil {
+		c.prefetched = nil
+		return pages
+	}
+	return c.pages()
+}
+
+func (c *asyncColumnChunk) pages() *asyncPages {
+	return &asyncPages{base: c.base.Pages(), sem: c.sem}
+}
+
+// asyncPages reads pages from a base reader in a background goroutine, the
+// next page is read and decoded while the program consumes the current one.
+//
+// Each read is performed by a goroutine which delivers its result to a
+// buffered channel, so goroutines never block when the pages are abandoned by
+// the program before being read to completion.
+type asyncPages struct {
+	base Pages
+	sem  chan struct{}
+	next chan asyncPage
+}
+
+type asyncPage struct {
+	page Page
+	err  error
+}
+
+func (r *asyncPages) start() {
+	if r.next == nil {
+		r.next = make(chan asyncPage, 1)
+		go r.readPage(r.next)
+	}
+}
+
+func (r *asyncPages) readPage(next chan<- asyncPage) {
+	r.sem <- struct{}{}
+	defer func() { <-r.sem }()
+
+	p, err := r.base.ReadPage()
+	if err == nil {
+		// The base reader may reuse the page when it is called again, and the
+		// values of the page are decoded here to offload the work from the
+		// goroutine consuming the pages.
+		p = p.Buffer()
+	}
+	next <- asyncPage{page: p, err: err}
+}
+
+func (r *asyncPages) wait() asyncPage {
+	p := <-r.next
+	r.next = nil
+	return p
+}
+
+func (r *asyncPages) ReadPage() (Page, error) {
+	r.start()
+	p := r.wait()
+	if p.err == nil {
+		r.start()
+	}
+	return p.page, p.err
+}
+
+func (r *asyncPages) SeekToRow(rowIndex int64) error {
+	if r.next != nil {
+		r.wait()
+	}
+	return r.base.SeekToRow(rowIndex)
+}
+
+var (
+	_ RowGroup    = (*asyncRowGroup)(nil)
+	_ ColumnChunk = (*asyncColumnChunk)(nil)
+	_ Pages       = (*asyncPages)(nil)
+)
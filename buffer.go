@@ -176,7 +176,10 @@ func (buf *Buffer) Write(row interface{}) error {
 	defer func() {
 		clearValues(buf.rowbuf)
 	}()
-	buf.rowbuf = buf.schema.Deconstruct(buf.rowbuf[:0], row)
+	var err error
+	if buf.rowbuf, err = deconstructRow(buf.schema, buf.rowbuf[:0], row); err != nil {
+		return err
+	}
 	return buf.WriteRow(buf.rowbuf)
 }
 
@@ -207,6 +210,17 @@ func (buf *Buffer) WriteRow(row Row) error {
 	return nil
 }
 
+// WriteRows writes parquet rows to the buffer, returning the number of rows
+// written.
+func (buf *Buffer) WriteRows(rows []Row) (int, error) {
+	for i, row := range rows {
+		if err := buf.WriteRow(row); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
 // WriteRowGroup satisfies the RowGroupWriter interface.
 func (buf *Buffer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	rowGroupSchema := rowGroup.Schema()
@@ -241,6 +255,10 @@ func (w bufferWriter) WriteRow(row Row) error {
 	return w.buf.WriteRow(row)
 }
 
+func (w bufferWriter) WriteRows(rows []Row) (int, error) {
+	return w.buf.WriteRows(rows)
+}
+
 func (w bufferWriter) WriteValues(values []Value) (int, error) {
 	return w.buf.columns[values[0].Column()].WriteValues(values)
 }
@@ -252,9 +270,11 @@ func (w bufferWriter) WritePage(page Page) (int64, error) {
 var (
 	_ RowGroup       = (*Buffer)(nil)
 	_ RowGroupWriter = (*Buffer)(nil)
+	_ RowBatchWriter = (*Buffer)(nil)
 	_ sort.Interface = (*Buffer)(nil)
 
-	_ RowWriter   = (*bufferWriter)(nil)
-	_ PageWriter  = (*bufferWriter)(nil)
-	_ ValueWriter = (*bufferWriter)(nil)
+	_ RowWriter      = (*bufferWriter)(nil)
+	_ RowBatchWriter = (*bufferWriter)(nil)
+	_ PageWriter     = (*bufferWriter)(nil)
+	_ ValueWriter    = (*bufferWriter)(nil)
 )
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+
+	"github.com/segmentio/parquet-go"
+)
+
+var compactOptions struct {
+	output       string
+	rowGroupRows int64
+	rowGroupSize int64
+	tmpdir       string
+}
+
+func compactFlags(flags *flag.FlagSet) {
+	flags.StringVar(&compactOptions.output, "o", "", "path of the output file (default to stdout)")
+	flags.Int64Var(&compactOptions.rowGroupRows, "rows", 0, "maximum number of rows per row group")
+	flags.Int64Var(&compactOptions.rowGroupSize, "size", parquet.DefaultCompactRowGroupSize, "target size of row groups in bytes")
+	flags.StringVar(&compactOptions.tmpdir, "tmpdir", os.TempDir(), "directory of temporary files used to merge sorted rows and buffer pages")
+}
+
+func compact(w io.Writer, files []*parquet.File, _ *flag.FlagSet) error {
+	options := []parquet.WriterOption{
+		parquet.ColumnPageBuffers(parquet.NewFileBufferPool(compactOptions.tmpdir, "parquet-compact.*")),
+	}
+	if compactOptions.rowGroupRows > 0 {
+		options = append(options, parquet.MaxRowsPerRowGroup(compactOptions.rowGroupRows))
+	}
+	if compactOptions.rowGroupSize > 0 {
+		options = append(options, parquet.TargetRowGroupSize(compactOptions.rowGroupSize))
+	}
+
+	if compactOptions.output == "" {
+		return parquet.Compact(w, files, options...)
+	}
+
+	output, err := os.Create(compactOptions.output)
+	if err != nil {
+		return err
+	}
+	if err := parquet.Compact(output, files, options...); err != nil {
+		output.Close()
+		return err
+	}
+	return output.Close()
+}
@@ -0,0 +1,135 @@
+// Command parquet is a tool to inspect the content of parquet files.
+//
+// Usage:
+//
+//	parquet <command> [options] <file>
+//
+// The commands are:
+//
+//	schema     print the schema of the file in the parquet message format
+//	meta       print the file metadata, row groups and column chunks
+//	pages      print the headers of the pages of each column chunk
+//	head       print the first rows of the file as JSON lines
+//	cat        print all the rows of the file as JSON lines
+//	rowcount   print the number of rows in the file
+//	compact    merge the rows of multiple files into a single file
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/segmentio/parquet-go"
+)
+
+type command struct {
+	usage string
+	run   func(w io.Writer, f *parquet.File, flags *flag.FlagSet) error
+	flags func(flags *flag.FlagSet)
+	// Commands which operate on multiple files set runFiles instead of run.
+	runFiles func(w io.Writer, files []*parquet.File, flags *flag.FlagSet) error
+}
+
+var commands = map[string]command{
+	"schema":   {usage: "print the schema of the file in the parquet message format", run: schema},
+	"meta":     {usage: "print the file metadata, row groups and column chunks", run: meta},
+	"pages":    {usage: "print the headers of the pages of each column chunk", run: pages},
+	"head":     {usage: "print the first rows of the file as JSON lines", run: head, flags: headFlags},
+	"cat":      {usage: "print all the rows of the file as JSON lines", run: cat},
+	"rowcount": {usage: "print the number of rows in the file", run: rowcount},
+	"compact":  {usage: "merge the rows of multiple files into a single file", runFiles: compact, flags: compactFlags},
+}
+
+func main() {
+	if err := run(os.Stdout, os.Stderr, os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "parquet: %s\n", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(stdout, stderr io.Writer, args []string) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return flag.ErrHelp
+	}
+
+	name := args[0]
+	cmd, ok := commands[name]
+	if !ok {
+		usage(stderr)
+		return fmt.Errorf("unknown command: %q", name)
+	}
+
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	fileArgs := "<file>"
+	if cmd.runFiles != nil {
+		fileArgs = "<file>..."
+	}
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: parquet %s [options] %s\n\n%s\n", name, fileArgs, cmd.usage)
+		flags.PrintDefaults()
+	}
+	if cmd.flags != nil {
+		cmd.flags(flags)
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 || (cmd.runFiles == nil && flags.NArg() != 1) {
+		flags.Usage()
+		return flag.ErrHelp
+	}
+
+	files := make([]*parquet.File, 0, flags.NArg())
+	for _, path := range flags.Args() {
+		f, closeFile, err := openFile(path)
+		if err != nil {
+			return err
+		}
+		defer closeFile()
+		files = append(files, f)
+	}
+
+	if cmd.runFiles != nil {
+		return cmd.runFiles(stdout, files, flags)
+	}
+	return cmd.run(stdout, files[0], flags)
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "Usage: parquet <command> [options] <file>...\n\nCommands:\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].usage)
+	}
+}
+
+func openFile(path string) (*parquet.File, func() error, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	f, err := parquet.OpenFile(file, stat.Size())
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, file.Close, nil
+}
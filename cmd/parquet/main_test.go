@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const smallFile = "../../fixtures/small.parquet"
+
+func runCommand(t *testing.T, args ...string) string {
+	t.Helper()
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	if err := run(stdout, stderr, args); err != nil {
+		t.Fatalf("parquet %s: %v\n%s", strings.Join(args, " "), err, stderr)
+	}
+	return stdout.String()
+}
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		args     []string
+		contains []string
+	}{
+		{
+			args:     []string{"schema", smallFile},
+			contains: []string{"message parquet_go_root {", "required group baggage (LIST) {"},
+		},
+		{
+			args:     []string{"rowcount", smallFile},
+			contains: []string{"1297\n"},
+		},
+		{
+			args:     []string{"meta", smallFile},
+			contains: []string{"rows:        1297\n", "row groups:  1\n", "kafka_partition", "SNAPPY"},
+		},
+		{
+			args:     []string{"pages", smallFile},
+			contains: []string{"row group 0, column 0 (baggage.list.element.name):", "DATA_PAGE"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			output := runCommand(t, test.args...)
+			for _, s := range test.contains {
+				if !strings.Contains(output, s) {
+					t.Errorf("output does not contain %q:\n%s", s, output)
+				}
+			}
+		})
+	}
+}
+
+func TestHeadAndCat(t *testing.T) {
+	head := strings.Split(strings.TrimSuffix(runCommand(t, "head", "-n", "3", smallFile), "\n"), "\n")
+	if len(head) != 3 {
+		t.Fatalf("wrong number of rows printed by head: %d", len(head))
+	}
+
+	cat := strings.Split(strings.TrimSuffix(runCommand(t, "cat", smallFile), "\n"), "\n")
+	if len(cat) != 1297 {
+		t.Fatalf("wrong number of rows printed by cat: %d", len(cat))
+	}
+
+	for i, line := range head {
+		if line != cat[i] {
+			t.Errorf("row %d mismatch:\nhead: %s\ncat:  %s", i, line, cat[i])
+		}
+
+		row := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(line), &row); err != nil {
+			t.Fatalf("row %d is not valid JSON: %v", i, err)
+		}
+		if _, ok := row["kafka_partition"]; !ok {
+			t.Errorf("row %d is missing the kafka_partition column: %s", i, line)
+		}
+	}
+}
+
+func TestCompact(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "compact.parquet")
+
+	if s := runCommand(t, "compact", "-o", output, "-rows", "1000", smallFile, smallFile); s != "" {
+		t.Errorf("unexpected output when writing to a file:\n%s", s)
+	}
+	if s := runCommand(t, "rowcount", output); s != "2594\n" {
+		t.Errorf("wrong number of rows in the compacted file: %q", s)
+	}
+	if s := runCommand(t, "meta", output); !strings.Contains(s, "row groups:  3\n") {
+		t.Errorf("wrong number of row groups in the compacted file:\n%s", s)
+	}
+
+	// The row groups of small files are coalesced by default.
+	if s := runCommand(t, "compact", "-o", output, smallFile, smallFile); s != "" {
+		t.Errorf("unexpected output when writing to a file:\n%s", s)
+	}
+	if s := runCommand(t, "meta", output); !strings.Contains(s, "row groups:  1\n") {
+		t.Errorf("wrong number of row groups in the compacted file:\n%s", s)
+	}
+}
+
+func TestCommandErrors(t *testing.T) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+
+	if err := run(stdout, stderr, nil); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp without arguments but got %v", err)
+	}
+	if err := run(stdout, stderr, []string{"schema"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp without a file argument but got %v", err)
+	}
+	if err := run(stdout, stderr, []string{"compact"}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp without file arguments but got %v", err)
+	}
+	if err := run(stdout, stderr, []string{"schema", smallFile, smallFile}); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("expected flag.ErrHelp with multiple file arguments but got %v", err)
+	}
+	if err := run(stdout, stderr, []string{"bogus", smallFile}); err == nil {
+		t.Error("expected an error for an unknown command")
+	}
+	if err := run(stdout, stderr, []string{"schema", "does-not-exist.parquet"}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("unexpected output on failures:\n%s", stdout)
+	}
+}
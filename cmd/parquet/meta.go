@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/segmentio/parquet-go"
+	"github.com/segmentio/parquet-go/format"
+)
+
+func schema(w io.Writer, f *parquet.File, _ *flag.FlagSet) error {
+	root := f.Root()
+	if err := parquet.Print(w, root.Name(), root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func rowcount(w io.Writer, f *parquet.File, _ *flag.FlagSet) error {
+	_, err := fmt.Fprintln(w, f.Metadata().NumRows)
+	return err
+}
+
+func meta(w io.Writer, f *parquet.File, _ *flag.FlagSet) error {
+	metadata := f.Metadata()
+
+	fmt.Fprintf(w, "version:     %d\n", metadata.Version)
+	fmt.Fprintf(w, "created by:  %s\n", metadata.CreatedBy)
+	fmt.Fprintf(w, "size:        %d\n", f.Size())
+	fmt.Fprintf(w, "rows:        %d\n", metadata.NumRows)
+	fmt.Fprintf(w, "row groups:  %d\n", len(metadata.RowGroups))
+
+	if len(metadata.KeyValueMetadata) > 0 {
+		fmt.Fprintf(w, "metadata:\n")
+		for _, kv := range metadata.KeyValueMetadata {
+			fmt.Fprintf(w, "  %s = %s\n", kv.Key, kv.Value)
+		}
+	}
+
+	for i := range metadata.RowGroups {
+		rowGroup := &metadata.RowGroups[i]
+		fmt.Fprintf(w, "\nrow group %d: %d rows, %d bytes, %d compressed bytes\n",
+			i, rowGroup.NumRows, rowGroup.TotalByteSize, rowGroup.TotalCompressedSize)
+
+		t := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(t, "COLUMN\tTYPE\tCODEC\tENCODINGS\tVALUES\tNULLS\tCOMPRESSED\tUNCOMPRESSED")
+
+		for j := range rowGroup.Columns {
+			c := &rowGroup.Columns[j].MetaData
+			fmt.Fprintf(t, "%s\t%s\t%s\t%s\t%d\t%s\t%d\t%d\n",
+				strings.Join(c.PathInSchema, "."),
+				c.Type,
+				c.Codec,
+				joinEncodings(c.Encoding),
+				c.NumValues,
+				nullCount(&c.Statistics),
+				c.TotalCompressedSize,
+				c.TotalUncompressedSize,
+			)
+		}
+
+		if err := t.Flush(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func joinEncodings(encodings []format.Encoding) string {
+	s := make([]string, len(encodings))
+	for i, e := range encodings {
+		s[i] = e.String()
+	}
+	return strings.Join(s, ",")
+}
+
+func nullCount(stats *format.Statistics) string {
+	if stats.MinValue == nil && stats.MaxValue == nil && stats.NullCount == 0 && stats.Min == nil && stats.Max == nil {
+		return "-"
+	}
+	return fmt.Sprint(stats.NullCount)
+}
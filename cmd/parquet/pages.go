@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/segmentio/parquet-go"
+)
+
+func pages(w io.Writer, f *parquet.File, _ *flag.FlagSet) error {
+	paths := leafPaths(nil, nil, f.Root())
+
+	for i := 0; i < f.NumRowGroups(); i++ {
+		rowGroup := f.RowGroup(i)
+
+		for j := 0; j < rowGroup.NumColumns(); j++ {
+			path := strings.Join(paths[j], ".")
+			fmt.Fprintf(w, "row group %d, column %d (%s):\n", i, j, path)
+
+			if err := printPages(w, rowGroup.Column(j)); err != nil {
+				return fmt.Errorf("reading pages of column %q in row group %d: %w", path, i, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// leafPaths appends the paths of the leaf columns of col to paths, in the order
+// of their column indexes.
+func leafPaths(paths [][]string, path []string, col *parquet.Column) [][]string {
+	children := col.Columns()
+	if len(children) == 0 {
+		return append(paths, path)
+	}
+	for _, child := range children {
+		childPath := append(path[:len(path):len(path)], child.Name())
+		paths = leafPaths(paths, childPath, child)
+	}
+	return paths
+}
+
+func printPages(w io.Writer, chunk parquet.ColumnChunk) error {
+	t := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(t, "  PAGE\tTYPE\tENCODING\tVALUES\tNULLS\tSIZE\tCRC\tMIN\tMAX")
+
+	pages := chunk.Pages()
+	for i := 0; ; i++ {
+		p, err := pages.ReadPage()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+
+		if i == 0 {
+			if dict := p.Dictionary(); dict != nil {
+				fmt.Fprintf(t, "  -\tDICTIONARY_PAGE\t-\t%d\t-\t-\t-\t-\t-\n", dict.Len())
+			}
+		}
+
+		pageType, encoding, crc, size := "-", "-", "-", "-"
+		if compressed, ok := p.(parquet.CompressedPage); ok {
+			header := compressed.PageHeader()
+			pageType = header.PageType().String()
+			encoding = header.Encoding().String()
+			size = fmt.Sprint(compressed.PageSize())
+			if checksum := compressed.CRC(); checksum != 0 {
+				crc = fmt.Sprintf("0x%08X", checksum)
+			}
+		}
+
+		min, max := p.Bounds()
+		fmt.Fprintf(t, "  %d\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			i,
+			pageType,
+			encoding,
+			p.NumValues(),
+			p.NumNulls(),
+			size,
+			crc,
+			formatValue(min),
+			formatValue(max),
+		)
+	}
+
+	return t.Flush()
+}
+
+// formatValue returns a representation of v in page listings, byte arrays are
+// quoted and truncated since they may be arbitrarily long binary values.
+func formatValue(v parquet.Value) string {
+	const maxLength = 32
+
+	switch {
+	case v.IsNull():
+		return "-"
+	case v.Kind() == parquet.ByteArray || v.Kind() == parquet.FixedLenByteArray:
+		b := v.ByteArray()
+		if len(b) > maxLength {
+			return fmt.Sprintf("%q...", b[:maxLength])
+		}
+		return fmt.Sprintf("%q", b)
+	default:
+		return v.String()
+	}
+}
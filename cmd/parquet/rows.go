@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+
+	"github.com/segmentio/parquet-go"
+)
+
+var headRows int
+
+func headFlags(flags *flag.FlagSet) {
+	flags.IntVar(&headRows, "n", 10, "number of rows to print")
+}
+
+func head(w io.Writer, f *parquet.File, _ *flag.FlagSet) error {
+	return printRows(w, f, int64(headRows))
+}
+
+func cat(w io.Writer, f *parquet.File, _ *flag.FlagSet) error {
+	return printRows(w, f, -1)
+}
+
+// printRows writes up to limit rows of f to w as JSON lines, or all the rows if
+// limit is negative.
+func printRows(w io.Writer, f *parquet.File, limit int64) error {
+	reader := parquet.NewReader(f)
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	for n := int64(0); limit < 0 || n < limit; n++ {
+		row := make(map[string]interface{})
+		if err := reader.ReadMap(row); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return err
+		}
+		if err := enc.Encode(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -52,6 +52,10 @@ func (c *Column) Repeated() bool { return schemaRepetitionTypeOf(c.schema) == fo
 // Required returns true if the column is required.
 func (c *Column) Required() bool { return schemaRepetitionTypeOf(c.schema) == format.Required }
 
+// ID returns the field ID of the column, or zero if the column has no field
+// ID.
+func (c *Column) ID() int { return int(c.schema.FieldID) }
+
 // NumChildren returns the number of child columns.
 //
 // This method contributes to satisfying the Node interface.
@@ -208,22 +212,15 @@ func (c *Column) GoType() reflect.Type { return goTypeOf(c) }
 // String returns a human-readable string representation of the column.
 func (c *Column) String() string { return c.path.String() + ": " + sprint(c.Name(), c) }
 
-func (c *Column) forEachLeaf(do func(*Column)) {
-	if len(c.columns) == 0 {
-		do(c)
-	} else {
-		for _, child := range c.columns {
-			child.forEachLeaf(do)
-		}
-	}
-}
-
-func openColumns(file *File) (*Column, error) {
+// openColumns loads the column tree of file. The leaf columns are also returned
+// in the order of their column chunks in row groups, which differs from the
+// order of column indexes since children are sorted by name.
+func openColumns(file *File) (*Column, []*Column, error) {
 	cl := columnLoader{}
 
 	c, err := cl.open(file, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Validate that there aren't extra entries in the row group columns,
@@ -231,13 +228,13 @@ func openColumns(file *File) (*Column, error) {
 	// in the file.
 	for index, rowGroup := range file.metadata.RowGroups {
 		if cl.rowGroupColumnIndex != len(rowGroup.Columns) {
-			return nil, fmt.Errorf("row group at index %d contains %d columns but %d were referenced by the column schemas",
+			return nil, nil, fmt.Errorf("row group at index %d contains %d columns but %d were referenced by the column schemas",
 				index, len(rowGroup.Columns), cl.rowGroupColumnIndex)
 		}
 	}
 
 	_, err = c.setLevels(0, 0, 0, 0)
-	return c, err
+	return c, cl.leaves, err
 }
 
 func (c *Column) setLevels(depth, repetition, definition, index int) (int, error) {
@@ -287,6 +284,7 @@ type columnLoader struct {
 	schemaIndex         int
 	columnOrderIndex    int
 	rowGroupColumnIndex int
+	leaves              []*Column
 }
 
 func (cl *columnLoader) open(file *File, path []string) (*Column, error) {
@@ -310,6 +308,7 @@ func (cl *columnLoader) open(file *File, path []string) (*Column, error) {
 		rowGroups := file.metadata.RowGroups
 		rowGroupColumnIndex := cl.rowGroupColumnIndex
 		cl.rowGroupColumnIndex++
+		cl.leaves = append(cl.leaves, c)
 
 		c.chunks = make([]*format.ColumnChunk, 0, len(rowGroups))
 		c.columnIndex = make([]*format.ColumnIndex, 0, len(rowGroups))
@@ -358,7 +357,7 @@ func (cl *columnLoader) open(file *File, path []string) (*Column, error) {
 		return c, nil
 	}
 
-	c.typ = &groupType{}
+	c.typ = schemaElementGroupTypeOf(c.schema)
 	c.names = make([]string, numChildren)
 	c.columns = make([]*Column, numChildren)
 
@@ -442,8 +441,9 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 		case lt.Enum != nil:
 			return (*enumType)(lt.Enum)
 		case lt.Decimal != nil:
-			// TODO:
-			// return (*decimalType)(lt.Decimal)
+			if t := schemaElementPhysicalTypeOf(s); t != nil {
+				return &decimalType{decimal: *lt.Decimal, Type: t}
+			}
 		case lt.Date != nil:
 			return (*dateType)(lt.Date)
 		case lt.Time != nil:
@@ -460,6 +460,8 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 			return (*bsonType)(lt.Bson)
 		case lt.UUID != nil:
 			return (*uuidType)(lt.UUID)
+		case lt.Float16 != nil:
+			return (*float16Type)(lt.Float16)
 		}
 	}
 
@@ -479,7 +481,16 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 		case deprecated.Enum:
 			return &enumType{}
 		case deprecated.Decimal:
-			// TODO
+			if t := schemaElementPhysicalTypeOf(s); t != nil {
+				d := &decimalType{Type: t}
+				if s.Scale != nil {
+					d.decimal.Scale = *s.Scale
+				}
+				if s.Precision != nil {
+					d.decimal.Precision = *s.Precision
+				}
+				return d
+			}
 		case deprecated.Date:
 			return &dateType{}
 		case deprecated.TimeMillis:
@@ -511,13 +522,50 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 		case deprecated.Bson:
 			return &bsonType{}
 		case deprecated.Interval:
-			// TODO
+			if s.Type != nil && *s.Type == format.FixedLenByteArray && s.TypeLength != nil && *s.TypeLength == 12 {
+				return IntervalType
+			}
 		}
 	}
 
-	if t := s.Type; t != nil {
-		// The column only has a physical type, convert it to one of the
+	if t := schemaElementPhysicalTypeOf(s); t != nil {
+		// The column only has a physical type, it is represented by one of the
 		// primitive types supported by this package.
+		return t
+	}
+
+	// If we reach this point, we are likely reading a parquet column that was
+	// written with a non-standard type or is in a newer version of the format
+	// than this package supports.
+	return &nullType{}
+}
+
+// schemaElementGroupTypeOf returns the type of the group s, which retains the
+// LIST and MAP logical types so the nested structure of the group is known.
+func schemaElementGroupTypeOf(s *format.SchemaElement) Type {
+	if lt := s.LogicalType; lt != nil {
+		switch {
+		case lt.List != nil:
+			return (*listType)(lt.List)
+		case lt.Map != nil:
+			return (*mapType)(lt.Map)
+		}
+	}
+	if ct := s.ConvertedType; ct != nil {
+		switch *ct {
+		case deprecated.List:
+			return &listType{}
+		case deprecated.Map:
+			return &mapType{}
+		}
+	}
+	return &groupType{}
+}
+
+// schemaElementPhysicalTypeOf returns the primitive type of s, or nil if s has
+// no physical type.
+func schemaElementPhysicalTypeOf(s *format.SchemaElement) Type {
+	if t := s.Type; t != nil {
 		switch kind := Kind(*t); kind {
 		case Boolean:
 			return BooleanType
@@ -539,11 +587,7 @@ func schemaElementTypeOf(s *format.SchemaElement) Type {
 			}
 		}
 	}
-
-	// If we reach this point, we are likely reading a parquet column that was
-	// written with a non-standard type or is in a newer version of the format
-	// than this package supports.
-	return &nullType{}
+	return nil
 }
 
 func schemaRepetitionTypeOf(s *format.SchemaElement) format.FieldRepetitionType {
@@ -40,3 +40,42 @@ func TestOptionalDictWriteRowGroup(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRepeatedColumnBufferWriteValues(t *testing.T) {
+	type Row struct {
+		Tags []string `parquet:"tags"`
+	}
+
+	s := parquet.SchemaOf(Row{})
+	records := []Row{
+		{Tags: []string{"a", "b"}},
+		{Tags: nil},
+		{Tags: []string{"c"}},
+		{Tags: []string{"d", "e", "f"}},
+	}
+
+	var values []parquet.Value
+	for i := range records {
+		values = s.Deconstruct(values, &records[i])
+	}
+
+	buf := parquet.NewBuffer(s)
+	col := buf.Column(0).(parquet.ColumnBuffer)
+	if _, err := col.WriteValues(values); err != nil {
+		t.Fatal(err)
+	}
+	if n := col.Len(); n != len(records) {
+		t.Fatalf("wrong number of rows in column buffer: want=%d got=%d", len(records), n)
+	}
+
+	rows := buf.Rows()
+	for i := range records {
+		row, err := rows.ReadRow(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := s.Deconstruct(nil, &records[i]); !want.Equal(row) {
+			t.Fatalf("row %d mismatch:\nwant = %+v\ngot  = %+v", i, want, row)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package parquet
 
 import (
+	"fmt"
 	"io"
 )
 
@@ -122,6 +123,33 @@ func (r *columnChunkReader) readValues() error {
 	}
 }
 
+// readRows appends the values of the next rows of the column to the rows
+// passed as argument, returning the number of rows that values were appended
+// to. The method may only be used when the values that each row holds for the
+// column are contiguous, see rowValuesAreContiguous.
+func (r *columnChunkReader) readRows(rows []Row) (int, error) {
+	n := 0
+
+	for {
+		for _, v := range r.buffer[r.offset:] {
+			if v.repetitionLevel == 0 {
+				if n == len(rows) {
+					return n, nil
+				}
+				n++
+			} else if n == 0 {
+				return 0, fmt.Errorf("reading rows of column %d: value with repetition level %d does not start a row", r.column.Column(), v.repetitionLevel)
+			}
+			rows[n-1] = append(rows[n-1], v)
+			r.offset++
+		}
+
+		if err := r.readValues(); err != nil {
+			return n, err
+		}
+	}
+}
+
 func (r *columnChunkReader) readValuesFromCurrentPage() error {
 	if r.offset < len(r.buffer) {
 		return nil
@@ -0,0 +1,229 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// DefaultCompactRowGroupSize is the target size of row groups written by
+// Compact when the TargetRowGroupSize option is not set.
+const DefaultCompactRowGroupSize = 128 * 1024 * 1024
+
+// compactMergeWidth is the maximum number of row groups merged at once by
+// Compact. When there are more sorted row groups to merge, they are merged in
+// multiple passes through temporary files, which bounds the memory needed to
+// hold the pages of each row group being merged.
+const compactMergeWidth = 32
+
+// Compact writes the rows of the source files to dst as a single parquet file.
+//
+// The schema of the output is the one configured with the Schema option, or
+// the result of calling MergeSchemas on the schemas of the source files, rows
+// of files with different schemas are converted as if by calling Convert.
+//
+// When all the row groups of the source files share a prefix of sorting
+// columns, or when the SortingColumns option is set, the rows are merged
+// with MergeRowGroups and the output is sorted. Otherwise, rows are written in
+// the order of the files and their row groups. Large numbers of sorted row
+// groups are merged in multiple passes to bound the memory footprint, through
+// temporary files created in the default directory for temporary files, or
+// obtained from the ColumnPageBuffers pool when the option is set.
+//
+// The size of row groups written to dst is controlled by the
+// TargetRowGroupSize and MaxRowsPerRowGroup options, the target size defaults
+// to DefaultCompactRowGroupSize. Rows of small row groups are coalesced into
+// row groups of the target size, while row groups of unsorted files which are
+// at least half the target size, and within the row limit, are written
+// unchanged, copying their column chunks without re-encoding them when
+// possible (see Writer.WriteRowGroup).
+func Compact(dst io.Writer, srcs []*File, options ...WriterOption) error {
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		return err
+	}
+	if config.TargetRowGroupSize == DefaultTargetRowGroupSize {
+		config.TargetRowGroupSize = DefaultCompactRowGroupSize
+	}
+
+	schemas := make([]*Schema, len(srcs))
+	rowGroups := make([]RowGroup, 0, len(srcs))
+	for i, f := range srcs {
+		schemas[i] = NewSchema(f.root.Name(), f.root)
+		for j := range f.rowGroups {
+			rowGroups = append(rowGroups, &f.rowGroups[j])
+		}
+	}
+
+	if config.Schema == nil {
+		if len(srcs) == 0 {
+			return fmt.Errorf("parquet.Compact: no files to compact")
+		}
+		if config.Schema, err = MergeSchemas(schemas...); err != nil {
+			return fmt.Errorf("parquet.Compact: %w", err)
+		}
+	}
+
+	sorting := config.SortingColumns
+	if sorting == nil {
+		sorting = commonSortingColumns(config.Schema, rowGroups)
+	}
+
+	c := compactor{config: config, sorting: sorting, pool: config.ColumnPageBuffers}
+	if c.pool == PageBufferPool(&defaultPageBufferPool) {
+		c.pool = NewFileBufferPool(os.TempDir(), "parquet-compact.*")
+	}
+	defer c.release()
+
+	output := NewWriter(dst, config)
+	output.writer.sortingColumns = sorting
+
+	if len(sorting) == 0 {
+		for _, rowGroup := range rowGroups {
+			if g, ok := rowGroup.(*fileRowGroup); ok && c.canCopy(g) && nodesAreEqual(config.Schema, g.Schema()) && output.writer.canCopyFileRowGroup(g) {
+				_, err = output.WriteRowGroup(g)
+			} else {
+				_, err = CopyRows(output, compactRows{rowGroup.Rows()})
+			}
+			if err != nil {
+				return err
+			}
+		}
+	} else if len(rowGroups) > 0 {
+		for len(rowGroups) > compactMergeWidth {
+			if rowGroups, err = c.spill(rowGroups); err != nil {
+				return err
+			}
+		}
+		merged, err := c.merge(rowGroups)
+		if err != nil {
+			return err
+		}
+		if _, err := CopyRows(output, compactRows{merged.Rows()}); err != nil {
+			return err
+		}
+	}
+
+	return output.Close()
+}
+
+// compactRows hides the WriteRowsTo method of row readers, which may write the
+// rows of a row group as a single row group of the output instead of applying
+// the row group size limits configured on the writer.
+type compactRows struct{ RowReaderWithSchema }
+
+type compactor struct {
+	config  *WriterConfig
+	sorting []SortingColumn
+	pool    PageBufferPool
+	spills  []io.ReadWriter
+}
+
+// canCopy returns true if the row group is large enough to be written as-is,
+// which lets the writer copy its column chunks without decoding the pages;
+// the rows of smaller row groups are coalesced into row groups of the target
+// size instead.
+func (c *compactor) canCopy(rowGroup *fileRowGroup) bool {
+	if rowGroup.NumRows() > c.config.MaxRowsPerRowGroup {
+		return false
+	}
+	size := int64(0)
+	for i := range rowGroup.rowGroup.Columns {
+		size += rowGroup.rowGroup.Columns[i].MetaData.TotalCompressedSize
+	}
+	return size >= c.config.TargetRowGroupSize/2
+}
+
+func (c *compactor) merge(rowGroups []RowGroup) (RowGroup, error) {
+	return MergeRowGroups(rowGroups, c.config.Schema, SortingColumns(c.sorting...))
+}
+
+// spill merges groups of row groups into a temporary file, each group of up to
+// compactMergeWidth row groups becomes a single row group of the file. The
+// function returns the row groups of the temporary file.
+func (c *compactor) spill(rowGroups []RowGroup) ([]RowGroup, error) {
+	config := *c.config
+	config.BloomFilters = nil
+	config.KeyValueMetadata = nil
+	config.Encryption = nil
+	// Each merged group becomes a single row group of the temporary file, its
+	// pages are buffered in temporary files as well.
+	config.ColumnPageBuffers = c.pool
+
+	buffer := c.pool.GetPageBuffer()
+	c.spills = append(c.spills, buffer)
+
+	output := offsetTrackingWriter{}
+	output.Reset(buffer)
+	writer := NewWriter(&output, &config)
+
+	for len(rowGroups) > 0 {
+		n := compactMergeWidth
+		if n > len(rowGroups) {
+			n = len(rowGroups)
+		}
+		merged, err := c.merge(rowGroups[:n])
+		if err != nil {
+			return nil, err
+		}
+		if _, err := writer.WriteRowGroup(merged); err != nil {
+			return nil, err
+		}
+		rowGroups = rowGroups[n:]
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	spill, err := spillReaderAt(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := OpenFile(spill, output.offset)
+	if err != nil {
+		return nil, fmt.Errorf("opening merged rows spilled to temporary storage: %w", err)
+	}
+
+	rowGroups = make([]RowGroup, file.NumRowGroups())
+	for i := range rowGroups {
+		rowGroups[i] = file.RowGroup(i)
+	}
+	return rowGroups, nil
+}
+
+func (c *compactor) release() {
+	for _, buffer := range c.spills {
+		c.pool.PutPageBuffer(buffer)
+	}
+	c.spills = nil
+}
+
+// commonSortingColumns returns the longest list of sorting columns which is a
+// prefix of the sorting columns of all the row groups, and which exist in the
+// schema.
+func commonSortingColumns(schema *Schema, rowGroups []RowGroup) []SortingColumn {
+	if len(rowGroups) == 0 {
+		return nil
+	}
+
+	sorting := rowGroups[0].SortingColumns()
+	for _, rowGroup := range rowGroups[1:] {
+		n := 0
+		for _, sortingColumn := range rowGroup.SortingColumns() {
+			if n == len(sorting) || !sortingColumnsAreEqual(sorting[n], sortingColumn) {
+				break
+			}
+			n++
+		}
+		sorting = sorting[:n]
+	}
+
+	for i, sortingColumn := range sorting {
+		if !hasColumnPath(schema, sortingColumn.Path()) {
+			return sorting[:i]
+		}
+	}
+	return sorting
+}
@@ -0,0 +1,290 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+type compactRow struct {
+	Key   int64  `parquet:"key"`
+	Value string `parquet:"value"`
+}
+
+func compactFiles(t *testing.T, srcs []*parquet.File, options ...parquet.WriterOption) *parquet.File {
+	t.Helper()
+	buffer := new(bytes.Buffer)
+	if err := parquet.Compact(buffer, srcs, options...); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func readCompactRows(t *testing.T, f *parquet.File) []compactRow {
+	t.Helper()
+	reader := parquet.NewReader(f)
+	rows := make([]compactRow, 0, f.Metadata().NumRows)
+	for {
+		row := compactRow{}
+		if err := reader.Read(&row); err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			return rows
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestCompact(t *testing.T) {
+	srcs := make([]*parquet.File, 5)
+	want := make([]compactRow, 0, 100*len(srcs))
+
+	for i := range srcs {
+		rows := make([]compactRow, 100)
+		for j := range rows {
+			rows[j] = compactRow{Key: int64(100*i + j), Value: fmt.Sprint(i, j)}
+		}
+		f, err := createParquetFile(makeRows(rows))
+		if err != nil {
+			t.Fatal(err)
+		}
+		srcs[i] = f
+		want = append(want, rows...)
+	}
+
+	f := compactFiles(t, srcs, parquet.MaxRowsPerRowGroup(150))
+
+	if n := f.NumRowGroups(); n != 4 {
+		t.Errorf("wrong number of row groups: want=4 got=%d", n)
+	}
+	for i := 0; i < f.NumRowGroups(); i++ {
+		if sorting := f.RowGroup(i).SortingColumns(); len(sorting) != 0 {
+			t.Errorf("row group %d has unexpected sorting columns: %v", i, sorting)
+		}
+	}
+
+	found := readCompactRows(t, f)
+	if len(found) != len(want) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(want), len(found))
+	}
+	for i := range want {
+		if found[i] != want[i] {
+			t.Fatalf("row %d mismatch: want=%+v got=%+v", i, want[i], found[i])
+		}
+	}
+}
+
+func TestCompactCopyRowGroups(t *testing.T) {
+	srcs := make([]*parquet.File, 3)
+	want := make([]compactRow, 0, 100*len(srcs))
+
+	for i := range srcs {
+		rows := make([]compactRow, 100)
+		for j := range rows {
+			rows[j] = compactRow{Key: int64(100*i + j), Value: fmt.Sprint(i, j)}
+		}
+		f, err := createParquetFile(makeRows(rows))
+		if err != nil {
+			t.Fatal(err)
+		}
+		srcs[i] = f
+		want = append(want, rows...)
+	}
+
+	// Row groups which are at least half the target size are copied as-is,
+	// re-encoding their rows would cut many more row groups.
+	f := compactFiles(t, srcs, parquet.TargetRowGroupSize(1))
+
+	if n := f.NumRowGroups(); n != len(srcs) {
+		t.Errorf("wrong number of row groups: want=%d got=%d", len(srcs), n)
+	}
+
+	found := readCompactRows(t, f)
+	if len(found) != len(want) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(want), len(found))
+	}
+	for i := range want {
+		if found[i] != want[i] {
+			t.Fatalf("row %d mismatch: want=%+v got=%+v", i, want[i], found[i])
+		}
+	}
+}
+
+func TestCompactCoalesceRowGroups(t *testing.T) {
+	srcs := make([]*parquet.File, 3)
+	want := make([]compactRow, 0, 100*len(srcs))
+
+	for i := range srcs {
+		rows := make([]compactRow, 100)
+		for j := range rows {
+			rows[j] = compactRow{Key: int64(100*i + j), Value: fmt.Sprint(i, j)}
+		}
+		f, err := createParquetFile(makeRows(rows))
+		if err != nil {
+			t.Fatal(err)
+		}
+		srcs[i] = f
+		want = append(want, rows...)
+	}
+
+	// The rows of small row groups are coalesced into row groups of the
+	// default target size.
+	f := compactFiles(t, srcs)
+
+	if n := f.NumRowGroups(); n != 1 {
+		t.Errorf("wrong number of row groups: want=1 got=%d", n)
+	}
+
+	found := readCompactRows(t, f)
+	if len(found) != len(want) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(want), len(found))
+	}
+	for i := range want {
+		if found[i] != want[i] {
+			t.Fatalf("row %d mismatch: want=%+v got=%+v", i, want[i], found[i])
+		}
+	}
+}
+
+// makeSortedCompactFiles creates more files than can be merged at once by
+// Compact, to exercise merging in multiple passes through temporary files.
+func makeSortedCompactFiles(t *testing.T) (srcs []*parquet.File, numRows int) {
+	t.Helper()
+	prng := rand.New(rand.NewSource(0))
+	srcs = make([]*parquet.File, 70)
+
+	for i := range srcs {
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewSortingWriter(buffer, 1000, parquet.SortingColumns(parquet.Ascending("key")))
+		for j := 0; j < 20; j++ {
+			if err := writer.Write(&compactRow{Key: prng.Int63n(1000), Value: fmt.Sprint(i, j)}); err != nil {
+				t.Fatal(err)
+			}
+			numRows++
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		srcs[i] = f
+	}
+	return srcs, numRows
+}
+
+func TestCompactSorted(t *testing.T) {
+	srcs, numRows := makeSortedCompactFiles(t)
+
+	tmpdir := t.TempDir()
+	f := compactFiles(t, srcs,
+		parquet.ColumnPageBuffers(parquet.NewFileBufferPool(tmpdir, "compact.*")),
+		parquet.MaxRowsPerRowGroup(500),
+	)
+
+	if entries, err := os.ReadDir(tmpdir); err != nil {
+		t.Fatal(err)
+	} else if len(entries) != 0 {
+		t.Errorf("%d temporary files were not removed", len(entries))
+	}
+
+	if n := f.NumRowGroups(); n != 3 {
+		t.Errorf("wrong number of row groups: want=3 got=%d", n)
+	}
+	for i := 0; i < f.NumRowGroups(); i++ {
+		if sorting := f.RowGroup(i).SortingColumns(); len(sorting) != 1 || sorting[0].Path()[0] != "key" {
+			t.Errorf("row group %d has wrong sorting columns: %v", i, sorting)
+		}
+	}
+
+	found := readCompactRows(t, f)
+	if len(found) != numRows {
+		t.Fatalf("wrong number of rows: want=%d got=%d", numRows, len(found))
+	}
+	if !sort.SliceIsSorted(found, func(i, j int) bool { return found[i].Key < found[j].Key }) {
+		t.Error("rows are not sorted")
+	}
+}
+
+func TestCompactSortedDefaultOptions(t *testing.T) {
+	srcs, numRows := makeSortedCompactFiles(t)
+
+	// Without the ColumnPageBuffers option, the rows are merged through files
+	// created in the default directory for temporary files.
+	tmpdir := t.TempDir()
+	t.Setenv("TMPDIR", tmpdir)
+
+	f := compactFiles(t, srcs)
+
+	if entries, err := os.ReadDir(tmpdir); err != nil {
+		t.Fatal(err)
+	} else if len(entries) != 0 {
+		t.Errorf("%d temporary files were not removed", len(entries))
+	}
+	if n := f.NumRowGroups(); n != 1 {
+		t.Errorf("wrong number of row groups: want=1 got=%d", n)
+	}
+
+	found := readCompactRows(t, f)
+	if len(found) != numRows {
+		t.Fatalf("wrong number of rows: want=%d got=%d", numRows, len(found))
+	}
+	if !sort.SliceIsSorted(found, func(i, j int) bool { return found[i].Key < found[j].Key }) {
+		t.Error("rows are not sorted")
+	}
+}
+
+func TestCompactSchemaEvolution(t *testing.T) {
+	type RowV1 struct {
+		Key int32 `parquet:"key"`
+	}
+
+	f1, err := createParquetFile(makeRows([]RowV1{{Key: 1}, {Key: 2}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f2, err := createParquetFile(makeRows([]compactRow{{Key: 3, Value: "C"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := compactFiles(t, []*parquet.File{f1, f2})
+
+	want := []string{
+		`map[key:1 value:<nil>]`,
+		`map[key:2 value:<nil>]`,
+		`map[key:3 value:C]`,
+	}
+
+	reader := parquet.NewReader(f)
+	for i := range want {
+		row := make(map[string]interface{})
+		if err := reader.ReadMap(row); err != nil {
+			t.Fatalf("reading row %d: %v", i, err)
+		}
+		if got := fmt.Sprint(row); got != want[i] {
+			t.Errorf("row %d mismatch:\nwant: %s\ngot:  %s", i, want[i], got)
+		}
+	}
+	if err := reader.ReadMap(make(map[string]interface{})); err != io.EOF {
+		t.Errorf("expected io.EOF after the last row but got %v", err)
+	}
+}
+
+func TestCompactNoFiles(t *testing.T) {
+	if err := parquet.Compact(new(bytes.Buffer), nil); err == nil {
+		t.Error("expected an error when compacting no files without a schema")
+	}
+}
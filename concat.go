@@ -47,7 +47,84 @@ func (c *concatenatedRowGroup) SortingColumns() []SortingColumn { return nil }
 
 func (c *concatenatedRowGroup) Schema() *Schema { return c.schema }
 
-func (c *concatenatedRowGroup) Rows() Rows { return &rowGroupRowReader{rowGroup: c} }
+func (c *concatenatedRowGroup) Rows() Rows {
+	for _, rowGroup := range c.rowGroups {
+		if !canProjectColumnChunks(rowGroup) {
+			// The rows of this row group are not the values of its column
+			// chunks (e.g. converted row groups), they must be read from the
+			// row readers of each row group.
+			return &concatenatedRows{rowGroup: c}
+		}
+	}
+	return &rowGroupRowReader{rowGroup: c}
+}
+
+// concatenatedRows reads the rows of a concatenated row group from the row
+// readers of the underlying row groups, one after the other.
+type concatenatedRows struct {
+	rowGroup *concatenatedRowGroup
+	index    int
+	rows     Rows
+}
+
+func (r *concatenatedRows) next() bool {
+	if r.rows == nil {
+		if r.index == len(r.rowGroup.rowGroups) {
+			return false
+		}
+		r.rows = r.rowGroup.rowGroups[r.index].Rows()
+	}
+	return true
+}
+
+func (r *concatenatedRows) done() {
+	r.rows = nil
+	r.index++
+}
+
+func (r *concatenatedRows) ReadRow(row Row) (Row, error) {
+	n := len(row)
+	for r.next() {
+		var err error
+		row, err = r.rows.ReadRow(row[:n])
+		if err != io.EOF {
+			return row, err
+		}
+		r.done()
+	}
+	return row[:n], io.EOF
+}
+
+func (r *concatenatedRows) ReadRows(rows []Row) (int, error) {
+	for r.next() {
+		n, err := readRows(r.rows, rows)
+		if err == io.EOF {
+			r.done()
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+	return 0, io.EOF
+}
+
+func (r *concatenatedRows) SeekToRow(rowIndex int64) error {
+	r.rows, r.index = nil, 0
+	for r.index < len(r.rowGroup.rowGroups) {
+		numRows := r.rowGroup.rowGroups[r.index].NumRows()
+		if rowIndex < numRows {
+			r.next()
+			return r.rows.SeekToRow(rowIndex)
+		}
+		rowIndex -= numRows
+		r.index++
+	}
+	return nil
+}
+
+func (r *concatenatedRows) Schema() *Schema { return r.rowGroup.schema }
 
 type concatenatedColumnChunk struct {
 	rowGroup *concatenatedRowGroup
@@ -201,3 +278,7 @@ func (r *concatenatedPages) SeekToRow(rowIndex int64) error {
 	}
 	return nil
 }
+
+var (
+	_ RowBatchReader = (*concatenatedRows)(nil)
+)
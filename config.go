@@ -2,6 +2,7 @@ package parquet
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -14,6 +15,10 @@ const (
 	DefaultDataPageStatistics   = false
 	DefaultSkipPageIndex        = false
 	DefaultSkipBloomFilters     = false
+	DefaultReadConcurrency      = 1
+	DefaultWriteConcurrency     = 1
+	DefaultMaxRowsPerRowGroup   = math.MaxInt64
+	DefaultTargetRowGroupSize   = math.MaxInt64
 )
 
 // The FileConfig type carries configuration options for parquet files.
@@ -29,6 +34,8 @@ const (
 type FileConfig struct {
 	SkipPageIndex    bool
 	SkipBloomFilters bool
+	KeyRetriever     KeyRetriever
+	AADPrefix        []byte
 }
 
 // DefaultFileConfig returns a new FileConfig value initialized with the
@@ -63,6 +70,8 @@ func (c *FileConfig) ConfigureFile(config *FileConfig) {
 	*config = FileConfig{
 		SkipPageIndex:    config.SkipPageIndex,
 		SkipBloomFilters: config.SkipBloomFilters,
+		KeyRetriever:     coalesceKeyRetriever(c.KeyRetriever, config.KeyRetriever),
+		AADPrefix:        coalesceBytes(c.AADPrefix, config.AADPrefix),
 	}
 }
 
@@ -81,13 +90,18 @@ func (c *FileConfig) Validate() error {
 //	})
 //
 type ReaderConfig struct {
-	Schema *Schema
+	Schema          *Schema
+	Filter          Filter
+	Columns         []string
+	ReadConcurrency int
 }
 
 // DefaultReaderConfig returns a new ReaderConfig value initialized with the
 // default reader configuration.
 func DefaultReaderConfig() *ReaderConfig {
-	return &ReaderConfig{}
+	return &ReaderConfig{
+		ReadConcurrency: DefaultReadConcurrency,
+	}
 }
 
 // NewReaderConfig constructs a new reader configuration applying the options
@@ -111,13 +125,19 @@ func (c *ReaderConfig) Apply(options ...ReaderOption) {
 // ConfigureReader applies configuration options from c to config.
 func (c *ReaderConfig) ConfigureReader(config *ReaderConfig) {
 	*config = ReaderConfig{
-		Schema: coalesceSchema(c.Schema, config.Schema),
+		Schema:          coalesceSchema(c.Schema, config.Schema),
+		Filter:          coalesceFilter(c.Filter, config.Filter),
+		Columns:         coalesceStrings(c.Columns, config.Columns),
+		ReadConcurrency: coalesceInt(c.ReadConcurrency, config.ReadConcurrency),
 	}
 }
 
 // Validate returns a non-nil error if the configuration of c is invalid.
 func (c *ReaderConfig) Validate() error {
-	return nil
+	const baseName = "parquet.(*ReaderConfig)."
+	return errorInvalidConfiguration(
+		validatePositiveInt(baseName+"ReadConcurrency", c.ReadConcurrency),
+	)
 }
 
 // The WriterConfig type carries configuration options for parquet writers.
@@ -137,9 +157,16 @@ type WriterConfig struct {
 	PageBufferSize       int
 	DataPageVersion      int
 	DataPageStatistics   bool
+	Int96Timestamps      bool
 	KeyValueMetadata     map[string]string
 	Schema               *Schema
 	BloomFilters         []BloomFilterColumn
+	WriteConcurrency     int
+	MaxRowsPerRowGroup   int64
+	TargetRowGroupSize   int64
+	SortingColumns       []SortingColumn
+	KeyRetriever         KeyRetriever
+	Encryption           *EncryptionConfig
 }
 
 // DefaultWriterConfig returns a new WriterConfig value initialized with the
@@ -152,6 +179,9 @@ func DefaultWriterConfig() *WriterConfig {
 		PageBufferSize:       DefaultPageBufferSize,
 		DataPageVersion:      DefaultDataPageVersion,
 		DataPageStatistics:   DefaultDataPageStatistics,
+		WriteConcurrency:     DefaultWriteConcurrency,
+		MaxRowsPerRowGroup:   DefaultMaxRowsPerRowGroup,
+		TargetRowGroupSize:   DefaultTargetRowGroupSize,
 	}
 }
 
@@ -191,20 +221,35 @@ func (c *WriterConfig) ConfigureWriter(config *WriterConfig) {
 		PageBufferSize:       coalesceInt(c.PageBufferSize, config.PageBufferSize),
 		DataPageVersion:      coalesceInt(c.DataPageVersion, config.DataPageVersion),
 		DataPageStatistics:   config.DataPageStatistics,
+		Int96Timestamps:      c.Int96Timestamps || config.Int96Timestamps,
 		KeyValueMetadata:     keyValueMetadata,
 		Schema:               coalesceSchema(c.Schema, config.Schema),
 		BloomFilters:         coalesceBloomFilters(c.BloomFilters, config.BloomFilters),
+		WriteConcurrency:     coalesceInt(c.WriteConcurrency, config.WriteConcurrency),
+		MaxRowsPerRowGroup:   coalesceInt64(c.MaxRowsPerRowGroup, config.MaxRowsPerRowGroup),
+		TargetRowGroupSize:   coalesceInt64(c.TargetRowGroupSize, config.TargetRowGroupSize),
+		SortingColumns:       coalesceSortingColumns(c.SortingColumns, config.SortingColumns),
+		KeyRetriever:         coalesceKeyRetriever(c.KeyRetriever, config.KeyRetriever),
+		Encryption:           coalesceEncryption(c.Encryption, config.Encryption),
 	}
 }
 
 // Validate returns a non-nil error if the configuration of c is invalid.
 func (c *WriterConfig) Validate() error {
 	const baseName = "parquet.(*WriterConfig)."
+	var keyRetriever error
+	if c.Encryption != nil {
+		keyRetriever = validateNotNil(baseName+"KeyRetriever", c.KeyRetriever)
+	}
 	return errorInvalidConfiguration(
+		keyRetriever,
 		validateNotNil(baseName+"ColumnPageBuffers", c.ColumnPageBuffers),
 		validatePositiveInt(baseName+"ColumnIndexSizeLimit", c.ColumnIndexSizeLimit),
 		validatePositiveInt(baseName+"PageBufferSize", c.PageBufferSize),
 		validateOneOfInt(baseName+"DataPageVersion", c.DataPageVersion, 1, 2),
+		validatePositiveInt(baseName+"WriteConcurrency", c.WriteConcurrency),
+		validatePositiveInt64(baseName+"MaxRowsPerRowGroup", c.MaxRowsPerRowGroup),
+		validatePositiveInt64(baseName+"TargetRowGroupSize", c.TargetRowGroupSize),
 	)
 }
 
@@ -288,6 +333,20 @@ type RowGroupOption interface {
 	ConfigureRowGroup(*RowGroupConfig)
 }
 
+// RowGroupWriterOption is an interface implemented by configuration options
+// which apply to both row groups and writers.
+type RowGroupWriterOption interface {
+	RowGroupOption
+	WriterOption
+}
+
+// FileWriterOption is an interface implemented by configuration options which
+// apply to both files and writers.
+type FileWriterOption interface {
+	FileOption
+	WriterOption
+}
+
 // SkipPageIndex is a file configuration option which when set to true, prevents
 // automatically reading the page index when opening a parquet file. This is
 // useful as an optimization when programs know that they will not need to
@@ -298,6 +357,76 @@ func SkipPageIndex(skip bool) FileOption {
 	return fileOption(func(config *FileConfig) { config.SkipPageIndex = skip })
 }
 
+// EncryptionKeys is a file and writer configuration option which sets the
+// key retriever used to obtain the keys of encrypted parquet files.
+//
+// Files opened with a key retriever can decrypt the footer and the columns
+// for which the retriever returns keys. Writers configured with the Encryption
+// option require a key retriever.
+//
+// By default, no key retriever is configured, and only the plaintext columns
+// of files with plaintext footers can be read.
+func EncryptionKeys(keys KeyRetriever) FileWriterOption {
+	return keyRetrieverOption{keys}
+}
+
+// DecryptionAADPrefix is a file configuration option setting the AAD prefix
+// used to decrypt files which were written without storing the prefix (see
+// EncryptionConfig.SupplyAADPrefix).
+//
+// When the prefix is stored in the file, it must match the configured prefix.
+func DecryptionAADPrefix(prefix []byte) FileOption {
+	return fileOption(func(config *FileConfig) { config.AADPrefix = prefix })
+}
+
+// ReaderFilter creates a configuration option which sets the filter applied to
+// rows read from parquet files.
+//
+// Readers use the column indexes and bloom filters of the files to skip row
+// groups and pages which cannot contain rows matching the filter, the filter is
+// then evaluated on each remaining row and only matching rows are returned.
+//
+// By default, no filter is applied.
+func ReaderFilter(filter Filter) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.Filter = filter })
+}
+
+// ReaderColumns creates a configuration option which restricts the columns
+// read from parquet files to those matching the given paths. Paths use dots to
+// separate the names of nested columns, and a path referencing a group selects
+// all the columns nested in the group.
+//
+// The column chunks of columns that are not selected are never read from the
+// underlying storage, nor decompressed or decoded. When the reader is also
+// configured with a schema, the columns of the schema which are not selected
+// are read as null values.
+//
+// When only a schema is configured on the reader, the columns read from the
+// files are those which exist in the schema.
+//
+// NewReader panics if one of the paths does not exist in the schema of the
+// file, the same way it does when the reader filter references an unknown
+// column.
+//
+// By default, all columns are read.
+func ReaderColumns(paths ...string) ReaderOption {
+	paths = append([]string{}, paths...)
+	return readerOption(func(config *ReaderConfig) { config.Columns = paths })
+}
+
+// ReadConcurrency creates a configuration option which sets the maximum number
+// of goroutines used by readers to decode pages of parquet files.
+//
+// When the concurrency is greater than one, the pages of each column are read
+// and decoded in background goroutines, and the first pages of the next row
+// group are prefetched while reading the current one. The order of rows is not
+// affected by the concurrency of the reader.
+//
+// Defaults to 1, which means pages are decoded on the goroutine reading rows.
+func ReadConcurrency(concurrency int) ReaderOption {
+	return readerOption(func(config *ReaderConfig) { config.ReadConcurrency = concurrency })
+}
+
 // PageBufferSize configures the size of column page buffers on parquet writers.
 //
 // Note that the page buffer size refers to the in-memory buffers where pages
@@ -355,6 +484,17 @@ func DataPageStatistics(enabled bool) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.DataPageStatistics = enabled })
 }
 
+// Int96Timestamps creates a configuration option which defines whether columns
+// of TIMESTAMP logical type are written with the deprecated INT96 physical type.
+// This option is useful when generating parquet files for legacy consumers, such
+// as Impala, Hive or older versions of Spark, which only support timestamps in
+// the INT96 representation.
+//
+// Defaults to false.
+func Int96Timestamps(enabled bool) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.Int96Timestamps = enabled })
+}
+
 // KeyValueMetadata creates a configuration option which adds key/value metadata
 // to add to the metadata of parquet files.
 //
@@ -390,6 +530,63 @@ func BloomFilters(filters ...BloomFilterColumn) WriterOption {
 	return writerOption(func(config *WriterConfig) { config.BloomFilters = filters })
 }
 
+// WriterConcurrency creates a configuration option which sets the maximum number
+// of goroutines used by writers to encode and compress the column chunks of row
+// groups.
+//
+// When the concurrency is greater than one, the pages buffered in each column
+// are encoded, compressed and checksummed in parallel when row groups are
+// flushed, then written to the output in the order of columns in the schema,
+// which keeps the output deterministic. Pages cut while rows are written, when
+// the page buffer of a column is full, are also encoded and compressed in the
+// background while the column buffers values in a second buffer; columns with
+// a dictionary are the exception since their dictionary is updated as values
+// are written. Each column then uses its own scratch buffers, and the page
+// buffer pool of the writer must be safe to use concurrently.
+//
+// Defaults to 1, which means columns are flushed sequentially.
+func WriterConcurrency(concurrency int) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.WriteConcurrency = concurrency })
+}
+
+// MaxRowsPerRowGroup creates a configuration option which sets the maximum
+// number of rows in the row groups produced by parquet writers.
+//
+// When the limit is reached, the rows buffered by the writer are automatically
+// flushed to a new row group, as if the program had called Flush.
+//
+// Defaults to no limit, row groups are only written when the program calls
+// Flush or Close.
+func MaxRowsPerRowGroup(numRows int64) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.MaxRowsPerRowGroup = numRows })
+}
+
+// TargetRowGroupSize creates a configuration option which sets the size in
+// bytes that parquet writers aim for when producing row groups.
+//
+// The size of a row group is estimated from the compressed pages already
+// produced for each column, and the size of values still buffered in memory
+// which have not been encoded yet. Rows are automatically flushed to a new row
+// group when the estimate reaches the target, so the row groups written to the
+// file are usually slightly smaller than the configured size. To keep writing
+// rows cheap, the estimate is refreshed when columns flush pages, and between
+// those it is extrapolated from the average size of the rows.
+//
+// Defaults to no limit, row groups are only written when the program calls
+// Flush or Close.
+func TargetRowGroupSize(size int64) WriterOption {
+	return writerOption(func(config *WriterConfig) { config.TargetRowGroupSize = size })
+}
+
+// Encryption creates a configuration option which enables parquet modular
+// encryption on writers, the keys are obtained from the KeyRetriever set with
+// the EncryptionKeys option.
+//
+// Defaults to nil, files are written in plaintext.
+func Encryption(config *EncryptionConfig) WriterOption {
+	return writerOption(func(c *WriterConfig) { c.Encryption = config })
+}
+
 // ColumnBufferSize creates a configuration option which defines the size of
 // row group column buffers.
 //
@@ -404,13 +601,15 @@ func ColumnBufferSize(size int) RowGroupOption {
 // The order of sorting columns passed as argument defines the ordering
 // hierarchy; when elements are equal in the first column, the second column is
 // used to order rows, etc...
-func SortingColumns(sortingColumns ...SortingColumn) RowGroupOption {
+//
+// The option can also be passed to NewSortingWriter to define the order in
+// which rows are sorted before being written to the output file.
+func SortingColumns(sortingColumns ...SortingColumn) RowGroupWriterOption {
 	// Make a copy so that we do not retain the input slice generated implicitly
 	// for the variable argument list, and also avoid having a nil slice when
 	// the option is passed with no sorting columns, so we can differentiate it
 	// from it not being passed.
-	sortingColumns = append([]SortingColumn{}, sortingColumns...)
-	return rowGroupOption(func(config *RowGroupConfig) { config.SortingColumns = sortingColumns })
+	return sortingColumnsOption(append([]SortingColumn{}, sortingColumns...))
 }
 
 type fileOption func(*FileConfig)
@@ -429,6 +628,26 @@ type rowGroupOption func(*RowGroupConfig)
 
 func (opt rowGroupOption) ConfigureRowGroup(config *RowGroupConfig) { opt(config) }
 
+type keyRetrieverOption struct{ keys KeyRetriever }
+
+func (opt keyRetrieverOption) ConfigureFile(config *FileConfig) {
+	config.KeyRetriever = opt.keys
+}
+
+func (opt keyRetrieverOption) ConfigureWriter(config *WriterConfig) {
+	config.KeyRetriever = opt.keys
+}
+
+type sortingColumnsOption []SortingColumn
+
+func (opt sortingColumnsOption) ConfigureRowGroup(config *RowGroupConfig) {
+	config.SortingColumns = opt
+}
+
+func (opt sortingColumnsOption) ConfigureWriter(config *WriterConfig) {
+	config.SortingColumns = opt
+}
+
 func coalesceInt(i1, i2 int) int {
 	if i1 != 0 {
 		return i1
@@ -450,6 +669,13 @@ func coalesceString(s1, s2 string) string {
 	return s2
 }
 
+func coalesceStrings(s1, s2 []string) []string {
+	if s1 != nil {
+		return s1
+	}
+	return s2
+}
+
 func coalesceBytes(b1, b2 []byte) []byte {
 	if b1 != nil {
 		return b1
@@ -471,6 +697,13 @@ func coalesceSchema(s1, s2 *Schema) *Schema {
 	return s2
 }
 
+func coalesceFilter(f1, f2 Filter) Filter {
+	if f1 != nil {
+		return f1
+	}
+	return f2
+}
+
 func coalesceSortingColumns(s1, s2 []SortingColumn) []SortingColumn {
 	if s1 != nil {
 		return s1
@@ -485,6 +718,20 @@ func coalesceBloomFilters(f1, f2 []BloomFilterColumn) []BloomFilterColumn {
 	return f2
 }
 
+func coalesceKeyRetriever(k1, k2 KeyRetriever) KeyRetriever {
+	if k1 != nil {
+		return k1
+	}
+	return k2
+}
+
+func coalesceEncryption(e1, e2 *EncryptionConfig) *EncryptionConfig {
+	if e1 != nil {
+		return e1
+	}
+	return e2
+}
+
 func validatePositiveInt(optionName string, optionValue int) error {
 	if optionValue > 0 {
 		return nil
@@ -3,7 +3,12 @@ package parquet
 import (
 	"fmt"
 	"io"
+	"math"
 	"sort"
+	"sync"
+	"time"
+
+	"github.com/segmentio/parquet-go/format"
 )
 
 // ConvertError is an error type returned by calls to Convert when the conversion
@@ -59,7 +64,20 @@ func (c *conversion) Schema() *Schema { return c.schema }
 // The function supports converting between schemas where the source or target
 // have extra columns; if there are more columns in the source, they will be
 // stripped out of the rows. Extra columns in the target schema will be set to
-// null or zero values.
+// null or zero values. Required columns of the source schema may also be
+// converted to optional columns of the target schema.
+//
+// Leaf columns of different types can be converted when the source type can be
+// promoted to the target type; values are rewritten during the conversion. The
+// supported promotions are:
+//
+//   - INT32 to INT64, including unsigned integers to wider integers
+//   - FLOAT to DOUBLE
+//   - BYTE_ARRAY to STRING, and STRING to BYTE_ARRAY
+//   - DATE to TIMESTAMP
+//   - INT96 to TIMESTAMP, and TIMESTAMP to INT96
+//   - TIME and TIMESTAMP to a different time unit, values are truncated when
+//     converted to a coarser unit
 //
 // The returned function is intended to be used to append the converted source
 // row to the destination buffer.
@@ -79,7 +97,7 @@ func Convert(to, from Node) (conv Conversion, err error) {
 		columns[i] = -1
 	}
 
-	_, _, convertFunc := convert(convertNode{node: to}, convertNode{node: from}, columns)
+	_, _, convertFunc := convert(convertNode{node: to}, convertNode{node: from, definitionLevels: []int8{0}}, columns)
 
 	c := &conversion{
 		convert: convertFunc,
@@ -91,12 +109,279 @@ func Convert(to, from Node) (conv Conversion, err error) {
 	return c, nil
 }
 
+// ConvertByFieldID is like Convert but matches the columns of the source and
+// target schemas by field ID instead of by name, which allows conversions to
+// retain the values of columns that were renamed.
+//
+// Columns without a field ID are matched by name. Columns of the source schema
+// with a field ID that does not exist in the target schema are removed.
+func ConvertByFieldID(to, from Node) (Conversion, error) {
+	var numColumns int16
+	renamed, reordering, err := renameColumnsByFieldID(to, from, nil, &numColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	conv, err := Convert(to, renamed)
+	if err != nil {
+		return nil, err
+	}
+
+	renamedColumns := make(map[string]int16)
+	forEachLeafColumnOf(renamed, func(leaf leafColumn) {
+		renamedColumns[leaf.path.String()] = leaf.columnIndex
+	})
+
+	c := &fieldIDConversion{
+		conv:       conv,
+		reordering: reordering,
+		columns:    make([]int16, numColumns),
+		sources:    make([]int16, len(renamedColumns)),
+	}
+	for i := range c.columns {
+		c.columns[i] = -1
+	}
+	reordering.forEachLeaf(func(r *columnReordering) {
+		columnIndex := renamedColumns[r.path.String()]
+		c.columns[r.columnIndex] = columnIndex
+		c.sources[columnIndex] = r.columnIndex
+	})
+	return c, nil
+}
+
+// fieldIDConversion wraps the conversion of rows from a schema where columns of
+// the source schema were renamed to the names of the columns with the same
+// field ID in the target schema.
+//
+// Since renaming columns may change their order, values of source rows are
+// reordered to match the renamed schema prior to being converted. The reordered
+// rows are written to buffers recycled across calls to Convert; the buffers are
+// pooled since conversions may be used concurrently.
+type fieldIDConversion struct {
+	conv       Conversion
+	reordering *columnReordering
+	columns    []int16 // source column index => renamed column index
+	sources    []int16 // renamed column index => source column index
+	buffers    sync.Pool
+}
+
+func (c *fieldIDConversion) Convert(dst, src Row) (Row, error) {
+	buf, _ := c.buffers.Get().(*reorderBuffer)
+	if buf == nil {
+		buf = new(reorderBuffer)
+	}
+	buf.row = c.reordering.reorderElement(buf.row[:0], src, 0, c.columns, &buf.segments)
+	dst, err := c.conv.Convert(dst, buf.row)
+	c.buffers.Put(buf)
+	return dst, err
+}
+
+// reorderBuffer holds the memory used to reorder the values of a row; segments
+// is a stack of the values of the children of groups being reordered.
+type reorderBuffer struct {
+	row      Row
+	segments []Row
+}
+
+func (c *fieldIDConversion) Column(i int) int {
+	if j := c.conv.Column(i); j >= 0 {
+		return int(c.sources[j])
+	}
+	return -1
+}
+
+func (c *fieldIDConversion) Schema() *Schema { return c.conv.Schema() }
+
+// columnReordering is a tree describing how the values of a source row must be
+// reordered to match the layout of a schema where columns were renamed.
+type columnReordering struct {
+	name        string
+	path        columnPath // path in the renamed schema
+	columnIndex int16      // first leaf column in the source schema
+	numColumns  int16
+	leaf        bool
+	repeated    bool
+	dropped     bool
+	children    []*columnReordering // in the order of the source schema
+	order       []int               // children indexes in the order of the renamed schema
+}
+
+func (r *columnReordering) forEachLeaf(do func(*columnReordering)) {
+	switch {
+	case r.dropped:
+	case r.leaf:
+		do(r)
+	default:
+		for _, child := range r.children {
+			child.forEachLeaf(do)
+		}
+	}
+}
+
+func (r *columnReordering) contains(v Value) bool {
+	c := int16(v.Column())
+	return c >= r.columnIndex && c < r.columnIndex+r.numColumns
+}
+
+// startsElement returns true if v is the first value of a new element of the
+// repeated node at the given depth.
+func (r *columnReordering) startsElement(v Value, depth int8) bool {
+	return int16(v.Column()) == r.columnIndex && v.repetitionLevel <= depth
+}
+
+// reorder appends the values of the node instance held in src to dst, where
+// depth is the repetition depth of the parent node.
+func (r *columnReordering) reorder(dst, src Row, depth int8, columns []int16, segments *[]Row) Row {
+	if r.dropped {
+		return dst
+	}
+	if !r.repeated {
+		return r.reorderElement(dst, src, depth, columns, segments)
+	}
+	depth++
+	for len(src) > 0 {
+		n := 1
+		for n < len(src) && !r.startsElement(src[n], depth) {
+			n++
+		}
+		dst = r.reorderElement(dst, src[:n], depth, columns, segments)
+		src = src[n:]
+	}
+	return dst
+}
+
+func (r *columnReordering) reorderElement(dst, src Row, depth int8, columns []int16, segments *[]Row) Row {
+	if r.leaf {
+		for _, v := range src {
+			v.columnIndex = ^columns[v.Column()]
+			dst = append(dst, v)
+		}
+		return dst
+	}
+
+	// Each child has at least one value in each instance of its parent, and
+	// the values of a child are contiguous, up to the value starting the next
+	// element of the repeated parent. The segments are pushed to the stack,
+	// which may be reallocated by the recursive calls so it is indexed from
+	// its base rather than sliced.
+	base := len(*segments)
+	for _, child := range r.children {
+		n := 0
+		for n < len(src) && child.contains(src[n]) && (n == 0 || !child.startsElement(src[n], depth)) {
+			n++
+		}
+		*segments, src = append(*segments, src[:n]), src[n:]
+	}
+
+	for _, i := range r.order {
+		dst = r.children[i].reorder(dst, (*segments)[base+i], depth, columns, segments)
+	}
+	*segments = (*segments)[:base]
+	return dst
+}
+
+// renameColumnsByFieldID returns a copy of the from node where columns are
+// renamed after the columns of the to node which have the same field ID.
+func renameColumnsByFieldID(to, from Node, path columnPath, numColumns *int16) (Node, *columnReordering, error) {
+	r := &columnReordering{
+		path:        path,
+		columnIndex: *numColumns,
+		repeated:    from.Repeated(),
+	}
+
+	if isLeaf(from) {
+		r.leaf = true
+		r.numColumns = 1
+		*numColumns++
+		return from, r, nil
+	}
+
+	group := make(Group, from.NumChildren())
+	for _, name := range from.ChildNames() {
+		fromChild := from.ChildByName(name)
+		newName, toChild, keep := fieldIDChildOf(to, name, fromChild)
+
+		child, reordering, err := renameColumnsByFieldID(toChild, fromChild, path.append(newName), numColumns)
+		if err != nil {
+			return nil, nil, err
+		}
+		reordering.name = newName
+		reordering.dropped = !keep
+		r.children = append(r.children, reordering)
+		r.numColumns += reordering.numColumns
+
+		if keep {
+			if _, exists := group[newName]; exists {
+				return nil, nil, &ConvertError{
+					Reason: "multiple columns are converted to the same column",
+					Path:   path.append(newName),
+					From:   from,
+					To:     to,
+				}
+			}
+			group[newName] = child
+			r.order = append(r.order, len(r.children)-1)
+		}
+	}
+
+	sort.Slice(r.order, func(i, j int) bool {
+		return r.children[r.order[i]].name < r.children[r.order[j]].name
+	})
+
+	var node Node = group
+	if logicalType := from.Type().LogicalType(); logicalType != nil {
+		switch {
+		case logicalType.List != nil:
+			node = listNode{group}
+		case logicalType.Map != nil:
+			node = mapNode{group}
+		}
+	}
+	switch {
+	case from.Optional():
+		node = Optional(node)
+	case from.Repeated():
+		node = Repeated(node)
+	}
+	if id := fieldIDOf(from); id != 0 {
+		node = FieldID(node, id)
+	}
+	return node, r, nil
+}
+
+// fieldIDChildOf returns the name of the child of the to node matching the
+// child of a source node, which is false if the source child has a field ID
+// which does not exist in the to node.
+func fieldIDChildOf(to Node, name string, fromChild Node) (string, Node, bool) {
+	if to == nil || isLeaf(to) {
+		return name, nil, true
+	}
+	id := fieldIDOf(fromChild)
+	for _, toName := range to.ChildNames() {
+		toChild := to.ChildByName(toName)
+		if id != 0 && fieldIDOf(toChild) == id || id == 0 && toName == name {
+			return toName, toChild, true
+		}
+	}
+	return name, nil, id == 0
+}
+
 type convertFunc func(Row, Row, levels) (Row, Row, error)
 
 type convertNode struct {
 	columnIndex int16
 	node        Node
 	path        columnPath
+	// The definition level of the target node, and the mapping of definition
+	// levels of the source node to the target, which is used to translate the
+	// definition levels of null values.
+	definitionLevel  int8
+	definitionLevels []int8
+}
+
+func (c convertNode) defined(definitionLevel int8) convertNode {
+	c.definitionLevels = append(c.definitionLevels[:len(c.definitionLevels):len(c.definitionLevels)], definitionLevel)
+	return c
 }
 
 func (c convertNode) child(name string) convertNode {
@@ -120,7 +405,7 @@ func convert(to, from convertNode, columns []int16) (int16, int16, convertFunc)
 		panic(convertError(to, from, "cannot convert from repeated to required column"))
 
 	case to.node.Optional():
-		panic(convertError(to, from, "cannot convert from required to optional column"))
+		return convertFuncOfOptional(to, from, columns)
 
 	case to.node.Repeated():
 		panic(convertError(to, from, "cannot convert from required to repeated column"))
@@ -145,6 +430,10 @@ func convertError(to, from convertNode, reason string) *ConvertError {
 
 //go:noinline
 func convertFuncOfOptional(to, from convertNode, columns []int16) (int16, int16, convertFunc) {
+	to.definitionLevel++
+	if from.node.Optional() {
+		from = from.defined(to.definitionLevel)
+	}
 	to.node = Required(to.node)
 	from.node = Required(from.node)
 
@@ -157,6 +446,8 @@ func convertFuncOfOptional(to, from convertNode, columns []int16) (int16, int16,
 
 //go:noinline
 func convertFuncOfRepeated(to, from convertNode, columns []int16) (int16, int16, convertFunc) {
+	to.definitionLevel++
+	from = from.defined(to.definitionLevel)
 	to.node = Required(to.node)
 	from.node = Required(from.node)
 	srcColumnIndex := ^from.columnIndex
@@ -181,26 +472,198 @@ func convertFuncOfRepeated(to, from convertNode, columns []int16) (int16, int16,
 
 //go:noinline
 func convertFuncOfLeaf(to, from convertNode, columns []int16) (int16, int16, convertFunc) {
-	if !typesAreEqual(to.node, from.node) {
+	convertValue, ok := convertValueFuncOf(to.node.Type(), from.node.Type())
+	if !ok {
 		panic(convertError(to, from, fmt.Sprintf("unsupported type conversion from %s to %s for parquet column", from.node.Type(), to.node.Type())))
 	}
 
 	srcColumnIndex := ^from.columnIndex
 	dstColumnIndex := ^to.columnIndex
 	columns[to.columnIndex] = from.columnIndex
+	definitionLevels := from.definitionLevels
+	maxDefinitionLevel := int8(len(definitionLevels) - 1)
 
 	return to.columnIndex + 1, from.columnIndex + 1, func(dst, src Row, levels levels) (Row, Row, error) {
 		if len(src) == 0 || src[0].columnIndex != srcColumnIndex {
 			return dst, src, convertError(to, from, "no value found in row for parquet column")
 		}
 		v := src[0]
+		definitionLevel := levels.definitionLevel
+		if v.definitionLevel < maxDefinitionLevel {
+			definitionLevel = definitionLevels[v.definitionLevel]
+		}
+		if convertValue != nil && !v.IsNull() {
+			var err error
+			if v, err = convertValue(v); err != nil {
+				return dst, src, fmt.Errorf("converting values of parquet column %q: %w", columnPath(from.path), err)
+			}
+		}
 		v.repetitionLevel = levels.repetitionLevel
-		v.definitionLevel = levels.definitionLevel
+		v.definitionLevel = definitionLevel
 		v.columnIndex = dstColumnIndex
 		return append(dst, v), src[1:], nil
 	}
 }
 
+// convertValueFunc is the type of functions converting values from one type to
+// another, which return an error when a value cannot be represented in the
+// target type.
+type convertValueFunc func(Value) (Value, error)
+
+// convertValueFuncOf returns a function converting values of the from type to
+// the to type, or false if the conversion is not supported. The function is
+// nil when values can be used without being rewritten.
+func convertValueFuncOf(to, from Type) (convertValueFunc, bool) {
+	toLogicalType, fromLogicalType := to.LogicalType(), from.LogicalType()
+
+	switch {
+	case to.Kind() == Int96 && fromLogicalType != nil && fromLogicalType.Timestamp != nil:
+		convert := convertTimestampToInt96(fromLogicalType.Timestamp.Unit)
+		return func(v Value) (Value, error) { return convert(v), nil }, true
+
+	case from.Kind() == Int96 && toLogicalType != nil && toLogicalType.Timestamp != nil:
+		return convertInt96ToTimestamp(toLogicalType.Timestamp.Unit), true
+	}
+
+	if toLogicalType != nil && fromLogicalType != nil {
+		switch {
+		case fromLogicalType.Date != nil && toLogicalType.Timestamp != nil:
+			return convertDateToTimestamp(timeUnitDurationOf(toLogicalType.Timestamp.Unit)), true
+
+		case fromLogicalType.Timestamp != nil && toLogicalType.Timestamp != nil:
+			fromUnit := timeUnitDurationOf(fromLogicalType.Timestamp.Unit)
+			toUnit := timeUnitDurationOf(toLogicalType.Timestamp.Unit)
+			return convertTimeUnit(to.Kind(), from.Kind(), toUnit, fromUnit), true
+
+		case fromLogicalType.Time != nil && toLogicalType.Time != nil:
+			fromUnit := timeUnitDurationOf(fromLogicalType.Time.Unit)
+			toUnit := timeUnitDurationOf(toLogicalType.Time.Unit)
+			return convertTimeUnit(to.Kind(), from.Kind(), toUnit, fromUnit), true
+		}
+	}
+
+	toKind, fromKind := to.Kind(), from.Kind()
+	switch {
+	case toKind == fromKind:
+		if toKind == FixedLenByteArray && to.Length() != from.Length() {
+			break
+		}
+		if logicalTypesAreCompatible(toLogicalType, fromLogicalType) {
+			return nil, true
+		}
+
+	case toKind == Int64 && fromKind == Int32:
+		_, toSigned, toInteger := integerTypeOf(to)
+		_, fromSigned, fromInteger := integerTypeOf(from)
+		switch {
+		case !toInteger || !fromInteger:
+		case fromSigned && toSigned:
+			return func(v Value) (Value, error) { return makeValueInt64(int64(v.Int32())), nil }, true
+		case !fromSigned:
+			return func(v Value) (Value, error) { return makeValueInt64(int64(uint32(v.Int32()))), nil }, true
+		}
+
+	case toKind == Double && fromKind == Float:
+		return func(v Value) (Value, error) { return makeValueDouble(float64(v.Float())), nil }, true
+	}
+
+	return nil, false
+}
+
+// logicalTypesAreCompatible returns true if values of the from logical type can
+// be read as values of the to logical type without being rewritten, which is
+// the case when either type is nil, when the types are equal, when integers are
+// widened, when decimals gain precision with the same scale, and between the
+// STRING, ENUM and JSON types.
+func logicalTypesAreCompatible(to, from *format.LogicalType) bool {
+	switch {
+	case to == nil || from == nil:
+		return true
+	case logicalTypesAreEqual(to, from):
+		return true
+	case to.Integer != nil && from.Integer != nil:
+		toBitWidth, fromBitWidth := to.Integer.BitWidth, from.Integer.BitWidth
+		if to.Integer.IsSigned == from.Integer.IsSigned {
+			return toBitWidth >= fromBitWidth
+		}
+		return to.Integer.IsSigned && toBitWidth > fromBitWidth
+	case to.Decimal != nil && from.Decimal != nil:
+		return to.Decimal.Scale == from.Decimal.Scale && to.Decimal.Precision >= from.Decimal.Precision
+	default:
+		return isTextLogicalType(to) && isTextLogicalType(from)
+	}
+}
+
+func isTextLogicalType(t *format.LogicalType) bool {
+	return t.UTF8 != nil || t.Enum != nil || t.Json != nil
+}
+
+func convertTimestampToInt96(unit format.TimeUnit) func(Value) Value {
+	return func(v Value) Value { return makeValueInt96(int96OfTime(unixTime(v.Int64(), unit))) }
+}
+
+func convertInt96ToTimestamp(unit format.TimeUnit) convertValueFunc {
+	min, max := timestampRangeOf(unit)
+	return func(v Value) (Value, error) {
+		t := timeOfInt96(v.Int96())
+		if t.Before(min) || t.After(max) {
+			return v, fmt.Errorf("%w: %s is not representable in units of %s", ErrTimestampOverflow, t, timeUnitDurationOf(unit))
+		}
+		return makeValueInt64(unixTimestamp(t, unit)), nil
+	}
+}
+
+func convertDateToTimestamp(unit time.Duration) convertValueFunc {
+	day := int64((24 * time.Hour) / unit)
+	return func(v Value) (Value, error) {
+		t, ok := multiplyTimeValue(int64(v.Int32()), day)
+		if !ok {
+			return v, fmt.Errorf("%w: date %d is not representable in units of %s", ErrTimestampOverflow, v.Int32(), unit)
+		}
+		return makeValueInt64(t), nil
+	}
+}
+
+// convertTimeUnit returns a function converting time values from one unit to
+// another; the values are truncated when converting to a coarser unit, and
+// the function returns an error wrapping ErrTimestampOverflow when they do not
+// fit in the target type.
+func convertTimeUnit(toKind, fromKind Kind, toUnit, fromUnit time.Duration) convertValueFunc {
+	if toUnit == fromUnit && toKind == fromKind {
+		return nil
+	}
+	return func(v Value) (Value, error) {
+		t := v.Int64()
+		if fromKind == Int32 {
+			t = int64(v.Int32())
+		}
+		value, ok := t, true
+		if fromUnit > toUnit {
+			t, ok = multiplyTimeValue(t, int64(fromUnit/toUnit))
+		} else {
+			t /= int64(toUnit / fromUnit)
+		}
+		if toKind == Int32 {
+			ok = ok && t >= math.MinInt32 && t <= math.MaxInt32
+		}
+		if !ok {
+			return v, fmt.Errorf("%w: %d in units of %s is not representable in units of %s", ErrTimestampOverflow, value, fromUnit, toUnit)
+		}
+		if toKind == Int32 {
+			return makeValueInt32(int32(t)), nil
+		}
+		return makeValueInt64(t), nil
+	}
+}
+
+// multiplyTimeValue returns t*factor, or false if the multiplication overflows.
+func multiplyTimeValue(t, factor int64) (int64, bool) {
+	if t > math.MaxInt64/factor || t < math.MinInt64/factor {
+		return 0, false
+	}
+	return t * factor, true
+}
+
 //go:noinline
 func convertFuncOfGroup(to, from convertNode, columns []int16) (int16, int16, convertFunc) {
 	extra, missing, names := comm(to.node.ChildNames(), from.node.ChildNames())
@@ -294,7 +757,8 @@ func nodesAreEqual(node1, node2 Node) bool {
 }
 
 func typesAreEqual(node1, node2 Node) bool {
-	return node1.Type().Kind() == node2.Type().Kind()
+	convertValue, ok := convertValueFuncOf(node1.Type(), node2.Type())
+	return ok && convertValue == nil
 }
 
 func repetitionsAreEqual(node1, node2 Node) bool {
@@ -364,6 +828,11 @@ func merge(s1, s2, s3 []string) []string {
 
 // ConvertRowGroup constructs a wrapper of the given row group which applies
 // the given schema conversion to its rows.
+//
+// The column chunks of the returned row group are those of the original row
+// group, values read from their pages are not converted when the conversion
+// changes the column types; programs should read converted values from the
+// rows of the returned row group.
 func ConvertRowGroup(rowGroup RowGroup, conv Conversion) RowGroup {
 	schema := conv.Schema()
 	numRows := rowGroup.NumRows()
@@ -428,6 +897,22 @@ func ConvertRowGroup(rowGroup RowGroup, conv Conversion) RowGroup {
 }
 
 func maskMissingRowGroupColumns(r RowGroup, numColumns int, conv Conversion) RowGroup {
+	switch g := r.(type) {
+	case *projectedRowGroup:
+		// Projected row groups rewrite the column indexes of values read from
+		// their rows, so their columns cannot be masked individually.
+		return g
+	case *filteredRowGroup:
+		// The columns that the filter applies to cannot be masked since their
+		// values are needed to evaluate the predicate on each row.
+		masked, ok := maskMissingRowGroupColumns(g.rowGroup, numColumns, conv).(*rowGroup)
+		if !ok {
+			return g
+		}
+		g.filter.columns(func(i int) { masked.columns[i] = g.rowGroup.Column(i) })
+		return &filteredRowGroup{rowGroup: masked, filter: g.filter}
+	}
+
 	columns := make([]ColumnChunk, r.NumColumns())
 	missing := make([]missingColumnChunk, len(columns))
 	numRows := r.NumRows()
@@ -470,7 +955,7 @@ type missingColumnChunk struct {
 
 func (c *missingColumnChunk) Type() Type               { return c.typ }
 func (c *missingColumnChunk) Column() int              { return int(c.column) }
-func (c *missingColumnChunk) Pages() Pages             { return onePage(missingPage{c}) }
+func (c *missingColumnChunk) Pages() Pages             { return &missingPages{column: c} }
 func (c *missingColumnChunk) ColumnIndex() ColumnIndex { return missingColumnIndex{c} }
 func (c *missingColumnChunk) OffsetIndex() OffsetIndex { return missingOffsetIndex{} }
 func (c *missingColumnChunk) BloomFilter() BloomFilter { return missingBloomFilter{} }
@@ -499,6 +984,38 @@ func (missingBloomFilter) ReadAt([]byte, int64) (int, error) { return 0, io.EOF
 func (missingBloomFilter) Size() int64                       { return 0 }
 func (missingBloomFilter) Check(Value) (bool, error)         { return false, nil }
 
+// missingPages is the implementation of the Pages interface for missing column
+// chunks. Missing pages cannot be buffered and sliced, so seeking is done by
+// producing a page holding only the remaining rows.
+type missingPages struct {
+	column *missingColumnChunk
+	seek   int64
+}
+
+func (r *missingPages) ReadPage() (Page, error) {
+	c := r.column
+	if r.seek >= c.numRows {
+		return nil, io.EOF
+	}
+	seek := r.seek
+	r.seek = c.numRows
+	if seek == 0 {
+		return missingPage{c}, nil
+	}
+	return missingPage{&missingColumnChunk{
+		typ:       c.typ,
+		column:    c.column,
+		numRows:   c.numRows - seek,
+		numValues: c.numValues - seek,
+		numNulls:  c.numNulls - seek,
+	}}, nil
+}
+
+func (r *missingPages) SeekToRow(rowIndex int64) error {
+	r.seek = rowIndex
+	return nil
+}
+
 type missingPage struct{ *missingColumnChunk }
 
 func (p missingPage) Column() int              { return int(p.column) }
@@ -571,6 +1088,17 @@ func (c *convertedRows) ReadRow(row Row) (Row, error) {
 	return c.conv.Convert(row, c.buf)
 }
 
+func (c *convertedRows) ReadRows(rows []Row) (int, error) {
+	for i := range rows {
+		row, err := c.ReadRow(rows[i][:0])
+		rows[i] = row
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
 func (c *convertedRows) Schema() *Schema {
 	return c.conv.Schema()
 }
@@ -1,10 +1,14 @@
 package parquet_test
 
 import (
+	"errors"
+	"math"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/segmentio/parquet-go"
+	"github.com/segmentio/parquet-go/deprecated"
 )
 
 var conversionTests = [...]struct {
@@ -130,3 +134,397 @@ func TestConvert(t *testing.T) {
 }
 
 func newString(s string) *string { return &s }
+
+func TestConvertTypePromotion(t *testing.T) {
+	day := int64(24 * time.Hour)
+
+	tests := []struct {
+		scenario string
+		from     parquet.Node
+		to       parquet.Node
+		value    parquet.Value
+		want     parquet.Value
+	}{
+		{
+			scenario: "int32 to int64",
+			from:     parquet.Int(32),
+			to:       parquet.Int(64),
+			value:    parquet.ValueOf(int32(-42)),
+			want:     parquet.ValueOf(int64(-42)),
+		},
+
+		{
+			scenario: "uint32 to uint64",
+			from:     parquet.Uint(32),
+			to:       parquet.Uint(64),
+			value:    parquet.ValueOf(uint32(math.MaxUint32)),
+			want:     parquet.ValueOf(uint64(math.MaxUint32)),
+		},
+
+		{
+			scenario: "uint32 to int64",
+			from:     parquet.Uint(32),
+			to:       parquet.Int(64),
+			value:    parquet.ValueOf(uint32(math.MaxUint32)),
+			want:     parquet.ValueOf(int64(math.MaxUint32)),
+		},
+
+		{
+			scenario: "int16 to int32",
+			from:     parquet.Int(16),
+			to:       parquet.Int(32),
+			value:    parquet.ValueOf(int16(-1)),
+			want:     parquet.ValueOf(int32(-1)),
+		},
+
+		{
+			scenario: "decimal precision",
+			from:     parquet.Decimal(2, 10, parquet.Int64Type),
+			to:       parquet.Decimal(2, 12, parquet.Int64Type),
+			value:    parquet.ValueOf(int64(123)),
+			want:     parquet.ValueOf(int64(123)),
+		},
+
+		{
+			scenario: "float to double",
+			from:     parquet.Leaf(parquet.FloatType),
+			to:       parquet.Leaf(parquet.DoubleType),
+			value:    parquet.ValueOf(float32(0.5)),
+			want:     parquet.ValueOf(float64(0.5)),
+		},
+
+		{
+			scenario: "byte array to string",
+			from:     parquet.Leaf(parquet.ByteArrayType),
+			to:       parquet.String(),
+			value:    parquet.ValueOf([]byte("hello")),
+			want:     parquet.ValueOf("hello"),
+		},
+
+		{
+			scenario: "string to byte array",
+			from:     parquet.String(),
+			to:       parquet.Leaf(parquet.ByteArrayType),
+			value:    parquet.ValueOf("hello"),
+			want:     parquet.ValueOf([]byte("hello")),
+		},
+
+		{
+			scenario: "date to timestamp",
+			from:     parquet.Date(),
+			to:       parquet.Timestamp(parquet.Millisecond),
+			value:    parquet.ValueOf(int32(3)),
+			want:     parquet.ValueOf(3 * day / int64(time.Millisecond)),
+		},
+
+		{
+			scenario: "timestamp millis to nanos",
+			from:     parquet.Timestamp(parquet.Millisecond),
+			to:       parquet.Timestamp(parquet.Nanosecond),
+			value:    parquet.ValueOf(int64(1234)),
+			want:     parquet.ValueOf(int64(1234e6)),
+		},
+
+		{
+			scenario: "timestamp nanos to micros",
+			from:     parquet.Timestamp(parquet.Nanosecond),
+			to:       parquet.Timestamp(parquet.Microsecond),
+			value:    parquet.ValueOf(int64(1234567)),
+			want:     parquet.ValueOf(int64(1234)),
+		},
+
+		{
+			scenario: "int96 to timestamp millis",
+			from:     parquet.Leaf(parquet.Int96Type),
+			to:       parquet.Timestamp(parquet.Millisecond),
+			value:    parquet.ValueOf(deprecated.Int96{5e8, 0, 2440589}),
+			want:     parquet.ValueOf(int64(86400500)),
+		},
+
+		{
+			scenario: "timestamp micros to int96",
+			from:     parquet.Timestamp(parquet.Microsecond),
+			to:       parquet.Leaf(parquet.Int96Type),
+			value:    parquet.ValueOf(int64(-1)),
+			want:     parquet.ValueOf(deprecated.Int96{2437872664, 20116, 2440587}),
+		},
+
+		{
+			scenario: "time millis to micros",
+			from:     parquet.Time(parquet.Millisecond),
+			to:       parquet.Time(parquet.Microsecond),
+			value:    parquet.ValueOf(int32(1234)),
+			want:     parquet.ValueOf(int64(1234e3)),
+		},
+
+		{
+			scenario: "time micros to millis",
+			from:     parquet.Time(parquet.Microsecond),
+			to:       parquet.Time(parquet.Millisecond),
+			value:    parquet.ValueOf(int64(1234e3)),
+			want:     parquet.ValueOf(int32(1234)),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			from := parquet.NewSchema("test", parquet.Group{"value": test.from})
+			to := parquet.NewSchema("test", parquet.Group{"value": test.to})
+
+			conv, err := parquet.Convert(to, from)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			row, err := conv.Convert(nil, parquet.Row{test.value.Level(0, 0, 0)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := parquet.Row{test.want.Level(0, 0, 0)}
+			if !row.Equal(want) {
+				t.Errorf("wrong converted row:\nwant = %+v\ngot  = %+v", want, row)
+			}
+		})
+	}
+}
+
+func TestConvertTypePromotionOptional(t *testing.T) {
+	from := parquet.NewSchema("test", parquet.Group{"value": parquet.Optional(parquet.Int(32))})
+	to := parquet.NewSchema("test", parquet.Group{"value": parquet.Optional(parquet.Int(64))})
+
+	conv, err := parquet.Convert(to, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := conv.Convert(nil, parquet.Row{parquet.ValueOf(int32(1)).Level(0, 1, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (parquet.Row{parquet.ValueOf(int64(1)).Level(0, 1, 0)}); !row.Equal(want) {
+		t.Errorf("wrong converted row:\nwant = %+v\ngot  = %+v", want, row)
+	}
+
+	row, err = conv.Convert(nil, parquet.Row{parquet.Value{}.Level(0, 0, 0)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !row[0].IsNull() || row[0].DefinitionLevel() != 0 {
+		t.Errorf("null value was converted to %+v", row[0])
+	}
+}
+
+func TestConvertNullDefinitionLevels(t *testing.T) {
+	from := parquet.NewSchema("test", parquet.Group{
+		"a": parquet.Optional(parquet.Group{
+			"b": parquet.Optional(parquet.Int(32)),
+		}),
+	})
+	to := parquet.NewSchema("test", parquet.Group{
+		"a": parquet.Optional(parquet.Group{
+			"b": parquet.Optional(parquet.Int(64)),
+		}),
+	})
+
+	conv, err := parquet.Convert(to, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, definitionLevel := range []int{0, 1} {
+		row, err := conv.Convert(nil, parquet.Row{parquet.Value{}.Level(0, definitionLevel, 0)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := parquet.Row{parquet.Value{}.Level(0, definitionLevel, 0)}
+		if !row.Equal(want) {
+			t.Errorf("wrong converted row:\nwant = %+v\ngot  = %+v", want, row)
+		}
+	}
+}
+
+func TestConvertUnsupportedTypes(t *testing.T) {
+	tests := []struct {
+		scenario string
+		from     parquet.Node
+		to       parquet.Node
+	}{
+		{"int64 to int32", parquet.Int(64), parquet.Int(32)},
+		{"int32 to uint64", parquet.Int(32), parquet.Uint(64)},
+		{"double to float", parquet.Leaf(parquet.DoubleType), parquet.Leaf(parquet.FloatType)},
+		{"string to int64", parquet.String(), parquet.Int(64)},
+		{"date to int64", parquet.Date(), parquet.Int(64)},
+		{"uint64 to int64", parquet.Uint(64), parquet.Int(64)},
+		{"int32 to uint32", parquet.Int(32), parquet.Uint(32)},
+		{"decimal scale", parquet.Decimal(2, 10, parquet.Int64Type), parquet.Decimal(4, 10, parquet.Int64Type)},
+		{"decimal precision", parquet.Decimal(2, 12, parquet.Int64Type), parquet.Decimal(2, 10, parquet.Int64Type)},
+		{"string to decimal", parquet.String(), parquet.Decimal(2, 10, parquet.ByteArrayType)},
+		{"fixed length", parquet.Leaf(parquet.FixedLenByteArrayType(16)), parquet.Leaf(parquet.FixedLenByteArrayType(12))},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			from := parquet.NewSchema("test", parquet.Group{"value": test.from})
+			to := parquet.NewSchema("test", parquet.Group{"value": test.to})
+
+			if _, err := parquet.Convert(to, from); err == nil {
+				t.Fatal("expected an error for unsupported type conversion")
+			}
+		})
+	}
+}
+
+func TestConvertTimestampOverflow(t *testing.T) {
+	tests := []struct {
+		scenario string
+		from     parquet.Node
+		to       parquet.Node
+		value    parquet.Value
+	}{
+		{
+			scenario: "timestamp millis to nanos",
+			from:     parquet.Timestamp(parquet.Millisecond),
+			to:       parquet.Timestamp(parquet.Nanosecond),
+			value:    parquet.ValueOf(time.Date(2263, time.January, 1, 0, 0, 0, 0, time.UTC).UnixMilli()),
+		},
+
+		{
+			scenario: "negative timestamp millis to nanos",
+			from:     parquet.Timestamp(parquet.Millisecond),
+			to:       parquet.Timestamp(parquet.Nanosecond),
+			value:    parquet.ValueOf(int64(math.MinInt64 / 1000)),
+		},
+
+		{
+			scenario: "date to timestamp nanos",
+			from:     parquet.Date(),
+			to:       parquet.Timestamp(parquet.Nanosecond),
+			value:    parquet.ValueOf(int32(math.MaxInt32)),
+		},
+
+		{
+			scenario: "int96 to timestamp nanos",
+			from:     parquet.Leaf(parquet.Int96Type),
+			to:       parquet.Timestamp(parquet.Nanosecond),
+			value:    parquet.ValueOf(deprecated.Int96{0, 0, 2440588 + 200000}),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			from := parquet.NewSchema("test", parquet.Group{"value": test.from})
+			to := parquet.NewSchema("test", parquet.Group{"value": test.to})
+
+			conv, err := parquet.Convert(to, from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := conv.Convert(nil, parquet.Row{test.value.Level(0, 0, 0)}); !errors.Is(err, parquet.ErrTimestampOverflow) {
+				t.Errorf("want=%v got=%v", parquet.ErrTimestampOverflow, err)
+			}
+		})
+	}
+}
+
+func TestConvertByFieldID(t *testing.T) {
+	type ItemV1 struct {
+		X int64  `parquet:"x,id=4"`
+		Y string `parquet:"y,id=5"`
+	}
+	type RowV1 struct {
+		A     int64    `parquet:"a,id=1"`
+		B     string   `parquet:"b,id=2"`
+		Gone  int64    `parquet:"gone,id=6"`
+		Items []ItemV1 `parquet:"items,id=3"`
+	}
+
+	type ItemV2 struct {
+		Key   string `parquet:"key,id=5"`
+		Value int64  `parquet:"value,id=4"`
+	}
+	type RowV2 struct {
+		Count   int64    `parquet:"count,id=1"`
+		Entries []ItemV2 `parquet:"entries,id=3"`
+		Label   string   `parquet:"label,id=2"`
+		New     *string  `parquet:"new,id=7"`
+	}
+
+	from := parquet.SchemaOf(RowV1{})
+	to := parquet.SchemaOf(RowV2{})
+
+	conv, err := parquet.ConvertByFieldID(to, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		from RowV1
+		to   RowV2
+	}{
+		{
+			from: RowV1{A: 1, B: "hello", Gone: 42, Items: []ItemV1{{X: 10, Y: "ten"}, {X: 20, Y: "twenty"}}},
+			to:   RowV2{Count: 1, Label: "hello", Entries: []ItemV2{{Key: "ten", Value: 10}, {Key: "twenty", Value: 20}}},
+		},
+		{
+			from: RowV1{A: 2, B: "world"},
+			to:   RowV2{Count: 2, Label: "world", Entries: []ItemV2{}},
+		},
+	} {
+		row, err := conv.Convert(nil, from.Deconstruct(nil, &test.from))
+		if err != nil {
+			t.Fatal(err)
+		}
+		value := RowV2{}
+		if err := to.Reconstruct(&value, row); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(value, test.to) {
+			t.Errorf("converted value mismatch:\nwant = %+v\ngot  = %+v", test.to, value)
+		}
+	}
+
+	for i, want := range []int{0, 4, 3, 1, -1} {
+		if column := conv.Column(i); column != want {
+			t.Errorf("wrong source column for column %d: want=%d got=%d", i, want, column)
+		}
+	}
+}
+
+func BenchmarkConvertByFieldID(b *testing.B) {
+	type RowV1 struct {
+		A int64  `parquet:"a,id=1"`
+		B string `parquet:"b,id=2"`
+	}
+	type RowV2 struct {
+		Count int64  `parquet:"count,id=1"`
+		Label string `parquet:"label,id=2"`
+	}
+
+	conv, err := parquet.ConvertByFieldID(parquet.SchemaOf(RowV2{}), parquet.SchemaOf(RowV1{}))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	src := parquet.SchemaOf(RowV1{}).Deconstruct(nil, &RowV1{A: 1, B: "hello"})
+	dst := parquet.Row{}
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		if dst, err = conv.Convert(dst[:0], src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestConvertByFieldIDConflict(t *testing.T) {
+	from := parquet.NewSchema("test", parquet.Group{
+		"a": parquet.FieldID(parquet.Int(64), 1),
+		"b": parquet.Int(64),
+	})
+	to := parquet.NewSchema("test", parquet.Group{
+		"b": parquet.FieldID(parquet.Int(64), 1),
+	})
+	if _, err := parquet.ConvertByFieldID(to, from); err == nil {
+		t.Fatal("expected an error when two columns are converted to the same column")
+	}
+}
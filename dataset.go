@@ -0,0 +1,343 @@
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Dataset represents a collection of parquet files stored in a directory tree,
+// which are exposed as a single row group.
+//
+// Directories named after the Hive convention "key=value" define partitions of
+// the dataset; the keys become virtual partition columns which can be used to
+// select the files of a dataset with the Prune method, for example:
+//
+//	dataset, err := parquet.OpenDataset(os.DirFS("/data"), "events")
+//	if err != nil {
+//		...
+//	}
+//	defer dataset.Close()
+//
+//	dataset, err = dataset.Prune(parquet.Eq("country", parquet.ValueOf("FR")))
+//	if err != nil {
+//		...
+//	}
+//
+//	rows := dataset.Rows()
+//	...
+//
+// The partition columns are not part of the rows returned by the dataset.
+type Dataset struct {
+	schema     *Schema
+	files      []*DatasetFile
+	partitions *Schema
+	rowGroup   *concatenatedRowGroup
+	closers    []io.Closer
+}
+
+// DatasetFile represents a parquet file of a dataset.
+type DatasetFile struct {
+	*File
+
+	// The path of the file in the dataset file system.
+	Path string
+	// The values of the partition columns of the file, in the order of the
+	// Dataset.PartitionColumns method. Values are null for partitions missing
+	// from the path of the file, or when the partition value is the Hive
+	// default partition.
+	Partition Row
+}
+
+// OpenDataset opens all the parquet files found under the root directory of
+// fsys.
+//
+// Files are discovered recursively and must have the ".parquet" extension;
+// files and directories with names starting with "." or "_" are ignored,
+// following the Hive convention for hidden and metadata files. The options are
+// used to open each parquet file.
+//
+// The schema of the dataset is the merge of the schemas of all its files, as
+// returned by MergeSchemas; the rows of files with a different schema are
+// converted to it, and the function returns an error if the schemas of the
+// files cannot be merged.
+//
+// The parquet files are opened to read their metadata, and remain open until
+// the dataset is closed since their column chunks are read from when rows of
+// the dataset are accessed. Files which do not implement io.ReaderAt are read
+// into memory and closed immediately.
+//
+// The values of partition columns are parsed from the directory names. When
+// all the values of a partition column are integers, the column has the INT64
+// type, otherwise it is a UTF-8 string.
+func OpenDataset(fsys fs.FS, root string, options ...FileOption) (*Dataset, error) {
+	d := new(Dataset)
+
+	err := fs.WalkDir(fsys, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if base := entry.Name(); name != root && (strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_")) {
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() || path.Ext(name) != ".parquet" {
+			return nil
+		}
+		f, err := d.openFile(fsys, name, options)
+		if err != nil {
+			return fmt.Errorf("opening parquet file %s of dataset: %w", name, err)
+		}
+		d.files = append(d.files, f)
+		return nil
+	})
+	if err != nil {
+		d.Close()
+		return nil, err
+	}
+
+	if err := d.init(root); err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+func (d *Dataset) openFile(fsys fs.FS, name string, options []FileOption) (*DatasetFile, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.ReaderAt
+	var size int64
+
+	if ra, ok := f.(io.ReaderAt); ok {
+		d.closers = append(d.closers, f)
+		s, err := f.Stat()
+		if err != nil {
+			return nil, err
+		}
+		r, size = ra, s.Size()
+	} else {
+		// The file cannot be read at random offsets, its content is loaded in
+		// memory and it does not need to remain open.
+		b, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		r, size = bytes.NewReader(b), int64(len(b))
+	}
+
+	file, err := OpenFile(r, size, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &DatasetFile{File: file, Path: name}, nil
+}
+
+func (d *Dataset) init(root string) error {
+	if len(d.files) == 0 {
+		return fmt.Errorf("no parquet files found in %s", root)
+	}
+
+	// The keys map records whether all the values of a partition key are
+	// integers, in which case the partition column has the INT64 type.
+	partitions := make([]map[string]string, len(d.files))
+	keys := make(map[string]bool)
+
+	for i, f := range d.files {
+		partitions[i] = parsePartitions(root, f.Path)
+		for key, value := range partitions[i] {
+			isInt, seen := keys[key]
+			if !seen {
+				isInt = true
+			}
+			if value != HivePartitionDefault {
+				_, err := strconv.ParseInt(value, 10, 64)
+				isInt = isInt && err == nil
+			}
+			keys[key] = isInt
+		}
+	}
+
+	group := make(Group, len(keys))
+	for key, isInt := range keys {
+		if isInt {
+			group[key] = Optional(Int(64))
+		} else {
+			group[key] = Optional(String())
+		}
+	}
+	d.partitions = NewSchema("partitions", group)
+
+	for i, f := range d.files {
+		f.Partition = make(Row, 0, len(keys))
+		if len(keys) == 0 {
+			// An empty group would be seen as a leaf column, the files of a
+			// dataset without partitions have no partition values.
+			continue
+		}
+		forEachLeafColumnOf(d.partitions, func(leaf leafColumn) {
+			columnIndex := int(leaf.columnIndex)
+			value, ok := partitions[i][leaf.path[0]]
+			if !ok || value == HivePartitionDefault {
+				f.Partition = append(f.Partition, Value{}.Level(0, 0, columnIndex))
+				return
+			}
+			v := ValueOf(value)
+			if keys[leaf.path[0]] {
+				n, _ := strconv.ParseInt(value, 10, 64)
+				v = ValueOf(n)
+			}
+			f.Partition = append(f.Partition, v.Level(0, 1, columnIndex))
+		})
+	}
+
+	schemas := make([]*Schema, len(d.files))
+	for i, f := range d.files {
+		schemas[i] = f.schema()
+	}
+	schema, err := MergeSchemas(schemas...)
+	if err != nil {
+		return fmt.Errorf("merging the schemas of the dataset files: %w", err)
+	}
+	d.schema = schema
+	return d.concat()
+}
+
+// concat builds the row group exposing the rows of the dataset files.
+func (d *Dataset) concat() error {
+	rowGroups := make([]RowGroup, 0, len(d.files))
+
+	for _, f := range d.files {
+		schema := f.schema()
+		var conv Conversion
+		if !nodesAreEqual(d.schema, schema) {
+			c, err := Convert(d.schema, schema)
+			if err != nil {
+				return fmt.Errorf("cannot read parquet file %s of dataset: %w", f.Path, err)
+			}
+			conv = c
+		}
+		for i, n := 0, f.NumRowGroups(); i < n; i++ {
+			rowGroup := f.RowGroup(i)
+			if conv != nil {
+				rowGroup = ConvertRowGroup(rowGroup, conv)
+			}
+			rowGroups = append(rowGroups, rowGroup)
+		}
+	}
+
+	d.rowGroup = concat(d.schema, rowGroups)
+	return nil
+}
+
+// parsePartitions returns the Hive partitions found in the directories between
+// root and the file at the given path.
+func parsePartitions(root, name string) map[string]string {
+	partitions := make(map[string]string)
+	dir := strings.TrimPrefix(path.Dir(name), root)
+
+	for _, segment := range strings.Split(dir, "/") {
+		i := strings.IndexByte(segment, '=')
+		if i <= 0 {
+			continue
+		}
+		key, value := segment[:i], segment[i+1:]
+		if k, err := url.PathUnescape(key); err == nil {
+			key = k
+		}
+		if v, err := url.PathUnescape(value); err == nil {
+			value = v
+		}
+		partitions[key] = value
+	}
+
+	return partitions
+}
+
+func (f *DatasetFile) schema() *Schema { return NewSchema(f.root.Name(), f.root) }
+
+// Close closes the files opened by the dataset.
+//
+// Datasets returned by Prune share the files of the dataset they were created
+// from, only the original dataset needs to be closed.
+func (d *Dataset) Close() error {
+	var lastErr error
+	for _, c := range d.closers {
+		if err := c.Close(); err != nil {
+			lastErr = err
+		}
+	}
+	d.closers = nil
+	return lastErr
+}
+
+// Files returns the list of parquet files in the dataset.
+//
+// The method returns the same slice across multiple calls, the program must
+// treat it as a read-only value.
+func (d *Dataset) Files() []*DatasetFile { return d.files }
+
+// PartitionColumns returns the schema of the virtual partition columns of the
+// dataset.
+//
+// The schema has an optional leaf column for each key found in the Hive
+// partitions of the dataset directories.
+func (d *Dataset) PartitionColumns() *Schema { return d.partitions }
+
+// Prune returns a view of the dataset containing only the files where the
+// values of partition columns match the filter passed as argument.
+//
+// The filter must only reference partition columns, the method returns an
+// error otherwise. Filters on the columns of the parquet files can be applied
+// to the rows of the returned dataset with FilterRowGroup or ReaderFilter.
+func (d *Dataset) Prune(filter Filter) (*Dataset, error) {
+	f, err := filter.bind(d.partitions)
+	if err != nil {
+		return nil, fmt.Errorf("pruning dataset partitions: %w", err)
+	}
+
+	pruned := &Dataset{
+		schema:     d.schema,
+		partitions: d.partitions,
+	}
+	for _, file := range d.files {
+		if f.match(file.Partition) {
+			pruned.files = append(pruned.files, file)
+		}
+	}
+	return pruned, pruned.concat()
+}
+
+// NumRows returns the total number of rows in the dataset.
+func (d *Dataset) NumRows() int64 { return d.rowGroup.NumRows() }
+
+// NumColumns returns the number of leaf columns in the dataset.
+func (d *Dataset) NumColumns() int { return d.rowGroup.NumColumns() }
+
+// Column returns the column chunk concatenating the column at index i of all
+// the files in the dataset.
+func (d *Dataset) Column(i int) ColumnChunk { return d.rowGroup.Column(i) }
+
+// SortingColumns returns nil, the rows of a dataset are not sorted.
+func (d *Dataset) SortingColumns() []SortingColumn { return nil }
+
+// Schema returns the schema of rows in the dataset.
+func (d *Dataset) Schema() *Schema { return d.schema }
+
+// Rows returns a reader exposing the rows of all the files in the dataset.
+func (d *Dataset) Rows() Rows { return d.rowGroup.Rows() }
+
+var (
+	_ RowGroup = (*Dataset)(nil)
+)
@@ -0,0 +1,202 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"testing"
+	"testing/fstest"
+
+	"github.com/segmentio/parquet-go"
+)
+
+type datasetRow struct {
+	ID   int64  `parquet:"id"`
+	Name string `parquet:"name"`
+}
+
+func writeDatasetFile(t *testing.T, rows ...datasetRow) *fstest.MapFile {
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	for i := range rows {
+		if err := writer.Write(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &fstest.MapFile{Data: buffer.Bytes()}
+}
+
+func readDatasetIDs(t *testing.T, rows parquet.Rows) []int64 {
+	ids := []int64{}
+	for {
+		row, err := rows.ReadRow(nil)
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+		ids = append(ids, row[0].Int64())
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func TestOpenDataset(t *testing.T) {
+	fsys := fstest.MapFS{
+		"table/country=FR/year=2021/part-0.parquet":  writeDatasetFile(t, datasetRow{1, "a"}, datasetRow{2, "b"}),
+		"table/country=FR/year=2022/part-0.parquet":  writeDatasetFile(t, datasetRow{3, "c"}),
+		"table/country=US/year=2022/part-0.parquet":  writeDatasetFile(t, datasetRow{4, "d"}),
+		"table/country=US/year=2022/part-1.parquet":  writeDatasetFile(t, datasetRow{5, "e"}, datasetRow{6, "f"}),
+		"table/country=US/year=2022/_SUCCESS":        &fstest.MapFile{},
+		"table/country=US/year=2022/.part-2.parquet": &fstest.MapFile{Data: []byte("invalid")},
+		"table/_tmp/part-3.parquet":                  &fstest.MapFile{Data: []byte("invalid")},
+	}
+
+	dataset, err := parquet.OpenDataset(fsys, "table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dataset.Close()
+
+	if n := len(dataset.Files()); n != 4 {
+		t.Fatalf("wrong number of files: want=4 got=%d", n)
+	}
+	if numRows := dataset.NumRows(); numRows != 6 {
+		t.Fatalf("wrong number of rows: want=6 got=%d", numRows)
+	}
+	if ids := readDatasetIDs(t, dataset.Rows()); len(ids) != 6 {
+		t.Fatalf("wrong rows read from the dataset: %v", ids)
+	}
+
+	partitions := dataset.PartitionColumns()
+	if names := partitions.ChildNames(); len(names) != 2 || names[0] != "country" || names[1] != "year" {
+		t.Fatalf("wrong partition columns: %v", names)
+	}
+	if kind := partitions.ChildByName("year").Type().Kind(); kind != parquet.Int64 {
+		t.Fatalf("wrong type of integer partition column: %s", kind)
+	}
+
+	tests := []struct {
+		filter parquet.Filter
+		ids    []int64
+	}{
+		{parquet.Eq("country", parquet.ValueOf("FR")), []int64{1, 2, 3}},
+		{parquet.Eq("year", parquet.ValueOf(2022)), []int64{3, 4, 5, 6}},
+		{parquet.And(parquet.Eq("country", parquet.ValueOf("US")), parquet.Lt("year", parquet.ValueOf(2022))), []int64{}},
+		{parquet.Or(parquet.Eq("country", parquet.ValueOf("US")), parquet.Lt("year", parquet.ValueOf(2022))), []int64{1, 2, 4, 5, 6}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter.String(), func(t *testing.T) {
+			pruned, err := dataset.Prune(test.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := readDatasetIDs(t, pruned.Rows())
+			if len(ids) != len(test.ids) {
+				t.Fatalf("wrong rows: want=%v got=%v", test.ids, ids)
+			}
+			for i := range ids {
+				if ids[i] != test.ids[i] {
+					t.Fatalf("wrong rows: want=%v got=%v", test.ids, ids)
+				}
+			}
+		})
+	}
+
+	if _, err := dataset.Prune(parquet.Eq("name", parquet.ValueOf("a"))); err == nil {
+		t.Fatal("expected an error when pruning on a column which is not a partition column")
+	}
+}
+
+func TestOpenDatasetEmpty(t *testing.T) {
+	if _, err := parquet.OpenDataset(fstest.MapFS{"table/README": &fstest.MapFile{}}, "table"); err == nil {
+		t.Fatal("expected an error when opening a dataset without parquet files")
+	}
+}
+
+func TestOpenDatasetMergeSchemas(t *testing.T) {
+	type extendedRow struct {
+		ID    int64  `parquet:"id"`
+		Name  string `parquet:"name"`
+		Email string `parquet:"email"`
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	if err := writer.Write(&extendedRow{ID: 2, Name: "b", Email: "b@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := fstest.MapFS{
+		"table/part-0.parquet": writeDatasetFile(t, datasetRow{1, "a"}),
+		"table/part-1.parquet": &fstest.MapFile{Data: buffer.Bytes()},
+	}
+
+	dataset, err := parquet.OpenDataset(fsys, "table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dataset.Close()
+
+	schema := dataset.Schema()
+	email := schema.ChildByName("email")
+	if email == nil {
+		t.Fatalf("column of the second file missing from the dataset schema:\n%s", schema)
+	}
+	if !email.Optional() {
+		t.Fatalf("column missing from the first file is not optional in the dataset schema:\n%s", schema)
+	}
+
+	columns := map[string]int{}
+	for i, name := range schema.ChildNames() {
+		columns[name] = i
+	}
+
+	emails := map[int64]string{}
+	rows := dataset.Rows()
+	for {
+		row, err := rows.ReadRow(nil)
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+		var id int64
+		var address string
+		for _, v := range row {
+			switch v.Column() {
+			case columns["id"]:
+				id = v.Int64()
+			case columns["email"]:
+				if !v.IsNull() {
+					address = v.String()
+				}
+			}
+		}
+		emails[id] = address
+	}
+
+	if len(emails) != 2 || emails[1] != "" || emails[2] != "b@example.com" {
+		t.Fatalf("wrong rows read from the dataset: %v", emails)
+	}
+
+	if err := rows.SeekToRow(1); err != nil {
+		t.Fatal(err)
+	}
+	row, err := rows.ReadRow(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := row[columns["id"]].Int64(); id != 2 {
+		t.Fatalf("wrong row read after seeking: want=2 got=%d", id)
+	}
+}
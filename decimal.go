@@ -0,0 +1,234 @@
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/segmentio/parquet-go/format"
+)
+
+// ErrDecimalOverflow is an error returned by writers when a DECIMAL value has
+// more digits than the precision of the column it is written to.
+var ErrDecimalOverflow = errors.New("decimal value exceeds the precision of the column")
+
+var (
+	bigIntType = reflect.TypeOf(big.Int{})
+	bigRatType = reflect.TypeOf(big.Rat{})
+	bigOne     = big.NewInt(1)
+	bigTen     = big.NewInt(10)
+)
+
+// isDecimalGoType returns true if t (or the type that t points to) is one of
+// the math/big types that can represent DECIMAL values.
+func isDecimalGoType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == bigIntType || t == bigRatType
+}
+
+// maxDecimalPrecision returns the maximum precision of DECIMAL values that can
+// be stored in the physical type t, or zero if the precision is unbounded.
+func maxDecimalPrecision(t Type) int {
+	switch t.Kind() {
+	case Int32:
+		return 9
+	case Int64:
+		return 18
+	case FixedLenByteArray:
+		// floor(log10(2^(8n-1) - 1)), which is the number of digits of the
+		// largest signed integer minus one.
+		max := new(big.Int).Lsh(bigOne, uint(8*t.Length()-1))
+		return len(max.String()) - 1
+	default:
+		return 0
+	}
+}
+
+// decimalTypeOf returns the smallest physical type able to hold DECIMAL values
+// of the given precision.
+func decimalTypeOf(precision int) Type {
+	switch {
+	case precision <= 9:
+		return Int32Type
+	case precision <= 18:
+		return Int64Type
+	}
+	for n := 9; ; n++ {
+		if t := FixedLenByteArrayType(n); maxDecimalPrecision(t) >= precision {
+			return t
+		}
+	}
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(bigTen, big.NewInt(int64(n)), nil)
+}
+
+// decimalBytes returns the big-endian two's complement representation of x on
+// n bytes, or false if x cannot be represented on n bytes.
+func decimalBytes(x *big.Int, n int) ([]byte, bool) {
+	if x.BitLen() > 8*n-1 {
+		return nil, false
+	}
+	b := make([]byte, n)
+	if x.Sign() >= 0 {
+		x.FillBytes(b)
+	} else {
+		c := new(big.Int).Lsh(bigOne, uint(8*n))
+		c.Add(c, x)
+		c.FillBytes(b)
+	}
+	return b, true
+}
+
+// decimalOfBytes is the inverse of decimalBytes.
+func decimalOfBytes(b []byte) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		x.Sub(x, new(big.Int).Lsh(bigOne, uint(8*len(b))))
+	}
+	return x
+}
+
+// decimalOf returns the unscaled value of v, or nil if v does not have a kind
+// that can hold DECIMAL values.
+func decimalOf(v Value) *big.Int {
+	switch v.Kind() {
+	case Int32:
+		return big.NewInt(int64(v.Int32()))
+	case Int64:
+		return big.NewInt(v.Int64())
+	case ByteArray, FixedLenByteArray:
+		return decimalOfBytes(v.ByteArray())
+	default:
+		return nil
+	}
+}
+
+// makeDecimalValue constructs a value of type t from the go value v, which may
+// be a big.Int holding the unscaled value or a big.Rat, which is multiplied by
+// 10^scale and truncated. Other go values are converted by makeValue.
+//
+// Values that do not fit in the physical type are kept as byte arrays so that
+// writers can report the overflow when checking the precision of the column.
+func makeDecimalValue(t Type, scale int, v reflect.Value) Value {
+	if !isDecimalGoType(v.Type()) {
+		return makeValue(t.Kind(), v)
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			v = reflect.Zero(v.Type().Elem())
+		} else {
+			v = v.Elem()
+		}
+	}
+
+	var x *big.Int
+	switch v.Type() {
+	case bigIntType:
+		i := v.Interface().(big.Int)
+		x = &i
+	case bigRatType:
+		r := v.Interface().(big.Rat)
+		x = new(big.Int).Mul(r.Num(), pow10(scale))
+		x.Quo(x, r.Denom())
+	}
+
+	switch t.Kind() {
+	case Int32:
+		if x.IsInt64() {
+			if i := x.Int64(); int64(int32(i)) == i {
+				return makeValueInt32(int32(i))
+			}
+		}
+	case Int64:
+		if x.IsInt64() {
+			return makeValueInt64(x.Int64())
+		}
+	case FixedLenByteArray:
+		if b, ok := decimalBytes(x, t.Length()); ok {
+			return makeValueBytes(FixedLenByteArray, b)
+		}
+	}
+
+	b, _ := decimalBytes(x, x.BitLen()/8+1)
+	return makeValueBytes(ByteArray, b)
+}
+
+// assignDecimalValue assigns the DECIMAL value src to dst, which may be a
+// big.Int receiving the unscaled value or a big.Rat receiving the value divided
+// by 10^scale. Other go values are assigned by assignValue.
+func assignDecimalValue(dst reflect.Value, scale int, src Value) error {
+	if !isDecimalGoType(dst.Type()) {
+		return assignValue(dst, src)
+	}
+	if dst.Kind() == reflect.Ptr {
+		if src.IsNull() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return nil
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		dst = dst.Elem()
+	}
+	if src.IsNull() {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	x := decimalOf(src)
+	if x == nil {
+		return fmt.Errorf("cannot assign parquet value of type %s to decimal value of type %s", src.Kind(), dst.Type())
+	}
+
+	switch dst.Type() {
+	case bigIntType:
+		dst.Set(reflect.ValueOf(*x))
+	case bigRatType:
+		dst.Set(reflect.ValueOf(*new(big.Rat).SetFrac(x, pow10(scale))))
+	}
+	return nil
+}
+
+// decimalChecker validates that values written to a DECIMAL column do not
+// exceed its precision.
+type decimalChecker struct {
+	precision int
+	max       int64    // 10^precision when precision <= 18
+	bigMax    *big.Int // 10^precision
+}
+
+func newDecimalChecker(decimal *format.DecimalType) *decimalChecker {
+	c := &decimalChecker{
+		precision: int(decimal.Precision),
+		bigMax:    pow10(int(decimal.Precision)),
+	}
+	if c.bigMax.IsInt64() {
+		c.max = c.bigMax.Int64()
+	}
+	return c
+}
+
+func (c *decimalChecker) check(v Value) bool {
+	if v.IsNull() {
+		return true
+	}
+	switch v.Kind() {
+	case Int32, Int64:
+		if c.max != 0 {
+			i := v.Int64()
+			if v.Kind() == Int32 {
+				i = int64(v.Int32())
+			}
+			return i < c.max && i > -c.max
+		}
+	}
+	if x := decimalOf(v); x != nil {
+		return x.CmpAbs(c.bigMax) < 0
+	}
+	return true
+}
@@ -0,0 +1,139 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+type decimalRow struct {
+	Amount  int32    `parquet:"amount,decimal(2:9)"`
+	Balance int64    `parquet:"balance,decimal(3:18)"`
+	Count   big.Int  `parquet:"count,decimal(0:30)"`
+	Price   *big.Rat `parquet:"price,decimal(4:38),optional"`
+	Raw     []byte   `parquet:"raw,decimal(2:50)"`
+}
+
+func TestDecimalSchema(t *testing.T) {
+	schema := parquet.SchemaOf(decimalRow{})
+
+	tests := []struct {
+		column string
+		kind   parquet.Kind
+		length int
+	}{
+		{column: "amount", kind: parquet.Int32},
+		{column: "balance", kind: parquet.Int64},
+		{column: "count", kind: parquet.FixedLenByteArray, length: 13},
+		{column: "price", kind: parquet.FixedLenByteArray, length: 16},
+		{column: "raw", kind: parquet.ByteArray},
+	}
+
+	for _, test := range tests {
+		t.Run(test.column, func(t *testing.T) {
+			typ := schema.ChildByName(test.column).Type()
+			if typ.LogicalType() == nil || typ.LogicalType().Decimal == nil {
+				t.Fatalf("column is not a decimal: %s", typ)
+			}
+			if kind := typ.Kind(); kind != test.kind {
+				t.Errorf("wrong kind: want=%s got=%s", test.kind, kind)
+			}
+			if test.kind == parquet.FixedLenByteArray && typ.Length() != test.length {
+				t.Errorf("wrong length: want=%d got=%d", test.length, typ.Length())
+			}
+		})
+	}
+}
+
+func TestDecimalRoundTrip(t *testing.T) {
+	count, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	price, _ := new(big.Rat).SetString("-98765432109876543210.1234")
+
+	rows := []decimalRow{
+		{Amount: 12345, Balance: -1, Price: big.NewRat(1, 4), Raw: []byte{0x7f}},
+		{Amount: -999999999, Balance: 999999999999999999, Count: *count, Price: price, Raw: []byte{0xff, 0x00}},
+		{Count: *big.NewInt(-1)},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	for i := range rows {
+		if err := writer.Write(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, column := range []string{"amount", "balance", "count", "price", "raw"} {
+		got := f.Root().Column(column).Type().String()
+		want := parquet.SchemaOf(decimalRow{}).ChildByName(column).Type().String()
+		if got != want {
+			t.Errorf("wrong type of column %s read from the file: want=%s got=%s", column, want, got)
+		}
+	}
+
+	reader := parquet.NewReader(f)
+	for i := range rows {
+		row := decimalRow{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		want := &rows[i]
+		if row.Amount != want.Amount || row.Balance != want.Balance || !bytes.Equal(row.Raw, want.Raw) {
+			t.Errorf("row %d: integer or byte array decimals mismatch:\nwant = %+v\ngot  = %+v", i, want, row)
+		}
+		if row.Count.Cmp(&want.Count) != 0 {
+			t.Errorf("row %d: wrong count: want=%s got=%s", i, &want.Count, &row.Count)
+		}
+		switch {
+		case want.Price == nil:
+			if row.Price != nil {
+				t.Errorf("row %d: wrong price: want=<nil> got=%s", i, row.Price)
+			}
+		case row.Price == nil || row.Price.Cmp(want.Price) != 0:
+			t.Errorf("row %d: wrong price: want=%s got=%s", i, want.Price, row.Price)
+		}
+	}
+	if err := reader.Read(new(decimalRow)); err != io.EOF {
+		t.Errorf("expected io.EOF after the last row but got %v", err)
+	}
+}
+
+func TestDecimalOverflow(t *testing.T) {
+	tooLarge, _ := new(big.Int).SetString("1000000000000000000000000000000", 10)
+
+	tests := []struct {
+		scenario string
+		row      decimalRow
+	}{
+		{scenario: "int32", row: decimalRow{Amount: 1000000000}},
+		{scenario: "int64", row: decimalRow{Balance: -1000000000000000000}},
+		{scenario: "big.Int", row: decimalRow{Count: *tooLarge}},
+		{scenario: "big.Rat", row: decimalRow{Price: new(big.Rat).SetInt(new(big.Int).Mul(tooLarge, tooLarge))}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			writer := parquet.NewWriter(io.Discard, parquet.SchemaOf(decimalRow{}))
+			if err := writer.Write(&decimalRow{Amount: 1}); err != nil {
+				t.Fatal(err)
+			}
+			if err := writer.Write(&test.row); !errors.Is(err, parquet.ErrDecimalOverflow) {
+				t.Fatalf("expected a decimal overflow error but got %v", err)
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
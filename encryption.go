@@ -0,0 +1,730 @@
+package parquet
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/segmentio/encoding/thrift"
+	"github.com/segmentio/parquet-go/format"
+)
+
+// KeyRetriever is an interface implemented by types that provide the keys used
+// to encrypt and decrypt parquet files, for example a client of a key
+// management service.
+//
+// Key metadata are opaque byte sequences stored in the parquet files next to
+// the modules that they encrypted, typically carrying an identifier of the key
+// in the key management service. The keys returned by RetrieveKey must be 16,
+// 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+type KeyRetriever interface {
+	RetrieveKey(keyMetadata []byte) ([]byte, error)
+}
+
+// KeyRetrieverFunc is an implementation of the KeyRetriever interface for
+// functions.
+type KeyRetrieverFunc func(keyMetadata []byte) ([]byte, error)
+
+// RetrieveKey calls f(keyMetadata).
+func (f KeyRetrieverFunc) RetrieveKey(keyMetadata []byte) ([]byte, error) {
+	return f(keyMetadata)
+}
+
+// KeyMap is an implementation of the KeyRetriever interface which looks up keys
+// in a map, using the key metadata as map key.
+//
+// KeyMap is mostly useful for testing, or when keys are loaded from a local
+// source.
+type KeyMap map[string][]byte
+
+// RetrieveKey returns the key associated with keyMetadata in m, or an error if
+// the key did not exist.
+func (m KeyMap) RetrieveKey(keyMetadata []byte) ([]byte, error) {
+	key, ok := m[string(keyMetadata)]
+	if !ok {
+		return nil, fmt.Errorf("key not found: %q: %w", keyMetadata, ErrKeyNotFound)
+	}
+	return key, nil
+}
+
+// EncryptionAlgorithm represents the algorithms used to encrypt parquet files.
+type EncryptionAlgorithm int
+
+const (
+	// AESGCM encrypts all the modules of parquet files with AES-GCM, both data
+	// and metadata are authenticated. This is the default algorithm.
+	AESGCM EncryptionAlgorithm = iota
+
+	// AESGCMCTR encrypts page data with AES-CTR, and all other modules with
+	// AES-GCM. The page data are not authenticated, which makes encryption
+	// faster at the expense of not detecting tampering of the page content.
+	AESGCMCTR
+)
+
+// String returns a human-readable representation of the algorithm.
+func (a EncryptionAlgorithm) String() string {
+	switch a {
+	case AESGCM:
+		return "AES_GCM_V1"
+	case AESGCMCTR:
+		return "AES_GCM_CTR_V1"
+	default:
+		return fmt.Sprintf("EncryptionAlgorithm(%d)", int(a))
+	}
+}
+
+// EncryptionConfig carries the configuration of parquet writers producing
+// encrypted files.
+//
+// The keys are never stored in the configuration, only their metadata; the
+// writer obtains the keys from the KeyRetriever set in its configuration.
+type EncryptionConfig struct {
+	// The algorithm used to encrypt the file, AESGCM by default.
+	Algorithm EncryptionAlgorithm
+
+	// Metadata of the key used to encrypt the file footer, and the columns
+	// which are encrypted with the footer key.
+	FooterKeyMetadata []byte
+
+	// Metadata of the keys used to encrypt columns, indexed by the dotted
+	// paths of the columns (e.g. "user.email").
+	//
+	// When the map is empty, all columns are encrypted with the footer key.
+	// Otherwise, only the columns present in the map are encrypted, and the
+	// others are written in plaintext.
+	ColumnKeyMetadata map[string][]byte
+
+	// When true, the footer is written in plaintext and signed with the footer
+	// key, allowing readers which do not have the keys to read the schema and
+	// the unencrypted columns.
+	PlaintextFooter bool
+
+	// An optional prefix of the additional authenticated data of all encrypted
+	// modules, which readers must know to decrypt the file (e.g. a table name
+	// to prevent files from being swapped between tables).
+	AADPrefix []byte
+
+	// When true, the AAD prefix is not stored in the file; readers have to be
+	// configured with the DecryptionAADPrefix option to read it.
+	SupplyAADPrefix bool
+}
+
+// Errors returned when reading and writing encrypted parquet files.
+var (
+	// ErrKeyNotFound is returned by KeyMap when a key metadata is not present
+	// in the map.
+	ErrKeyNotFound = errors.New("encryption key not found")
+
+	// ErrMissingKeyRetriever is returned when opening an encrypted parquet file
+	// without configuring a key retriever.
+	ErrMissingKeyRetriever = errors.New("missing key retriever to read encrypted parquet file")
+
+	// ErrDecryption is returned when decrypting modules of parquet files fails,
+	// either because the wrong keys or AAD prefix were used, or because the
+	// file was tampered with.
+	ErrDecryption = errors.New("parquet decryption failed")
+)
+
+// Module types used to construct the additional authenticated data of modules,
+// as defined by the parquet modular encryption specification.
+//
+// https://github.com/apache/parquet-format/blob/master/Encryption.md#442-aad-suffix
+const (
+	footerModule byte = iota
+	columnMetaDataModule
+	dataPageModule
+	dictionaryPageModule
+	dataPageHeaderModule
+	dictionaryPageHeaderModule
+	columnIndexModule
+	offsetIndexModule
+	bloomFilterHeaderModule
+	bloomFilterBitsetModule
+)
+
+const (
+	encryptionLengthSize     = 4
+	encryptionNonceSize      = 12
+	encryptionTagSize        = 16
+	encryptionFileUniqueSize = 8
+	footerSignatureSize      = encryptionNonceSize + encryptionTagSize
+)
+
+// module identifies an encrypted module in a parquet file.
+type module struct {
+	kind     byte
+	rowGroup int
+	column   int
+	page     int
+}
+
+func (m module) appendAAD(aad []byte) ([]byte, error) {
+	aad = append(aad, m.kind)
+	if m.kind == footerModule {
+		return aad, nil
+	}
+	if m.rowGroup > math.MaxInt16 || m.column > math.MaxInt16 || m.page > math.MaxInt16 {
+		return aad, fmt.Errorf("encrypted parquet files cannot have more than %d row groups, columns or pages per column chunk", math.MaxInt16)
+	}
+	aad = append(aad, byte(m.rowGroup), byte(m.rowGroup>>8))
+	aad = append(aad, byte(m.column), byte(m.column>>8))
+	if m.kind == dataPageModule || m.kind == dataPageHeaderModule {
+		aad = append(aad, byte(m.page), byte(m.page>>8))
+	}
+	return aad, nil
+}
+
+func (m module) isPageData() bool {
+	return m.kind == dataPageModule || m.kind == dictionaryPageModule
+}
+
+// moduleCipher encrypts and decrypts the modules of a parquet file with a
+// single key.
+//
+// Encrypted modules are made of a 4 bytes little-endian length, followed by a
+// 12 bytes nonce and the ciphertext. Modules encrypted with AES-GCM are
+// terminated by a 16 bytes authentication tag.
+type moduleCipher struct {
+	gcm   cipher.AEAD
+	block cipher.Block
+	// The file AAD (prefix + unique file identifier) is shared by all ciphers
+	// of a file, writers generate a new file identifier in-place when they are
+	// reset.
+	aad []byte
+	ctr bool
+}
+
+func newModuleCipher(key, aad []byte, algorithm EncryptionAlgorithm) (*moduleCipher, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parquet encryption key: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &moduleCipher{
+		gcm:   gcm,
+		block: block,
+		aad:   aad,
+		ctr:   algorithm == AESGCMCTR,
+	}, nil
+}
+
+func (c *moduleCipher) moduleAAD(m module) ([]byte, error) {
+	aad := make([]byte, len(c.aad), len(c.aad)+7)
+	copy(aad, c.aad)
+	return m.appendAAD(aad)
+}
+
+// encrypt appends the encrypted module of plaintext to dst.
+func (c *moduleCipher) encrypt(dst, plaintext []byte, m module) ([]byte, error) {
+	offset := len(dst)
+	dst = append(dst, make([]byte, encryptionLengthSize+encryptionNonceSize)...)
+	nonce := dst[offset+encryptionLengthSize:]
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return dst[:offset], fmt.Errorf("generating parquet encryption nonce: %w", err)
+	}
+
+	if c.ctr && m.isPageData() {
+		dst = append(dst, plaintext...)
+		c.ctrStream(nonce).XORKeyStream(dst[len(dst)-len(plaintext):], plaintext)
+	} else {
+		aad, err := c.moduleAAD(m)
+		if err != nil {
+			return dst[:offset], err
+		}
+		dst = c.gcm.Seal(dst, nonce, plaintext, aad)
+	}
+
+	binary.LittleEndian.PutUint32(dst[offset:], uint32(len(dst)-(offset+encryptionLengthSize)))
+	return dst, nil
+}
+
+// decrypt appends the plaintext of the encrypted module in src to dst.
+func (c *moduleCipher) decrypt(dst, src []byte, m module) ([]byte, error) {
+	if len(src) < encryptionLengthSize+encryptionNonceSize {
+		return dst, fmt.Errorf("encrypted module is too short: %d bytes: %w", len(src), ErrCorrupted)
+	}
+	length := binary.LittleEndian.Uint32(src)
+	src = src[encryptionLengthSize:]
+	if uint64(length) != uint64(len(src)) {
+		return dst, fmt.Errorf("encrypted module length mismatch: %d != %d: %w", length, len(src), ErrCorrupted)
+	}
+	nonce, ciphertext := src[:encryptionNonceSize], src[encryptionNonceSize:]
+
+	if c.ctr && m.isPageData() {
+		offset := len(dst)
+		dst = append(dst, ciphertext...)
+		c.ctrStream(nonce).XORKeyStream(dst[offset:], ciphertext)
+		return dst, nil
+	}
+
+	aad, err := c.moduleAAD(m)
+	if err != nil {
+		return dst, err
+	}
+	plaintext, err := c.gcm.Open(dst, nonce, ciphertext, aad)
+	if err != nil {
+		return dst, ErrDecryption
+	}
+	return plaintext, nil
+}
+
+func (c *moduleCipher) ctrStream(nonce []byte) cipher.Stream {
+	// The AES-CTR counter is made of the 12 bytes nonce followed by a 4 bytes
+	// big-endian block counter starting at 1.
+	iv := [aes.BlockSize]byte{aes.BlockSize - 1: 1}
+	copy(iv[:], nonce)
+	return cipher.NewCTR(c.block, iv[:])
+}
+
+// sign returns the signature of a plaintext footer: the nonce and tag produced
+// by encrypting the footer with AES-GCM.
+func (c *moduleCipher) sign(footer []byte) ([]byte, error) {
+	module, err := c.encrypt(nil, footer, module{kind: footerModule})
+	if err != nil {
+		return nil, err
+	}
+	nonce := module[encryptionLengthSize : encryptionLengthSize+encryptionNonceSize]
+	tag := module[len(module)-encryptionTagSize:]
+	return append(nonce, tag...), nil
+}
+
+func (c *moduleCipher) verify(footer, signature []byte) error {
+	if len(signature) != footerSignatureSize {
+		return fmt.Errorf("invalid parquet footer signature length: %d: %w", len(signature), ErrCorrupted)
+	}
+	aad, err := c.moduleAAD(module{kind: footerModule})
+	if err != nil {
+		return err
+	}
+	nonce, tag := signature[:encryptionNonceSize], signature[encryptionNonceSize:]
+	sealed := c.gcm.Seal(nil, nonce, footer, aad)
+	// The tags are compared in constant time to avoid leaking how many bytes
+	// of a forged signature are valid.
+	if subtle.ConstantTimeCompare(sealed[len(sealed)-encryptionTagSize:], tag) != 1 {
+		return fmt.Errorf("verifying signature of parquet footer: %w", ErrDecryption)
+	}
+	return nil
+}
+
+// readModule reads an encrypted module from r into buf, returning the module
+// including its length prefix.
+//
+// The limit is the number of bytes that remain to be read from r, the function
+// returns an error without allocating memory when the length prefix of the
+// module exceeds it.
+func readModule(r io.Reader, buf []byte, limit int64) ([]byte, error) {
+	var length [encryptionLengthSize]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return buf, err
+	}
+	n := int(binary.LittleEndian.Uint32(length[:]))
+	if int64(n) > limit-encryptionLengthSize {
+		return buf, fmt.Errorf("encrypted module of length %d exceeds the %d bytes remaining to be read: %w", n, limit-encryptionLengthSize, ErrCorrupted)
+	}
+	if cap(buf) < encryptionLengthSize+n {
+		buf = make([]byte, encryptionLengthSize+n)
+	}
+	buf = buf[:encryptionLengthSize+n]
+	copy(buf, length[:])
+	if _, err := io.ReadFull(r, buf[encryptionLengthSize:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return buf, err
+	}
+	return buf, nil
+}
+
+func retrieveKey(keys KeyRetriever, keyMetadata []byte, algorithm EncryptionAlgorithm, aad []byte) (*moduleCipher, error) {
+	if keys == nil {
+		return nil, ErrMissingKeyRetriever
+	}
+	key, err := keys.RetrieveKey(keyMetadata)
+	if err != nil {
+		return nil, err
+	}
+	return newModuleCipher(key, aad, algorithm)
+}
+
+// fileEncryptor holds the state used by writers to produce encrypted files.
+type fileEncryptor struct {
+	// Key retrieval errors are reported when the writer first attempts to
+	// encrypt a module, since writer constructors cannot return errors.
+	err        error
+	config     *EncryptionConfig
+	algorithm  format.EncryptionAlgorithm
+	aad        []byte
+	fileUnique []byte
+	footer     *moduleCipher
+}
+
+func newFileEncryptor(config *EncryptionConfig, keys KeyRetriever) *fileEncryptor {
+	e := &fileEncryptor{config: config}
+	e.aad = make([]byte, 0, len(config.AADPrefix)+encryptionFileUniqueSize)
+	e.aad = append(e.aad, config.AADPrefix...)
+	e.aad = append(e.aad, make([]byte, encryptionFileUniqueSize)...)
+	e.fileUnique = e.aad[len(config.AADPrefix):]
+	e.reset()
+
+	var aadPrefix []byte
+	if !config.SupplyAADPrefix {
+		aadPrefix = config.AADPrefix
+	}
+	switch config.Algorithm {
+	case AESGCMCTR:
+		e.algorithm.AesGcmCtrV1 = &format.AesGcmCtrV1{
+			AadPrefix:       aadPrefix,
+			AadFileUnique:   e.fileUnique,
+			SupplyAadPrefix: config.SupplyAADPrefix,
+		}
+	default:
+		e.algorithm.AesGcmV1 = &format.AesGcmV1{
+			AadPrefix:       aadPrefix,
+			AadFileUnique:   e.fileUnique,
+			SupplyAadPrefix: config.SupplyAADPrefix,
+		}
+	}
+
+	footer, err := retrieveKey(keys, config.FooterKeyMetadata, config.Algorithm, e.aad)
+	if err != nil {
+		e.err = fmt.Errorf("retrieving parquet footer encryption key: %w", err)
+	}
+	e.footer = footer
+	return e
+}
+
+// reset generates a new unique file identifier, which is shared by all the
+// module ciphers of the file.
+func (e *fileEncryptor) reset() {
+	if _, err := io.ReadFull(rand.Reader, e.fileUnique); err != nil && e.err == nil {
+		e.err = fmt.Errorf("generating parquet file AAD: %w", err)
+	}
+}
+
+// columnEncryption returns the encryption state of the column at the given
+// path, or nil if the column is not encrypted.
+func (e *fileEncryptor) columnEncryption(path columnPath, keys KeyRetriever) *columnEncryption {
+	columnKeys := e.config.ColumnKeyMetadata
+	if len(columnKeys) == 0 {
+		return &columnEncryption{
+			cipher:   e.footer,
+			metadata: format.ColumnCryptoMetaData{EncryptionWithFooterKey: &format.EncryptionWithFooterKey{}},
+			err:      e.err,
+		}
+	}
+
+	keyMetadata, ok := columnKeys[path.String()]
+	if !ok {
+		return nil
+	}
+	if bytes.Equal(keyMetadata, e.config.FooterKeyMetadata) {
+		return &columnEncryption{
+			cipher:   e.footer,
+			metadata: format.ColumnCryptoMetaData{EncryptionWithFooterKey: &format.EncryptionWithFooterKey{}},
+			err:      e.err,
+		}
+	}
+
+	c := &columnEncryption{
+		metadata: format.ColumnCryptoMetaData{
+			EncryptionWithColumnKey: &format.EncryptionWithColumnKey{
+				PathInSchema: path,
+				KeyMetadata:  keyMetadata,
+			},
+		},
+		withColumnKey: true,
+	}
+	cipher, err := retrieveKey(keys, keyMetadata, e.config.Algorithm, e.aad)
+	if err != nil {
+		c.err = fmt.Errorf("retrieving encryption key of parquet column %q: %w", path, err)
+		if e.err == nil {
+			e.err = c.err
+		}
+	}
+	c.cipher = cipher
+	return c
+}
+
+// checkColumnKeys returns an error if column keys were configured for columns
+// which do not exist in the schema.
+func (e *fileEncryptor) checkColumnKeys(schema *Schema) {
+	paths := make([]string, 0, len(e.config.ColumnKeyMetadata))
+	for path := range e.config.ColumnKeyMetadata {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		found := false
+		forEachLeafColumnOf(schema, func(leaf leafColumn) {
+			found = found || columnPath(leaf.path).String() == path
+		})
+		if !found && e.err == nil {
+			e.err = fmt.Errorf("encryption key configured for parquet column %q which does not exist in the schema", path)
+		}
+	}
+}
+
+func (e *fileEncryptor) magic() string {
+	if e.config.PlaintextFooter {
+		return "PAR1"
+	}
+	return "PARE"
+}
+
+// encryptColumnMetaData sets the crypto metadata of column chunks, and the
+// encrypted column metadata when the footer is not enough to protect them.
+func (e *fileEncryptor) encryptColumnMetaData(chunk *format.ColumnChunk, c *columnEncryption, rowGroup, column int) error {
+	chunk.CryptoMetadata = c.metadata
+	if !c.withColumnKey && !e.config.PlaintextFooter {
+		// The column metadata are protected by the footer encryption.
+		return nil
+	}
+
+	metadata, err := thrift.Marshal(new(thrift.CompactProtocol), &chunk.MetaData)
+	if err != nil {
+		return err
+	}
+	chunk.EncryptedColumnMetadata, err = c.cipher.encrypt(nil, metadata, module{
+		kind:     columnMetaDataModule,
+		rowGroup: rowGroup,
+		column:   column,
+	})
+	if err != nil {
+		return err
+	}
+
+	if e.config.PlaintextFooter {
+		// Legacy readers need the column metadata to read the plaintext
+		// columns, we only strip the statistics which may leak information
+		// about the encrypted values.
+		chunk.MetaData.Statistics = format.Statistics{}
+	} else {
+		chunk.MetaData = format.ColumnMetaData{}
+	}
+	return nil
+}
+
+// encryptFooter returns the footer of the encrypted file, including the
+// length and magic suffix.
+func (e *fileEncryptor) encryptFooter(metadata *format.FileMetaData) ([]byte, error) {
+	var footer []byte
+	var err error
+
+	if e.config.PlaintextFooter {
+		metadata.EncryptionAlgorithm = e.algorithm
+		metadata.FooterSigningKeyMetadata = e.config.FooterKeyMetadata
+		if footer, err = thrift.Marshal(new(thrift.CompactProtocol), metadata); err != nil {
+			return nil, err
+		}
+		signature, err := e.footer.sign(footer)
+		if err != nil {
+			return nil, err
+		}
+		footer = append(footer, signature...)
+	} else {
+		footer, err = thrift.Marshal(new(thrift.CompactProtocol), &format.FileCryptoMetaData{
+			EncryptionAlgorithm: e.algorithm,
+			KeyMetadata:         e.config.FooterKeyMetadata,
+		})
+		if err != nil {
+			return nil, err
+		}
+		plaintext, err := thrift.Marshal(new(thrift.CompactProtocol), metadata)
+		if err != nil {
+			return nil, err
+		}
+		if footer, err = e.footer.encrypt(footer, plaintext, module{kind: footerModule}); err != nil {
+			return nil, err
+		}
+	}
+
+	length := len(footer)
+	footer = append(footer, 0, 0, 0, 0)
+	footer = append(footer, e.magic()...)
+	binary.LittleEndian.PutUint32(footer[length:], uint32(length))
+	return footer, nil
+}
+
+// columnEncryption holds the encryption state of a column chunk.
+type columnEncryption struct {
+	err           error
+	cipher        *moduleCipher
+	metadata      format.ColumnCryptoMetaData
+	withColumnKey bool
+	rowGroup      int
+	buffer        []byte
+}
+
+// encrypt replaces the content of b with its encrypted module.
+func (c *columnEncryption) encrypt(b *bytes.Buffer, m module) error {
+	if c.err != nil {
+		return c.err
+	}
+	encrypted, err := c.cipher.encrypt(c.buffer[:0], b.Bytes(), m)
+	if err != nil {
+		return err
+	}
+	c.buffer = encrypted
+	b.Reset()
+	b.Write(encrypted)
+	return nil
+}
+
+// fileDecryption holds the state used to read encrypted files.
+type fileDecryption struct {
+	footer  *moduleCipher
+	columns [][]columnDecryption // [row group][column]
+	// Set when the key of a signed plaintext footer could not be retrieved,
+	// which prevents reading the columns encrypted with the footer key.
+	footerErr error
+}
+
+// columnDecryption holds the decryption state of a column chunk; the cipher is
+// nil for plaintext columns, and err is set if the key of the column could not
+// be retrieved, in which case the column cannot be read.
+type columnDecryption struct {
+	cipher   *moduleCipher
+	err      error
+	rowGroup int
+}
+
+// decryptionAlgorithmOf returns the file AAD and algorithm of an encrypted
+// parquet file, applying the AAD prefix configured on the file when the
+// prefix is not stored in the file.
+func decryptionAlgorithmOf(algorithm *format.EncryptionAlgorithm, config *FileConfig) (aad []byte, alg EncryptionAlgorithm, err error) {
+	var aadPrefix, aadFileUnique []byte
+	var supplyAADPrefix bool
+
+	switch {
+	case algorithm.AesGcmV1 != nil:
+		alg = AESGCM
+		aadPrefix = algorithm.AesGcmV1.AadPrefix
+		aadFileUnique = algorithm.AesGcmV1.AadFileUnique
+		supplyAADPrefix = algorithm.AesGcmV1.SupplyAadPrefix
+	case algorithm.AesGcmCtrV1 != nil:
+		alg = AESGCMCTR
+		aadPrefix = algorithm.AesGcmCtrV1.AadPrefix
+		aadFileUnique = algorithm.AesGcmCtrV1.AadFileUnique
+		supplyAADPrefix = algorithm.AesGcmCtrV1.SupplyAadPrefix
+	default:
+		return nil, 0, fmt.Errorf("unsupported parquet encryption algorithm")
+	}
+
+	switch {
+	case len(config.AADPrefix) > 0:
+		if len(aadPrefix) > 0 && !bytes.Equal(aadPrefix, config.AADPrefix) {
+			return nil, 0, fmt.Errorf("AAD prefix of parquet file does not match the configured prefix: %w", ErrDecryption)
+		}
+		aadPrefix = config.AADPrefix
+	case supplyAADPrefix:
+		return nil, 0, fmt.Errorf("parquet file was encrypted with an AAD prefix which is not stored in the file: %w", ErrDecryption)
+	}
+
+	aad = make([]byte, 0, len(aadPrefix)+len(aadFileUnique))
+	aad = append(aad, aadPrefix...)
+	aad = append(aad, aadFileUnique...)
+	return aad, alg, nil
+}
+
+func isEncryptedFile(metadata *format.FileMetaData) bool {
+	return metadata.EncryptionAlgorithm.AesGcmV1 != nil || metadata.EncryptionAlgorithm.AesGcmCtrV1 != nil
+}
+
+// decryptColumns decrypts the column metadata of encrypted columns in the file
+// metadata, and prepares the ciphers used to read the column chunks.
+func (d *fileDecryption) decryptColumns(metadata *format.FileMetaData, keys KeyRetriever, aad []byte, algorithm EncryptionAlgorithm) error {
+	d.columns = make([][]columnDecryption, len(metadata.RowGroups))
+	ciphers := make(map[string]columnDecryption)
+
+	for i := range metadata.RowGroups {
+		rowGroup := &metadata.RowGroups[i]
+		d.columns[i] = make([]columnDecryption, len(rowGroup.Columns))
+
+		for j := range rowGroup.Columns {
+			chunk := &rowGroup.Columns[j]
+			column := &d.columns[i][j]
+			column.rowGroup = i
+
+			switch crypto := &chunk.CryptoMetadata; {
+			case crypto.EncryptionWithFooterKey != nil:
+				if d.footer == nil {
+					err := d.footerErr
+					if err == nil {
+						err = ErrMissingKeyRetriever
+					}
+					column.err = fmt.Errorf("reading encrypted parquet column %q: %w", columnPath(chunk.MetaData.PathInSchema), err)
+				}
+				column.cipher = d.footer
+			case crypto.EncryptionWithColumnKey != nil:
+				keyMetadata := crypto.EncryptionWithColumnKey.KeyMetadata
+				c, ok := ciphers[string(keyMetadata)]
+				if !ok {
+					c.cipher, c.err = retrieveKey(keys, keyMetadata, algorithm, aad)
+					ciphers[string(keyMetadata)] = c
+				}
+				column.cipher = c.cipher
+				if c.err != nil {
+					column.err = fmt.Errorf("retrieving decryption key of parquet column %q: %w", columnPath(crypto.EncryptionWithColumnKey.PathInSchema), c.err)
+				}
+			default:
+				continue
+			}
+
+			if column.err != nil {
+				column.cipher = nil
+				continue
+			}
+
+			if len(chunk.EncryptedColumnMetadata) > 0 {
+				b, err := column.cipher.decrypt(nil, chunk.EncryptedColumnMetadata, module{
+					kind:     columnMetaDataModule,
+					rowGroup: i,
+					column:   j,
+				})
+				if err != nil {
+					return fmt.Errorf("decrypting metadata of column %d in row group %d: %w", j, i, err)
+				}
+				chunk.MetaData = format.ColumnMetaData{}
+				if err := thrift.Unmarshal(new(thrift.CompactProtocol), b, &chunk.MetaData); err != nil {
+					return fmt.Errorf("decoding metadata of column %d in row group %d: %w", j, i, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (d *fileDecryption) column(rowGroup, column int) columnDecryption {
+	if d == nil {
+		return columnDecryption{}
+	}
+	return d.columns[rowGroup][column]
+}
+
+// readModule reads and decrypts the module at the given offset of r. If the
+// column is not encrypted, the function reads length bytes.
+func (c *columnDecryption) readModule(r io.ReaderAt, offset, length int64, m module) ([]byte, error) {
+	if c.cipher == nil {
+		b := make([]byte, length)
+		_, err := r.ReadAt(b, offset)
+		return b, err
+	}
+	b, err := readModule(io.NewSectionReader(r, offset, length), nil, length)
+	if err != nil {
+		return nil, err
+	}
+	return c.cipher.decrypt(nil, b, m)
+}
@@ -0,0 +1,431 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+type encryptedRow struct {
+	Country string   `parquet:"country,dict"`
+	Email   string   `parquet:"email,dict"`
+	ID      int64    `parquet:"id"`
+	Name    *string  `parquet:"name,optional"`
+	Tags    []string `parquet:"tags"`
+}
+
+var encryptionKeys = parquet.KeyMap{
+	"footer": []byte("0123456789abcdef"),
+	"email":  []byte("fedcba9876543210fedcba9876543210"),
+	"other":  []byte("0000000000000000"),
+}
+
+func makeEncryptedRows(n int) []encryptedRow {
+	countries := []string{"fr", "us", "jp"}
+	rows := make([]encryptedRow, n)
+	for i := range rows {
+		rows[i] = encryptedRow{
+			Email:   "user" + strings.Repeat("x", i%5) + "@secret.example.com",
+			ID:      int64(i),
+			Tags:    []string{"a", "b"}[:1+i%2],
+			Country: countries[i%len(countries)],
+		}
+		if i%2 == 0 {
+			name := "name-" + countries[i%len(countries)]
+			rows[i].Name = &name
+		}
+	}
+	return rows
+}
+
+// encryptedFileOptions returns the options of writers producing the encrypted
+// files of the tests, which have multiple row groups, pages and bloom filters.
+func encryptedFileOptions(config *parquet.EncryptionConfig) []parquet.WriterOption {
+	return []parquet.WriterOption{
+		parquet.SchemaOf(encryptedRow{}),
+		parquet.EncryptionKeys(encryptionKeys),
+		parquet.Encryption(config),
+		parquet.MaxRowsPerRowGroup(40),
+		parquet.PageBufferSize(256),
+		parquet.BloomFilters(parquet.SplitBlockFilter("email")),
+	}
+}
+
+func readEncryptedFile(t *testing.T, data []byte, options ...parquet.FileOption) []encryptedRow {
+	t.Helper()
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := parquet.NewReader(f)
+	rows := []encryptedRow{}
+	for {
+		row := encryptedRow{}
+		if err := reader.Read(&row); err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			return rows
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestEncryption(t *testing.T) {
+	tests := []struct {
+		scenario string
+		config   parquet.EncryptionConfig
+		magic    string
+	}{
+		{
+			scenario: "encrypted footer",
+			config:   parquet.EncryptionConfig{FooterKeyMetadata: []byte("footer")},
+			magic:    "PARE",
+		},
+		{
+			scenario: "encrypted footer with AES-GCM-CTR",
+			config:   parquet.EncryptionConfig{FooterKeyMetadata: []byte("footer"), Algorithm: parquet.AESGCMCTR},
+			magic:    "PARE",
+		},
+		{
+			scenario: "column keys",
+			config: parquet.EncryptionConfig{
+				FooterKeyMetadata: []byte("footer"),
+				ColumnKeyMetadata: map[string][]byte{
+					"email": []byte("email"),
+					"name":  []byte("footer"),
+				},
+			},
+			magic: "PARE",
+		},
+		{
+			scenario: "plaintext footer",
+			config: parquet.EncryptionConfig{
+				FooterKeyMetadata: []byte("footer"),
+				ColumnKeyMetadata: map[string][]byte{"email": []byte("email")},
+				PlaintextFooter:   true,
+			},
+			magic: "PAR1",
+		},
+		{
+			scenario: "stored AAD prefix",
+			config: parquet.EncryptionConfig{
+				FooterKeyMetadata: []byte("footer"),
+				AADPrefix:         []byte("table"),
+			},
+			magic: "PARE",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			rows := makeEncryptedRows(100)
+			buffer := new(bytes.Buffer)
+			if err := writeParquetFile(buffer, makeRows(rows), encryptedFileOptions(&test.config)...); err != nil {
+				t.Fatal(err)
+			}
+			data := buffer.Bytes()
+
+			if magic := string(data[:4]); magic != test.magic {
+				t.Errorf("wrong magic header: want=%q got=%q", test.magic, magic)
+			}
+			if bytes.Contains(data, []byte("@secret.example.com")) {
+				t.Error("encrypted column values found in plaintext in the file")
+			}
+
+			read := readEncryptedFile(t, data, parquet.EncryptionKeys(encryptionKeys))
+			if !reflect.DeepEqual(rows, read) {
+				t.Errorf("rows mismatch:\nwant: %+v\ngot:  %+v", rows[:3], read[:3])
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), parquet.EncryptionKeys(encryptionKeys))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := f.NumRowGroups(); n != 3 {
+				t.Fatalf("wrong number of row groups: want=3 got=%d", n)
+			}
+			emailColumn := f.Root().Column("email").Index()
+			for i := 0; i < f.NumRowGroups(); i++ {
+				chunk := f.RowGroup(i).Column(emailColumn)
+				if chunk.ColumnIndex() == nil || chunk.OffsetIndex() == nil {
+					t.Errorf("missing page index of email column in row group %d", i)
+				}
+				bloomFilter := chunk.BloomFilter()
+				if bloomFilter == nil {
+					t.Fatalf("missing bloom filter of email column in row group %d", i)
+				}
+				if ok, err := bloomFilter.Check(parquet.ValueOf(rows[i*40].Email)); err != nil {
+					t.Fatal(err)
+				} else if !ok {
+					t.Errorf("email of row %d not found in bloom filter", i*40)
+				}
+			}
+		})
+	}
+}
+
+func TestEncryptionColumnsNotSortedByName(t *testing.T) {
+	type Row struct {
+		Name  string `parquet:"name"`
+		Email string `parquet:"email"`
+		ID    int64  `parquet:"id"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{Name: fmt.Sprint("name-", i), Email: fmt.Sprint("user", i, "@secret.example.com"), ID: int64(i)}
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer,
+		parquet.SchemaOf(Row{}),
+		parquet.EncryptionKeys(encryptionKeys),
+		parquet.Encryption(&parquet.EncryptionConfig{
+			FooterKeyMetadata: []byte("footer"),
+			ColumnKeyMetadata: map[string][]byte{"email": []byte("email")},
+		}),
+		parquet.PageBufferSize(256),
+	)
+	for i := range rows {
+		if err := writer.Write(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()), parquet.EncryptionKeys(encryptionKeys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := parquet.NewReader(f)
+	for i := range rows {
+		row := make(map[string]interface{})
+		if err := reader.ReadMap(row); err != nil {
+			t.Fatalf("reading row %d: %v", i, err)
+		}
+		want := map[string]interface{}{"name": rows[i].Name, "email": rows[i].Email, "id": rows[i].ID}
+		if !reflect.DeepEqual(row, want) {
+			t.Fatalf("row %d mismatch: want=%v got=%v", i, want, row)
+		}
+	}
+}
+
+func TestEncryptionPlaintextFooterWithoutKeys(t *testing.T) {
+	rows := makeEncryptedRows(50)
+	buffer := new(bytes.Buffer)
+	if err := writeParquetFile(buffer, makeRows(rows), encryptedFileOptions(&parquet.EncryptionConfig{
+		FooterKeyMetadata: []byte("footer"),
+		ColumnKeyMetadata: map[string][]byte{"email": []byte("email")},
+		PlaintextFooter:   true,
+	})...); err != nil {
+		t.Fatal(err)
+	}
+	data := buffer.Bytes()
+
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rowGroup := f.RowGroup(0)
+	idColumn := f.Root().Column("id").Index()
+	emailColumn := f.Root().Column("email").Index()
+
+	values := make([]parquet.Value, 50)
+	pages := rowGroup.Column(idColumn).Pages()
+	page, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, _ := page.Values().ReadValues(values)
+	for i, v := range values[:n] {
+		if v.Int64() != rows[i].ID {
+			t.Fatalf("wrong value at index %d: want=%d got=%d", i, rows[i].ID, v.Int64())
+		}
+	}
+
+	if _, err := rowGroup.Column(emailColumn).Pages().ReadPage(); !errors.Is(err, parquet.ErrMissingKeyRetriever) {
+		t.Errorf("reading encrypted column without keys: want=%v got=%v", parquet.ErrMissingKeyRetriever, err)
+	}
+
+	keys := parquet.KeyMap{"footer": encryptionKeys["footer"]}
+	f, err = parquet.OpenFile(bytes.NewReader(data), int64(len(data)), parquet.EncryptionKeys(keys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.RowGroup(0).Column(emailColumn).Pages().ReadPage(); !errors.Is(err, parquet.ErrKeyNotFound) {
+		t.Errorf("reading encrypted column without column key: want=%v got=%v", parquet.ErrKeyNotFound, err)
+	}
+
+	// The footer key is not available, the file can still be opened to read
+	// the columns which do not need it.
+	keys = parquet.KeyMap{"email": encryptionKeys["email"]}
+	f, err = parquet.OpenFile(bytes.NewReader(data), int64(len(data)), parquet.EncryptionKeys(keys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages = f.RowGroup(0).Column(emailColumn).Pages()
+	if page, err = pages.ReadPage(); err != nil {
+		t.Fatal(err)
+	}
+	n, _ = page.Values().ReadValues(values)
+	for i, v := range values[:n] {
+		if v.String() != rows[i].Email {
+			t.Fatalf("wrong value at index %d: want=%q got=%q", i, rows[i].Email, v.String())
+		}
+	}
+}
+
+func TestEncryptionCorruptedModuleLength(t *testing.T) {
+	buffer := new(bytes.Buffer)
+	if err := writeParquetFile(buffer, makeRows(makeEncryptedRows(10)), encryptedFileOptions(&parquet.EncryptionConfig{
+		FooterKeyMetadata: []byte("footer"),
+		PlaintextFooter:   true,
+	})...); err != nil {
+		t.Fatal(err)
+	}
+	data := buffer.Bytes()
+
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)), parquet.EncryptionKeys(encryptionKeys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	idColumn := f.Root().Column("id").Index()
+	offset := f.Metadata().RowGroups[0].Columns[idColumn].MetaData.DataPageOffset
+
+	// The length prefix of the page header module claims that it spans more
+	// bytes than there are in the file.
+	corrupted := append([]byte{}, data...)
+	binary.LittleEndian.PutUint32(corrupted[offset:], 0x7fffffff)
+
+	f, err = parquet.OpenFile(bytes.NewReader(corrupted), int64(len(corrupted)), parquet.EncryptionKeys(encryptionKeys))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.RowGroup(0).Column(idColumn).Pages().ReadPage(); !errors.Is(err, parquet.ErrCorrupted) {
+		t.Errorf("reading page with corrupted module length: want=%v got=%v", parquet.ErrCorrupted, err)
+	}
+}
+
+func TestEncryptionErrors(t *testing.T) {
+	rows := makeEncryptedRows(10)
+	encryptedFooter := new(bytes.Buffer)
+	plaintextFooter := new(bytes.Buffer)
+	suppliedPrefix := new(bytes.Buffer)
+
+	for buffer, config := range map[*bytes.Buffer]*parquet.EncryptionConfig{
+		encryptedFooter: {
+			FooterKeyMetadata: []byte("footer"),
+		},
+		plaintextFooter: {
+			FooterKeyMetadata: []byte("footer"),
+			PlaintextFooter:   true,
+		},
+		suppliedPrefix: {
+			FooterKeyMetadata: []byte("footer"),
+			AADPrefix:         []byte("table"),
+			SupplyAADPrefix:   true,
+		},
+	} {
+		if err := writeParquetFile(buffer, makeRows(rows), encryptedFileOptions(config)...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	wrongKeys := parquet.KeyMap{"footer": encryptionKeys["other"]}
+
+	// Change the created_by field of the plaintext footer, which remains a
+	// valid file metadata but invalidates the signature.
+	tampered := append([]byte{}, plaintextFooter.Bytes()...)
+	tampered[bytes.LastIndex(tampered, []byte(parquet.DefaultCreatedBy))] = 'G'
+
+	tests := []struct {
+		scenario string
+		data     []byte
+		options  []parquet.FileOption
+		err      error
+	}{
+		{"missing keys", encryptedFooter.Bytes(), nil, parquet.ErrMissingKeyRetriever},
+		{"unknown key", encryptedFooter.Bytes(), []parquet.FileOption{parquet.EncryptionKeys(parquet.KeyMap{})}, parquet.ErrKeyNotFound},
+		{"wrong key", encryptedFooter.Bytes(), []parquet.FileOption{parquet.EncryptionKeys(wrongKeys)}, parquet.ErrDecryption},
+		{"wrong signing key", plaintextFooter.Bytes(), []parquet.FileOption{parquet.EncryptionKeys(wrongKeys)}, parquet.ErrDecryption},
+		{"tampered footer", tampered, []parquet.FileOption{parquet.EncryptionKeys(encryptionKeys)}, parquet.ErrDecryption},
+		{"missing AAD prefix", suppliedPrefix.Bytes(), []parquet.FileOption{parquet.EncryptionKeys(encryptionKeys)}, parquet.ErrDecryption},
+		{"wrong AAD prefix", suppliedPrefix.Bytes(), []parquet.FileOption{parquet.EncryptionKeys(encryptionKeys), parquet.DecryptionAADPrefix([]byte("other"))}, parquet.ErrDecryption},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := parquet.OpenFile(bytes.NewReader(test.data), int64(len(test.data)), test.options...)
+			if !errors.Is(err, test.err) {
+				t.Errorf("error mismatch: want=%v got=%v", test.err, err)
+			}
+		})
+	}
+
+	read := readEncryptedFile(t, suppliedPrefix.Bytes(), parquet.EncryptionKeys(encryptionKeys), parquet.DecryptionAADPrefix([]byte("table")))
+	if !reflect.DeepEqual(rows, read) {
+		t.Error("rows mismatch when reading file with supplied AAD prefix")
+	}
+}
+
+func TestEncryptionWriterErrors(t *testing.T) {
+	tests := []struct {
+		scenario string
+		config   parquet.EncryptionConfig
+		err      error
+	}{
+		{
+			scenario: "unknown footer key",
+			config:   parquet.EncryptionConfig{FooterKeyMetadata: []byte("missing")},
+			err:      parquet.ErrKeyNotFound,
+		},
+		{
+			scenario: "unknown column key",
+			config: parquet.EncryptionConfig{
+				FooterKeyMetadata: []byte("footer"),
+				ColumnKeyMetadata: map[string][]byte{"email": []byte("missing")},
+			},
+			err: parquet.ErrKeyNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			writer := parquet.NewWriter(io.Discard,
+				parquet.SchemaOf(encryptedRow{}),
+				parquet.EncryptionKeys(encryptionKeys),
+				parquet.Encryption(&test.config),
+			)
+			err := writer.Write(&encryptedRow{Email: "test@example.com"})
+			if err == nil {
+				err = writer.Close()
+			}
+			if !errors.Is(err, test.err) {
+				t.Errorf("error mismatch: want=%v got=%v", test.err, err)
+			}
+		})
+	}
+
+	writer := parquet.NewWriter(io.Discard,
+		parquet.SchemaOf(encryptedRow{}),
+		parquet.EncryptionKeys(encryptionKeys),
+		parquet.Encryption(&parquet.EncryptionConfig{
+			FooterKeyMetadata: []byte("footer"),
+			ColumnKeyMetadata: map[string][]byte{"phone": []byte("email")},
+		}),
+	)
+	if err := writer.Close(); err == nil {
+		t.Error("expected error when configuring the key of a column which does not exist")
+	}
+}
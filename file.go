@@ -33,6 +33,7 @@ type File struct {
 	columnIndexes []format.ColumnIndex
 	offsetIndexes []format.OffsetIndex
 	rowGroups     []fileRowGroup
+	decryption    *fileDecryption
 }
 
 // OpenFile opens a parquet file and reads the content between offset 0 and the given
@@ -41,6 +42,12 @@ type File struct {
 // Only the parquet magic bytes and footer are read, column chunks and other
 // parts of the file are left untouched; this means that successfully opening
 // a file does not validate that the pages have valid checksums.
+//
+// Encrypted files are decrypted with the keys obtained from the KeyRetriever
+// configured with the EncryptionKeys option. The keys of encrypted footers must
+// be available, while files with plaintext footers can be opened without keys,
+// in which case the signature of the footer is not verified; reading pages of
+// columns which cannot be decrypted returns an error.
 func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	b := make([]byte, 8)
 	f := &File{reader: r, size: size}
@@ -52,24 +59,37 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	if _, err := r.ReadAt(b[:4], 0); err != nil {
 		return nil, fmt.Errorf("reading magic header of parquet file: %w", err)
 	}
-	if string(b[:4]) != "PAR1" {
+	magic := string(b[:4])
+	if magic != "PAR1" && magic != "PARE" {
 		return nil, fmt.Errorf("invalid magic header of parquet file: %q", b[:4])
 	}
 
 	if _, err := r.ReadAt(b[:8], size-8); err != nil {
 		return nil, fmt.Errorf("reading magic footer of parquet file: %w", err)
 	}
-	if string(b[4:8]) != "PAR1" {
+	if string(b[4:8]) != magic {
 		return nil, fmt.Errorf("invalid magic footer of parquet file: %q", b[4:8])
 	}
 
 	footerSize := int64(binary.LittleEndian.Uint32(b[:4]))
-	section := acquireBufferedSectionReader(r, size-(footerSize+8), footerSize)
+	footerOffset := size - (footerSize + 8)
+	section := acquireBufferedSectionReader(r, footerOffset, footerSize)
 	decoder := thrift.NewDecoder(f.protocol.NewReader(section))
 	defer releaseBufferedSectionReader(section)
 
-	if err := decoder.Decode(&f.metadata); err != nil {
-		return nil, fmt.Errorf("reading parquet file metadata: %w", err)
+	if magic == "PARE" {
+		if err := f.decryptFooter(c, footerOffset, footerSize); err != nil {
+			return nil, fmt.Errorf("reading encrypted parquet file metadata: %w", err)
+		}
+	} else {
+		if err := decoder.Decode(&f.metadata); err != nil {
+			return nil, fmt.Errorf("reading parquet file metadata: %w", err)
+		}
+		if isEncryptedFile(&f.metadata) {
+			if err := f.verifyFooter(c, footerOffset, footerSize); err != nil {
+				return nil, fmt.Errorf("reading encrypted parquet file metadata: %w", err)
+			}
+		}
 	}
 	if len(f.metadata.Schema) == 0 {
 		return nil, ErrMissingRootColumn
@@ -81,17 +101,16 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 		}
 	}
 
-	if f.root, err = openColumns(f); err != nil {
+	var columns []*Column
+	if f.root, columns, err = openColumns(f); err != nil {
 		return nil, fmt.Errorf("opening columns of parquet file: %w", err)
 	}
 
 	schema := NewSchema(f.root.Name(), f.root)
-	columns := make([]*Column, 0, MaxColumnIndex+1)
-	f.root.forEachLeaf(func(c *Column) { columns = append(columns, c) })
 
 	f.rowGroups = make([]fileRowGroup, len(f.metadata.RowGroups))
 	for i := range f.rowGroups {
-		f.rowGroups[i].init(f, schema, columns, &f.metadata.RowGroups[i])
+		f.rowGroups[i].init(f, schema, columns, &f.metadata.RowGroups[i], i)
 	}
 
 	if !c.SkipBloomFilters {
@@ -106,6 +125,13 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 			for j := range g.columns {
 				c := &g.columns[j]
 
+				if c.decryption.cipher != nil || c.decryption.err != nil {
+					if c.bloomFilter, err = c.readEncryptedBloomFilter(i, c.ordinal); err != nil {
+						return nil, fmt.Errorf("reading bloom filter of column %d in row group %d: %w", j, i, err)
+					}
+					continue
+				}
+
 				if offset := c.chunk.MetaData.BloomFilterOffset; offset > 0 {
 					s.Seek(offset, io.SeekStart)
 					h = format.BloomFilterHeader{}
@@ -123,6 +149,77 @@ func OpenFile(r io.ReaderAt, size int64, options ...FileOption) (*File, error) {
 	return f, nil
 }
 
+// decryptFooter reads the footer of files with encrypted footers, which is made
+// of the plaintext crypto metadata followed by the encrypted file metadata.
+func (f *File) decryptFooter(config *FileConfig, offset, length int64) error {
+	footer := make([]byte, length)
+	if _, err := f.reader.ReadAt(footer, offset); err != nil {
+		return err
+	}
+
+	r := bytes.NewReader(footer)
+	fileCryptoMetaData := format.FileCryptoMetaData{}
+	if err := thrift.NewDecoder(f.protocol.NewReader(r)).Decode(&fileCryptoMetaData); err != nil {
+		return fmt.Errorf("decoding file crypto metadata: %w", err)
+	}
+
+	aad, algorithm, err := decryptionAlgorithmOf(&fileCryptoMetaData.EncryptionAlgorithm, config)
+	if err != nil {
+		return err
+	}
+	cipher, err := retrieveKey(config.KeyRetriever, fileCryptoMetaData.KeyMetadata, algorithm, aad)
+	if err != nil {
+		return fmt.Errorf("retrieving footer decryption key: %w", err)
+	}
+	metadata, err := cipher.decrypt(nil, footer[len(footer)-r.Len():], module{kind: footerModule})
+	if err != nil {
+		return fmt.Errorf("decrypting footer: %w", err)
+	}
+	if err := thrift.Unmarshal(&f.protocol, metadata, &f.metadata); err != nil {
+		return err
+	}
+
+	f.decryption = &fileDecryption{footer: cipher}
+	return f.decryption.decryptColumns(&f.metadata, config.KeyRetriever, aad, algorithm)
+}
+
+// verifyFooter verifies the signature of plaintext footers of encrypted files.
+//
+// When no key retriever is configured, or the footer key cannot be retrieved,
+// the signature is not verified and only the plaintext columns of the file,
+// and those encrypted with column keys that can be retrieved, can be read.
+func (f *File) verifyFooter(config *FileConfig, offset, length int64) error {
+	f.decryption = new(fileDecryption)
+	if config.KeyRetriever == nil {
+		return f.decryption.decryptColumns(&f.metadata, nil, nil, AESGCM)
+	}
+
+	aad, algorithm, err := decryptionAlgorithmOf(&f.metadata.EncryptionAlgorithm, config)
+	if err != nil {
+		return err
+	}
+	cipher, err := retrieveKey(config.KeyRetriever, f.metadata.FooterSigningKeyMetadata, algorithm, aad)
+	if err != nil {
+		f.decryption.footerErr = fmt.Errorf("retrieving footer signing key: %w", err)
+		return f.decryption.decryptColumns(&f.metadata, config.KeyRetriever, aad, algorithm)
+	}
+	if length < footerSignatureSize {
+		return fmt.Errorf("parquet footer is too short to be signed: %d bytes: %w", length, ErrCorrupted)
+	}
+
+	footer := make([]byte, length)
+	if _, err := f.reader.ReadAt(footer, offset); err != nil {
+		return err
+	}
+	n := len(footer) - footerSignatureSize
+	if err := cipher.verify(footer[:n], footer[n:]); err != nil {
+		return err
+	}
+
+	f.decryption.footer = cipher
+	return f.decryption.decryptColumns(&f.metadata, config.KeyRetriever, aad, algorithm)
+}
+
 // ReadPageIndex reads the page index section of the parquet file f.
 //
 // If the file did not contain a page index, the method returns two empty slices
@@ -179,6 +276,10 @@ func (f *File) readPageIndex(section *bufferedSectionReader, decoder *thrift.Dec
 	numColumnChunks := len(f.metadata.RowGroups) * len(f.metadata.RowGroups[0].Columns)
 	columnIndexes := make([]format.ColumnIndex, 0, numColumnChunks)
 	offsetIndexes := make([]format.OffsetIndex, 0, numColumnChunks)
+
+	if f.decryption != nil {
+		return f.readEncryptedPageIndex(columnIndexes, offsetIndexes)
+	}
 	section.Reset(f.reader, indexOffset, indexLength)
 
 	for i := range f.metadata.RowGroups {
@@ -206,6 +307,51 @@ func (f *File) readPageIndex(section *bufferedSectionReader, decoder *thrift.Dec
 	return columnIndexes, offsetIndexes, nil
 }
 
+// readEncryptedPageIndex reads the page index of encrypted files, where each
+// index of encrypted columns is a separate module. The indexes of columns which
+// cannot be decrypted are left empty.
+func (f *File) readEncryptedPageIndex(columnIndexes []format.ColumnIndex, offsetIndexes []format.OffsetIndex) ([]format.ColumnIndex, []format.OffsetIndex, error) {
+	for i := range f.metadata.RowGroups {
+		for j := range f.metadata.RowGroups[i].Columns {
+			chunk := &f.metadata.RowGroups[i].Columns[j]
+			decryption := f.decryption.column(i, j)
+			n := len(columnIndexes)
+			columnIndexes = append(columnIndexes, format.ColumnIndex{})
+
+			if decryption.err == nil {
+				b, err := decryption.readModule(f.reader, chunk.ColumnIndexOffset, int64(chunk.ColumnIndexLength), module{kind: columnIndexModule, rowGroup: i, column: j})
+				if err == nil {
+					err = thrift.Unmarshal(&f.protocol, b, &columnIndexes[n])
+				}
+				if err != nil {
+					return nil, nil, fmt.Errorf("reading column index %d of row group %d: %w", j, i, err)
+				}
+			}
+		}
+	}
+
+	for i := range f.metadata.RowGroups {
+		for j := range f.metadata.RowGroups[i].Columns {
+			chunk := &f.metadata.RowGroups[i].Columns[j]
+			decryption := f.decryption.column(i, j)
+			n := len(offsetIndexes)
+			offsetIndexes = append(offsetIndexes, format.OffsetIndex{})
+
+			if decryption.err == nil {
+				b, err := decryption.readModule(f.reader, chunk.OffsetIndexOffset, int64(chunk.OffsetIndexLength), module{kind: offsetIndexModule, rowGroup: i, column: j})
+				if err == nil {
+					err = thrift.Unmarshal(&f.protocol, b, &offsetIndexes[n])
+				}
+				if err != nil {
+					return nil, nil, fmt.Errorf("reading offset index %d of row group %d: %w", j, i, err)
+				}
+			}
+		}
+	}
+
+	return columnIndexes, offsetIndexes, nil
+}
+
 // NumRowGroups returns the number of row groups in f.
 func (f *File) NumRowGroups() int { return len(f.rowGroups) }
 
@@ -218,6 +364,11 @@ func (f *File) Root() *Column { return f.root }
 // Size returns the size of f (in bytes).
 func (f *File) Size() int64 { return f.size }
 
+// Metadata returns the metadata of f, decoded from the footer of the file.
+//
+// The returned value is shared with f, programs must treat it as read-only.
+func (f *File) Metadata() *format.FileMetaData { return &f.metadata }
+
 // ReadAt reads bytes into b from f at the given offset.
 //
 // The method satisfies the io.ReaderAt interface.
@@ -328,27 +479,35 @@ type fileRowGroup struct {
 	sorting  []SortingColumn
 }
 
-func (g *fileRowGroup) init(file *File, schema *Schema, columns []*Column, rowGroup *format.RowGroup) {
+func (g *fileRowGroup) init(file *File, schema *Schema, columns []*Column, rowGroup *format.RowGroup, ordinal int) {
 	g.schema = schema
 	g.rowGroup = rowGroup
 	g.columns = make([]fileColumnChunk, len(rowGroup.Columns))
 	g.sorting = make([]SortingColumn, len(rowGroup.SortingColumns))
 
-	for i := range g.columns {
+	// The columns are listed in the order of column chunks in the row group,
+	// chunks are placed at the index of their leaf column so rows can be read
+	// in the order of the schema.
+	for i, column := range columns {
 		c := fileColumnChunk{
 			file:     file,
-			column:   columns[i],
+			column:   column,
 			rowGroup: rowGroup,
 			chunk:    &rowGroup.Columns[i],
+			ordinal:  i,
+		}
+
+		if file.decryption != nil {
+			c.decryption = file.decryption.column(ordinal, i)
 		}
 
-		if file.hasIndexes() {
+		if file.hasIndexes() && c.decryption.err == nil {
 			j := (int(rowGroup.Ordinal) * len(columns)) + i
 			c.columnIndex = &file.columnIndexes[j]
 			c.offsetIndex = &file.offsetIndexes[j]
 		}
 
-		g.columns[i] = c
+		g.columns[column.Index()] = c
 	}
 
 	for i := range g.sorting {
@@ -385,6 +544,8 @@ type fileColumnChunk struct {
 	columnIndex *format.ColumnIndex
 	offsetIndex *format.OffsetIndex
 	chunk       *format.ColumnChunk
+	ordinal     int // position of the chunk in the row group
+	decryption  columnDecryption
 }
 
 func (c *fileColumnChunk) Type() Type {
@@ -403,6 +564,10 @@ func (c *fileColumnChunk) Pages() Pages {
 
 func (c *fileColumnChunk) setPagesOn(r *filePages) {
 	r.column = c
+	r.err = c.decryption.err
+	if r.err != nil {
+		return
+	}
 	r.page = filePage{
 		column:     c.column,
 		columnType: c.column.Type(),
@@ -420,6 +585,54 @@ func (c *fileColumnChunk) setPagesOn(r *filePages) {
 	r.decoder.Reset(r.protocol.NewReader(r.rbuf))
 }
 
+// bloomFilterSection returns a reader exposing the header and bitset of the
+// bloom filter of the column chunk, as they are stored in the file.
+func (c *fileColumnChunk) bloomFilterSection() (*io.SectionReader, error) {
+	offset := c.chunk.MetaData.BloomFilterOffset
+	s := io.NewSectionReader(c.file.reader, offset, c.file.size-offset)
+	h := format.BloomFilterHeader{}
+	p := thrift.CompactProtocol{}
+	if err := thrift.NewDecoder(p.NewReader(s)).Decode(&h); err != nil {
+		return nil, err
+	}
+	headerSize, _ := s.Seek(0, io.SeekCurrent)
+	return io.NewSectionReader(c.file.reader, offset, headerSize+int64(h.NumBytes)), nil
+}
+
+// readEncryptedBloomFilter reads the bloom filter of an encrypted column, the
+// header and bitset are separate modules. Bloom filters of columns which cannot
+// be decrypted are ignored.
+func (c *fileColumnChunk) readEncryptedBloomFilter(rowGroup, column int) (*bloomFilter, error) {
+	offset := c.chunk.MetaData.BloomFilterOffset
+	if offset <= 0 || c.decryption.err != nil {
+		return nil, nil
+	}
+
+	s := io.NewSectionReader(c.file.reader, offset, c.file.size-offset)
+	b, err := readModule(s, nil, s.Size())
+	if err != nil {
+		return nil, err
+	}
+	remaining := s.Size() - int64(len(b))
+	b, err = c.decryption.cipher.decrypt(nil, b, module{kind: bloomFilterHeaderModule, rowGroup: rowGroup, column: column})
+	if err != nil {
+		return nil, err
+	}
+	h := format.BloomFilterHeader{}
+	if err := thrift.Unmarshal(&c.file.protocol, b, &h); err != nil {
+		return nil, err
+	}
+
+	if b, err = readModule(s, b[:0], remaining); err != nil {
+		return nil, err
+	}
+	b, err = c.decryption.cipher.decrypt(nil, b, module{kind: bloomFilterBitsetModule, rowGroup: rowGroup, column: column})
+	if err != nil {
+		return nil, err
+	}
+	return newBloomFilter(bytes.NewReader(b), 0, &h), nil
+}
+
 func (c *fileColumnChunk) ColumnIndex() ColumnIndex {
 	if c.columnIndex == nil {
 		return nil
@@ -455,17 +668,22 @@ type filePages struct {
 
 	section *io.SectionReader
 	rbuf    *bufio.Reader
+	err     error
 
 	// This buffer holds compressed pages in memory when they are read; we need
 	// to read whole pages because we have to compute the checksum prior to
 	// exposing the page to the application.
 	compressedPageData []byte
+	// Those buffers hold the encrypted modules and decrypted page data when
+	// reading encrypted columns.
+	encryptedModule   []byte
+	decryptedPageData []byte
 
 	page filePage
 	skip int64
 }
 
-func (r *filePages) readPage() (*filePage, error) {
+func (r *filePages) readPage(dictionary bool) (*filePage, error) {
 	h := &r.page.header
 	h.Type = 0
 	h.UncompressedPageSize = 0
@@ -485,7 +703,7 @@ func (r *filePages) readPage() (*filePage, error) {
 		*h.DataPageHeaderV2 = format.DataPageHeaderV2{}
 	}
 
-	if err := r.decoder.Decode(h); err != nil {
+	if err := r.decodePageHeader(h, dictionary); err != nil {
 		if err != io.EOF {
 			err = fmt.Errorf("decoding page header: %w", err)
 		}
@@ -531,7 +749,22 @@ func (r *filePages) readPage() (*filePage, error) {
 		}
 	}
 
-	r.page.data.Reset(r.compressedPageData)
+	pageData := r.compressedPageData
+	if cipher := r.column.decryption.cipher; cipher != nil {
+		m := r.pageModule(dataPageModule, dictionary)
+		pageData, err = cipher.decrypt(r.decryptedPageData[:0], pageData, m)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting page %d of column %q: %w", r.page.index, r.page.columnPath(), err)
+		}
+		r.decryptedPageData = pageData
+		// Expose the page as if it had not been encrypted, so it can be copied
+		// as-is to other files.
+		r.page.header.CompressedPageSize = int32(len(pageData))
+		if r.page.header.CRC != 0 {
+			r.page.header.CRC = int32(crc32.ChecksumIEEE(pageData))
+		}
+	}
+	r.page.data.Reset(pageData)
 
 	if r.column.columnIndex != nil {
 		err = r.page.parseColumnIndex(r.column.columnIndex)
@@ -541,6 +774,38 @@ func (r *filePages) readPage() (*filePage, error) {
 	return &r.page, err
 }
 
+func (r *filePages) decodePageHeader(h *format.PageHeader, dictionary bool) error {
+	cipher := r.column.decryption.cipher
+	if cipher == nil {
+		return r.decoder.Decode(h)
+	}
+	b, err := readModule(r.rbuf, r.encryptedModule, r.section.Size())
+	r.encryptedModule = b
+	if err != nil {
+		return err
+	}
+	b, err = cipher.decrypt(r.decryptedPageData[:0], b, r.pageModule(dataPageHeaderModule, dictionary))
+	r.decryptedPageData = b
+	if err != nil {
+		return err
+	}
+	return thrift.Unmarshal(&r.protocol, b, h)
+}
+
+// pageModule returns the module of data pages or their headers, the dictionary
+// variant is selected when reading the dictionary page.
+func (r *filePages) pageModule(kind byte, dictionary bool) module {
+	if dictionary {
+		kind++ // dictionaryPageModule, dictionaryPageHeaderModule
+	}
+	return module{
+		kind:     kind,
+		rowGroup: r.column.decryption.rowGroup,
+		column:   r.column.ordinal,
+		page:     r.page.index,
+	}
+}
+
 func (r *filePages) readDictionary() error {
 	currentOffset, _ := r.section.Seek(0, io.SeekCurrent)
 	defer func() {
@@ -553,7 +818,7 @@ func (r *filePages) readDictionary() error {
 	}
 	r.rbuf.Reset(r.section)
 
-	p, err := r.readPage()
+	p, err := r.readPage(true)
 	if err != nil {
 		return err
 	}
@@ -576,13 +841,16 @@ func (r *filePages) readDictionary() error {
 }
 
 func (r *filePages) ReadPage() (Page, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
 	if r.page.dictionary == nil && r.dictOffset > 0 {
 		if err := r.readDictionary(); err != nil {
 			return nil, err
 		}
 	}
 	for {
-		p, err := r.readPage()
+		p, err := r.readPage(false)
 		if err != nil {
 			return nil, err
 		}
@@ -604,6 +872,9 @@ func (r *filePages) ReadPage() (Page, error) {
 }
 
 func (r *filePages) SeekToRow(rowIndex int64) (err error) {
+	if r.err != nil {
+		return r.err
+	}
 	if r.column.offsetIndex == nil {
 		_, err = r.section.Seek(r.dataOffset-r.baseOffset, io.SeekStart)
 		r.skip = rowIndex
@@ -812,6 +1083,15 @@ func (p *filePage) Values() ValueReader {
 
 func (p *filePage) Buffer() BufferedPage {
 	bufferedPage := p.column.Type().NewColumnBuffer(p.Column(), int(p.Size()))
+	// The column buffer must carry the repetition and definition levels of the
+	// column, otherwise slicing the buffered page would not account for null
+	// values and repeated rows, for example when seeking to a row in the page.
+	switch maxRepetitionLevel, maxDefinitionLevel := p.column.maxRepetitionLevel, p.column.maxDefinitionLevel; {
+	case maxRepetitionLevel > 0:
+		bufferedPage = newRepeatedColumnBuffer(bufferedPage, maxRepetitionLevel, maxDefinitionLevel, nullsGoLast)
+	case maxDefinitionLevel > 0:
+		bufferedPage = newOptionalColumnBuffer(bufferedPage, maxDefinitionLevel, nullsGoLast)
+	}
 	_, err := CopyValues(bufferedPage, p.Values())
 	if err != nil {
 		return &errorPage{err: err, columnIndex: p.Column()}
@@ -1,6 +1,7 @@
 package parquet_test
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -67,6 +68,54 @@ func printColumns(t *testing.T, col *parquet.Column, indent string) {
 	}
 }
 
+func TestFilePageBuffer(t *testing.T) {
+	type Row struct {
+		Value *int64 `parquet:"value,optional"`
+	}
+
+	i64 := func(v int64) *int64 { return &v }
+
+	f, err := createParquetFile(makeRows([]Row{
+		{Value: i64(1)},
+		{},
+		{Value: i64(3)},
+		{},
+		{Value: i64(5)},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages := f.RowGroup(0).Column(0).Pages()
+	page, err := pages.ReadPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := page.Buffer()
+	if n := buffer.NumNulls(); n != 2 {
+		t.Errorf("wrong number of nulls in the buffered page: want=2 got=%d", n)
+	}
+	if n := len(buffer.DefinitionLevels()); n != 5 {
+		t.Errorf("wrong number of definition levels in the buffered page: want=5 got=%d", n)
+	}
+
+	values := make([]parquet.Value, 2)
+	n, err := buffer.Slice(1, 3).Values().ReadValues(values)
+	if err != nil && err != io.EOF {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("wrong number of values in the page slice: want=2 got=%d", n)
+	}
+	if !values[0].IsNull() {
+		t.Errorf("first value of the page slice is not null: %v", values[0])
+	}
+	if values[1].IsNull() || values[1].Int64() != 3 {
+		t.Errorf("second value of the page slice is not 3: %v", values[1])
+	}
+}
+
 func TestFileKeyValueMetadata(t *testing.T) {
 	type Row struct {
 		Name string
@@ -92,3 +141,41 @@ func TestFileKeyValueMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestFileColumnsNotSortedByName(t *testing.T) {
+	type Row struct {
+		Score int64   `parquet:"score"`
+		Name  string  `parquet:"name"`
+		Tags  []int32 `parquet:"tags"`
+		Age   int32   `parquet:"age,optional"`
+	}
+
+	f, err := createParquetFile(makeRows([]Row{
+		{Score: 1, Name: "A", Tags: []int32{1, 2}, Age: 10},
+		{Score: 2, Name: "B"},
+		{Score: 3, Name: "C", Tags: []int32{3}, Age: 30},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`map[age:10 name:A score:1 tags:[1 2]]`,
+		`map[age:<nil> name:B score:2 tags:[]]`,
+		`map[age:30 name:C score:3 tags:[3]]`,
+	}
+
+	reader := parquet.NewReader(f)
+	for i := range want {
+		row := make(map[string]interface{})
+		if err := reader.ReadMap(row); err != nil {
+			t.Fatalf("reading row %d: %v", i, err)
+		}
+		if got := fmt.Sprint(row); got != want[i] {
+			t.Errorf("row %d mismatch:\nwant: %s\ngot:  %s", i, want[i], got)
+		}
+	}
+	if err := reader.ReadMap(make(map[string]interface{})); err != io.EOF {
+		t.Errorf("expected io.EOF after the last row but got %v", err)
+	}
+}
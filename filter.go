@@ -0,0 +1,684 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Filter is an interface representing predicates applied to select rows read
+// from parquet row groups.
+//
+// Filters are constructed by calling functions like Eq, Lt, And, Or, etc...
+// Columns are referenced by their path in the schema, using dots to separate
+// the names of nested fields, for example:
+//
+//	filter := parquet.And(
+//		parquet.Eq("user_id", parquet.ValueOf(userID)),
+//		parquet.Gt("event.time", parquet.ValueOf(startTime)),
+//	)
+//
+// When the column index and bloom filters are available, they are used to skip
+// row groups and pages that cannot contain rows matching the filter. The filter
+// is then evaluated on each remaining row. For repeated columns, a comparison
+// matches a row if any of the values of the column match.
+//
+// Comparison values are converted to the type of their column. Filters cannot
+// be applied when the conversion would lose information, for example when an
+// INT64 value is out of the range of an INT32 column.
+type Filter interface {
+	// Returns a human-readable representation of the filter.
+	String() string
+
+	// Binds the filter to the given schema, resolving column paths to column
+	// indexes and converting comparison values to the column types.
+	bind(schema *Schema) (boundFilter, error)
+}
+
+// Eq constructs a filter matching rows where the column at path is equal to
+// value.
+//
+// When the column has a bloom filter, it is used to skip column chunks which
+// do not contain the value.
+func Eq(path string, value Value) Filter { return compareFilterOf(filterEq, path, value) }
+
+// NotEq constructs a filter matching rows where the column at path is not null
+// and not equal to value.
+func NotEq(path string, value Value) Filter { return compareFilterOf(filterNotEq, path, value) }
+
+// Lt constructs a filter matching rows where the column at path is less than
+// value.
+func Lt(path string, value Value) Filter { return compareFilterOf(filterLt, path, value) }
+
+// LtEq constructs a filter matching rows where the column at path is less than
+// or equal to value.
+func LtEq(path string, value Value) Filter { return compareFilterOf(filterLtEq, path, value) }
+
+// Gt constructs a filter matching rows where the column at path is greater
+// than value.
+func Gt(path string, value Value) Filter { return compareFilterOf(filterGt, path, value) }
+
+// GtEq constructs a filter matching rows where the column at path is greater
+// than or equal to value.
+func GtEq(path string, value Value) Filter { return compareFilterOf(filterGtEq, path, value) }
+
+// IsNull constructs a filter matching rows where the column at path is null.
+func IsNull(path string) Filter { return compareFilterOf(filterIsNull, path, Value{}) }
+
+// IsNotNull constructs a filter matching rows where the column at path is not
+// null.
+func IsNotNull(path string) Filter { return compareFilterOf(filterIsNotNull, path, Value{}) }
+
+// And constructs a filter matching rows which match all the filters passed as
+// arguments.
+func And(filters ...Filter) Filter {
+	return &logicalFilter{op: filterAnd, filters: append([]Filter{}, filters...)}
+}
+
+// Or constructs a filter matching rows which match at least one of the filters
+// passed as arguments.
+func Or(filters ...Filter) Filter {
+	return &logicalFilter{op: filterOr, filters: append([]Filter{}, filters...)}
+}
+
+// Not constructs a filter matching rows which do not match the filter passed
+// as argument.
+//
+// Negated filters cannot be used to skip pages, they are only evaluated on
+// each row.
+func Not(filter Filter) Filter { return &notFilter{filter: filter} }
+
+// FilterRowGroup returns a view of rowGroup where the rows returned by calling
+// Rows are those matching the filter.
+//
+// The NumRows method of the returned row group reports the number of rows in
+// the original row group, which is an upper bound of the number of rows that
+// match the filter.
+//
+// The function returns an error if the filter cannot be applied to the schema
+// of the row group.
+func FilterRowGroup(rowGroup RowGroup, filter Filter) (RowGroup, error) {
+	f, err := filter.bind(rowGroup.Schema())
+	if err != nil {
+		return nil, err
+	}
+	return &filteredRowGroup{rowGroup: rowGroup, filter: f}, nil
+}
+
+type filterOp int
+
+const (
+	filterEq filterOp = iota
+	filterNotEq
+	filterLt
+	filterLtEq
+	filterGt
+	filterGtEq
+	filterIsNull
+	filterIsNotNull
+	filterAnd
+	filterOr
+)
+
+func (op filterOp) String() string {
+	switch op {
+	case filterEq:
+		return "="
+	case filterNotEq:
+		return "!="
+	case filterLt:
+		return "<"
+	case filterLtEq:
+		return "<="
+	case filterGt:
+		return ">"
+	case filterGtEq:
+		return ">="
+	case filterIsNull:
+		return "IS NULL"
+	case filterIsNotNull:
+		return "IS NOT NULL"
+	case filterAnd:
+		return "AND"
+	case filterOr:
+		return "OR"
+	default:
+		return "?"
+	}
+}
+
+type compareFilter struct {
+	op    filterOp
+	path  columnPath
+	value Value
+}
+
+func compareFilterOf(op filterOp, path string, value Value) *compareFilter {
+	return &compareFilter{
+		op:    op,
+		path:  columnPath(strings.Split(path, ".")),
+		value: value.Clone(),
+	}
+}
+
+func (f *compareFilter) String() string {
+	switch f.op {
+	case filterIsNull, filterIsNotNull:
+		return fmt.Sprintf("%s %s", f.path, f.op)
+	default:
+		return fmt.Sprintf("%s %s %v", f.path, f.op, f.value)
+	}
+}
+
+func (f *compareFilter) bind(schema *Schema) (boundFilter, error) {
+	var leaf leafColumn
+	var found bool
+
+	forEachLeafColumnOf(schema, func(c leafColumn) {
+		if !found && c.path.equal(f.path) {
+			leaf, found = c, true
+		}
+	})
+
+	if !found {
+		return nil, fmt.Errorf("cannot apply filter %s: column %q does not exist in schema %s", f, f.path, schema.Name())
+	}
+
+	b := &boundCompareFilter{
+		op:     f.op,
+		column: int(leaf.columnIndex),
+		typ:    leaf.node.Type(),
+	}
+
+	switch f.op {
+	case filterIsNull, filterIsNotNull:
+	default:
+		if f.value.IsNull() {
+			return nil, fmt.Errorf("cannot apply filter %s: comparison value is null", f)
+		}
+		v, err := convertFilterValue(f.value, b.typ.Kind())
+		if err != nil {
+			return nil, fmt.Errorf("cannot apply filter %s: %w", f, err)
+		}
+		b.value = v
+	}
+
+	return b, nil
+}
+
+func convertFilterValue(v Value, kind Kind) (Value, error) {
+	switch {
+	case v.Kind() == kind:
+		return v, nil
+	case kind == Int32 && v.Kind() == Int64:
+		// Values out of the range of the column would be truncated and compare
+		// with unrelated values of the column.
+		i := v.Int64()
+		if i < math.MinInt32 || i > math.MaxInt32 {
+			return v, fmt.Errorf("cannot compare %s value %d with %s column: value out of range", v.Kind(), i, kind)
+		}
+		return makeValueInt32(int32(i)), nil
+	case kind == Int64 && v.Kind() == Int32:
+		return makeValueInt64(int64(v.Int32())), nil
+	case kind == Float && v.Kind() == Double:
+		d := v.Double()
+		if f := float32(d); float64(f) != d && !math.IsNaN(d) {
+			return v, fmt.Errorf("cannot compare %s value %g with %s column: value cannot be represented exactly", v.Kind(), d, kind)
+		}
+		return makeValueFloat(float32(d)), nil
+	case kind == Double && v.Kind() == Float:
+		return makeValueDouble(float64(v.Float())), nil
+	case kind == ByteArray && v.Kind() == FixedLenByteArray,
+		kind == FixedLenByteArray && v.Kind() == ByteArray:
+		return makeValueBytes(kind, v.ByteArray()), nil
+	default:
+		return v, fmt.Errorf("cannot compare %s value with %s column", v.Kind(), kind)
+	}
+}
+
+type logicalFilter struct {
+	op      filterOp
+	filters []Filter
+}
+
+func (f *logicalFilter) String() string {
+	s := new(strings.Builder)
+	s.WriteString("(")
+	for i, filter := range f.filters {
+		if i != 0 {
+			s.WriteString(" ")
+			s.WriteString(f.op.String())
+			s.WriteString(" ")
+		}
+		s.WriteString(filter.String())
+	}
+	s.WriteString(")")
+	return s.String()
+}
+
+func (f *logicalFilter) bind(schema *Schema) (boundFilter, error) {
+	b := &boundLogicalFilter{
+		op:      f.op,
+		filters: make([]boundFilter, len(f.filters)),
+	}
+	for i, filter := range f.filters {
+		x, err := filter.bind(schema)
+		if err != nil {
+			return nil, err
+		}
+		b.filters[i] = x
+	}
+	return b, nil
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f *notFilter) String() string { return "NOT " + f.filter.String() }
+
+func (f *notFilter) bind(schema *Schema) (boundFilter, error) {
+	b, err := f.filter.bind(schema)
+	if err != nil {
+		return nil, err
+	}
+	return &boundNotFilter{filter: b}, nil
+}
+
+// boundFilter is the representation of filters after resolving the columns
+// that they apply to in a schema.
+type boundFilter interface {
+	// Calls fn for each column index referenced by the filter.
+	columns(fn func(int))
+
+	// Returns the ranges of rows of the row group which may match the filter,
+	// based on the column indexes and bloom filters.
+	rowRanges(rowGroup RowGroup) (rowRanges, error)
+
+	// Evaluates the filter on a row.
+	match(row Row) bool
+}
+
+type boundCompareFilter struct {
+	op     filterOp
+	column int
+	typ    Type
+	value  Value
+}
+
+func (f *boundCompareFilter) columns(fn func(int)) { fn(f.column) }
+
+func (f *boundCompareFilter) rowRanges(rowGroup RowGroup) (rowRanges, error) {
+	return f.columnChunkRowRanges(rowGroup.Column(f.column), rowGroup.NumRows())
+}
+
+func (f *boundCompareFilter) columnChunkRowRanges(chunk ColumnChunk, numRows int64) (rowRanges, error) {
+	if c, ok := chunk.(*concatenatedColumnChunk); ok {
+		// Concatenated column chunks do not expose page indexes, but each of
+		// the underlying chunks may, so we compute the row ranges of each
+		// chunk and shift them by the row offset of the chunk.
+		ranges := rowRanges{}
+		offset := int64(0)
+
+		for i, chunk := range c.chunks {
+			n := c.rowGroup.rowGroups[i].NumRows()
+			r, err := f.columnChunkRowRanges(chunk, n)
+			if err != nil {
+				return nil, err
+			}
+			for _, x := range r {
+				ranges = ranges.append(x.start+offset, x.end+offset)
+			}
+			offset += n
+		}
+
+		return ranges, nil
+	}
+
+	if numRows == 0 {
+		return nil, nil
+	}
+
+	if f.op == filterEq {
+		if bloomFilter := chunk.BloomFilter(); bloomFilter != nil {
+			ok, err := bloomFilter.Check(f.value)
+			if err != nil {
+				return nil, fmt.Errorf("checking bloom filter of column %d: %w", f.column, err)
+			}
+			if !ok {
+				return nil, nil
+			}
+		}
+	}
+
+	columnIndex, offsetIndex := chunk.ColumnIndex(), chunk.OffsetIndex()
+	if columnIndex == nil || offsetIndex == nil || columnIndex.NumPages() != offsetIndex.NumPages() {
+		return rowRanges{{start: 0, end: numRows}}, nil
+	}
+
+	ranges := rowRanges{}
+	numPages := columnIndex.NumPages()
+
+	for i := 0; i < numPages; i++ {
+		if !f.matchPage(columnIndex, i) {
+			continue
+		}
+		start, end := offsetIndex.FirstRowIndex(i), numRows
+		if i+1 < numPages {
+			end = offsetIndex.FirstRowIndex(i + 1)
+		}
+		ranges = ranges.append(start, end)
+	}
+
+	return ranges, nil
+}
+
+// matchPage returns true if the page at index i of the column index may contain
+// values matching the filter.
+func (f *boundCompareFilter) matchPage(columnIndex ColumnIndex, i int) bool {
+	switch f.op {
+	case filterIsNull:
+		return columnIndex.NullPage(i) || columnIndex.NullCount(i) > 0
+	case filterIsNotNull:
+		return !columnIndex.NullPage(i)
+	}
+
+	if columnIndex.NullPage(i) {
+		return false
+	}
+
+	min, max := columnIndex.MinValue(i), columnIndex.MaxValue(i)
+
+	switch f.op {
+	case filterEq:
+		return f.typ.Compare(min, f.value) <= 0 && f.typ.Compare(max, f.value) >= 0
+	case filterNotEq:
+		return f.typ.Compare(min, f.value) != 0 || f.typ.Compare(max, f.value) != 0
+	case filterLt:
+		return f.typ.Compare(min, f.value) < 0
+	case filterLtEq:
+		return f.typ.Compare(min, f.value) <= 0
+	case filterGt:
+		return f.typ.Compare(max, f.value) > 0
+	case filterGtEq:
+		return f.typ.Compare(max, f.value) >= 0
+	default:
+		return true
+	}
+}
+
+func (f *boundCompareFilter) match(row Row) bool {
+	for _, v := range row {
+		if v.Column() == f.column && f.matchValue(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *boundCompareFilter) matchValue(v Value) bool {
+	switch f.op {
+	case filterIsNull:
+		return v.IsNull()
+	case filterIsNotNull:
+		return !v.IsNull()
+	}
+
+	if v.IsNull() {
+		return false
+	}
+
+	switch c := f.typ.Compare(v, f.value); f.op {
+	case filterEq:
+		return c == 0
+	case filterNotEq:
+		return c != 0
+	case filterLt:
+		return c < 0
+	case filterLtEq:
+		return c <= 0
+	case filterGt:
+		return c > 0
+	case filterGtEq:
+		return c >= 0
+	default:
+		return false
+	}
+}
+
+type boundLogicalFilter struct {
+	op      filterOp
+	filters []boundFilter
+}
+
+func (f *boundLogicalFilter) columns(fn func(int)) {
+	for _, filter := range f.filters {
+		filter.columns(fn)
+	}
+}
+
+func (f *boundLogicalFilter) rowRanges(rowGroup RowGroup) (rowRanges, error) {
+	var ranges rowRanges
+
+	if f.op == filterAnd {
+		ranges = rowRanges{{start: 0, end: rowGroup.NumRows()}}
+	}
+
+	for _, filter := range f.filters {
+		r, err := filter.rowRanges(rowGroup)
+		if err != nil {
+			return nil, err
+		}
+		if f.op == filterAnd {
+			ranges = ranges.intersect(r)
+		} else {
+			ranges = ranges.union(r)
+		}
+	}
+
+	return ranges, nil
+}
+
+func (f *boundLogicalFilter) match(row Row) bool {
+	for _, filter := range f.filters {
+		if filter.match(row) != (f.op == filterAnd) {
+			return f.op != filterAnd
+		}
+	}
+	return f.op == filterAnd
+}
+
+type boundNotFilter struct {
+	filter boundFilter
+}
+
+func (f *boundNotFilter) columns(fn func(int)) { f.filter.columns(fn) }
+
+func (f *boundNotFilter) rowRanges(rowGroup RowGroup) (rowRanges, error) {
+	return rowRanges{{start: 0, end: rowGroup.NumRows()}}, nil
+}
+
+func (f *boundNotFilter) match(row Row) bool { return !f.filter.match(row) }
+
+// rowRange represents the half-open range of row indexes [start:end).
+type rowRange struct {
+	start int64
+	end   int64
+}
+
+// rowRanges is a sorted list of non-overlapping row ranges.
+type rowRanges []rowRange
+
+func (ranges rowRanges) append(start, end int64) rowRanges {
+	if start >= end {
+		return ranges
+	}
+	if n := len(ranges); n > 0 && ranges[n-1].end >= start {
+		if end > ranges[n-1].end {
+			ranges[n-1].end = end
+		}
+		return ranges
+	}
+	return append(ranges, rowRange{start: start, end: end})
+}
+
+// search returns the index of the first range ending after rowIndex.
+func (ranges rowRanges) search(rowIndex int64) int {
+	return sort.Search(len(ranges), func(i int) bool { return ranges[i].end > rowIndex })
+}
+
+func (ranges rowRanges) intersect(other rowRanges) rowRanges {
+	result := rowRanges{}
+
+	for i, j := 0, 0; i < len(ranges) && j < len(other); {
+		start, end := ranges[i].start, ranges[i].end
+		if other[j].start > start {
+			start = other[j].start
+		}
+		if other[j].end < end {
+			end = other[j].end
+		}
+		result = result.append(start, end)
+
+		if ranges[i].end < other[j].end {
+			i++
+		} else {
+			j++
+		}
+	}
+
+	return result
+}
+
+func (ranges rowRanges) union(other rowRanges) rowRanges {
+	result := rowRanges{}
+
+	for i, j := 0, 0; i < len(ranges) || j < len(other); {
+		var r rowRange
+		if j == len(other) || (i < len(ranges) && ranges[i].start < other[j].start) {
+			r, i = ranges[i], i+1
+		} else {
+			r, j = other[j], j+1
+		}
+		result = result.append(r.start, r.end)
+	}
+
+	return result
+}
+
+type filteredRowGroup struct {
+	rowGroup RowGroup
+	filter   boundFilter
+}
+
+func (g *filteredRowGroup) NumRows() int64 { return g.rowGroup.NumRows() }
+
+func (g *filteredRowGroup) NumColumns() int { return g.rowGroup.NumColumns() }
+
+func (g *filteredRowGroup) Column(i int) ColumnChunk { return g.rowGroup.Column(i) }
+
+func (g *filteredRowGroup) SortingColumns() []SortingColumn { return g.rowGroup.SortingColumns() }
+
+func (g *filteredRowGroup) Schema() *Schema { return g.rowGroup.Schema() }
+
+func (g *filteredRowGroup) Rows() Rows { return &filteredRows{rowGroup: g.rowGroup, filter: g.filter} }
+
+// filteredRows is an implementation of the Rows interface which skips the rows
+// that do not match a filter.
+//
+// The row ranges which may contain matching rows are computed on the first
+// read, the underlying rows are then positioned at the beginning of each range
+// so pages holding no candidate rows are never read. The cursor is the index
+// of the first range which may contain rows at or after rowIndex, the ranges
+// are retained so seeking backward can resume reading from earlier ranges.
+type filteredRows struct {
+	rowGroup RowGroup
+	filter   boundFilter
+	rows     Rows
+	ranges   rowRanges
+	cursor   int
+	rowIndex int64
+}
+
+func (r *filteredRows) init() error {
+	ranges, err := r.filter.rowRanges(r.rowGroup)
+	if err != nil {
+		return err
+	}
+	r.ranges = ranges
+	r.cursor = r.ranges.search(r.rowIndex)
+	r.rows = r.rowGroup.Rows()
+	if r.rowIndex > 0 {
+		return r.rows.SeekToRow(r.rowIndex)
+	}
+	return nil
+}
+
+func (r *filteredRows) Schema() *Schema { return r.rowGroup.Schema() }
+
+func (r *filteredRows) SeekToRow(rowIndex int64) error {
+	if r.rows != nil && rowIndex != r.rowIndex {
+		if err := r.rows.SeekToRow(rowIndex); err != nil {
+			return err
+		}
+		r.cursor = r.ranges.search(rowIndex)
+	}
+	r.rowIndex = rowIndex
+	return nil
+}
+
+func (r *filteredRows) ReadRow(row Row) (Row, error) {
+	if r.rows == nil {
+		if err := r.init(); err != nil {
+			return row, err
+		}
+	}
+
+	n := len(row)
+
+	for {
+		for r.cursor < len(r.ranges) && r.ranges[r.cursor].end <= r.rowIndex {
+			r.cursor++
+		}
+		if r.cursor == len(r.ranges) {
+			return row[:n], io.EOF
+		}
+
+		if start := r.ranges[r.cursor].start; start > r.rowIndex {
+			if err := r.rows.SeekToRow(start); err != nil {
+				return row[:n], err
+			}
+			r.rowIndex = start
+		}
+
+		var err error
+		row, err = r.rows.ReadRow(row[:n])
+		if err != nil {
+			return row[:n], err
+		}
+		r.rowIndex++
+
+		if r.filter.match(row[n:]) {
+			return row, nil
+		}
+	}
+}
+
+func (r *filteredRows) ReadRows(rows []Row) (int, error) {
+	for i := range rows {
+		row, err := r.ReadRow(rows[i][:0])
+		rows[i] = row
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
+var (
+	_ RowGroup       = (*filteredRowGroup)(nil)
+	_ Rows           = (*filteredRows)(nil)
+	_ RowBatchReader = (*filteredRows)(nil)
+)
@@ -0,0 +1,274 @@
+package parquet_test
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+type filterRow struct {
+	ID    int64   `parquet:"id"`
+	Name  string  `parquet:"name"`
+	Score *int32  `parquet:"score,optional"`
+	Tags  []int32 `parquet:"tags"`
+}
+
+func makeFilterRows(n int) []filterRow {
+	rows := make([]filterRow, n)
+	for i := range rows {
+		rows[i] = filterRow{
+			ID:   int64(i),
+			Name: string(rune('a' + i%26)),
+			Tags: []int32{int32(i % 7), int32(i % 11)},
+		}
+		if i%3 != 0 {
+			score := int32(i % 100)
+			rows[i].Score = &score
+		}
+	}
+	return rows
+}
+
+// filterFileOptions configure the writer of the files used in the tests to
+// produce row groups of 100 rows with multiple pages and bloom filters.
+var filterFileOptions = []parquet.WriterOption{
+	parquet.MaxRowsPerRowGroup(100),
+	parquet.PageBufferSize(256),
+	parquet.BloomFilters(parquet.SplitBlockFilter("id")),
+}
+
+func TestReaderFilter(t *testing.T) {
+	rows := makeFilterRows(1000)
+	file, err := createParquetFile(makeRows(rows), filterFileOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		scenario string
+		filter   parquet.Filter
+		match    func(filterRow) bool
+	}{
+		{
+			scenario: "eq",
+			filter:   parquet.Eq("id", parquet.ValueOf(int64(421))),
+			match:    func(r filterRow) bool { return r.ID == 421 },
+		},
+
+		{
+			scenario: "eq with int32 value on int64 column",
+			filter:   parquet.Eq("id", parquet.ValueOf(int32(42))),
+			match:    func(r filterRow) bool { return r.ID == 42 },
+		},
+
+		{
+			scenario: "eq not found",
+			filter:   parquet.Eq("id", parquet.ValueOf(int64(5000))),
+			match:    func(r filterRow) bool { return false },
+		},
+
+		{
+			scenario: "range",
+			filter: parquet.And(
+				parquet.GtEq("id", parquet.ValueOf(int64(180))),
+				parquet.Lt("id", parquet.ValueOf(int64(230))),
+			),
+			match: func(r filterRow) bool { return r.ID >= 180 && r.ID < 230 },
+		},
+
+		{
+			scenario: "or",
+			filter: parquet.Or(
+				parquet.LtEq("id", parquet.ValueOf(int64(3))),
+				parquet.Gt("id", parquet.ValueOf(int64(996))),
+			),
+			match: func(r filterRow) bool { return r.ID <= 3 || r.ID > 996 },
+		},
+
+		{
+			scenario: "not",
+			filter:   parquet.Not(parquet.NotEq("name", parquet.ValueOf("c"))),
+			match:    func(r filterRow) bool { return r.Name == "c" },
+		},
+
+		{
+			scenario: "is null",
+			filter: parquet.And(
+				parquet.IsNull("score"),
+				parquet.Lt("id", parquet.ValueOf(int64(20))),
+			),
+			match: func(r filterRow) bool { return r.Score == nil && r.ID < 20 },
+		},
+
+		{
+			scenario: "is not null",
+			filter: parquet.And(
+				parquet.IsNotNull("score"),
+				parquet.Gt("score", parquet.ValueOf(int32(97))),
+			),
+			match: func(r filterRow) bool { return r.Score != nil && *r.Score > 97 },
+		},
+
+		{
+			scenario: "repeated",
+			filter:   parquet.Eq("tags", parquet.ValueOf(int32(10))),
+			match:    func(r filterRow) bool { return r.ID%7 == 10 || r.ID%11 == 10 },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			want := []filterRow{}
+			for _, row := range rows {
+				if test.match(row) {
+					want = append(want, row)
+				}
+			}
+
+			r := parquet.NewReader(file, parquet.ReaderFilter(test.filter))
+			got := []filterRow{}
+			for {
+				row := filterRow{}
+				if err := r.Read(&row); err != nil {
+					if err == io.EOF {
+						break
+					}
+					t.Fatal(err)
+				}
+				got = append(got, row)
+			}
+
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("rows mismatch for filter %s\nwant = %+v\ngot  = %+v", test.filter, want, got)
+			}
+		})
+	}
+}
+
+func TestReaderFilterSeekToRow(t *testing.T) {
+	file, err := createParquetFile(makeRows(makeFilterRows(1000)), filterFileOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := parquet.NewReader(file, parquet.ReaderFilter(parquet.Or(
+		parquet.Lt("id", parquet.ValueOf(int64(3))),
+		parquet.Gt("id", parquet.ValueOf(int64(90))),
+	)))
+
+	read := func(n int) []int64 {
+		ids := make([]int64, n)
+		for i := range ids {
+			row := filterRow{}
+			if err := r.Read(&row); err != nil {
+				t.Fatal(err)
+			}
+			ids[i] = row.ID
+		}
+		return ids
+	}
+
+	for _, test := range []struct {
+		seek int64
+		want []int64
+	}{
+		{seek: 0, want: []int64{0, 1, 2, 91, 92}},
+		{seek: 0, want: []int64{0, 1, 2, 91}},
+		{seek: 4, want: []int64{91, 92}},
+		{seek: 2, want: []int64{2, 91}},
+		{seek: 950, want: []int64{950, 951}},
+		{seek: 1, want: []int64{1, 2, 91}},
+	} {
+		if err := r.SeekToRow(test.seek); err != nil {
+			t.Fatal(err)
+		}
+		if got := read(len(test.want)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("rows mismatch after seeking to row %d\nwant = %v\ngot  = %v", test.seek, test.want, got)
+		}
+	}
+}
+
+func TestFilterRowGroupProjection(t *testing.T) {
+	type idRow struct {
+		ID int64 `parquet:"id"`
+	}
+
+	rows := makeFilterRows(300)
+	file, err := createParquetFile(makeRows(rows), filterFileOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := parquet.NewReader(file, parquet.ReaderFilter(parquet.Eq("name", parquet.ValueOf("z"))))
+	got := []int64{}
+	for {
+		row := idRow{}
+		if err := r.Read(&row); err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatal(err)
+		}
+		got = append(got, row.ID)
+	}
+
+	want := []int64{}
+	for _, row := range rows {
+		if row.Name == "z" {
+			want = append(want, row.ID)
+		}
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("rows mismatch\nwant = %v\ngot  = %v", want, got)
+	}
+}
+
+func TestFilterRowGroupUnknownColumn(t *testing.T) {
+	f, err := createParquetFile(makeRows(makeFilterRows(10)), filterFileOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = parquet.FilterRowGroup(f.RowGroup(0), parquet.Eq("nope", parquet.ValueOf(1)))
+	if err == nil {
+		t.Error("expected an error when filtering on a column which does not exist")
+	}
+}
+
+func TestFilterValueConversion(t *testing.T) {
+	f, err := createParquetFile(makeRows(makeFilterRows(10)), filterFileOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := parquet.NewSchema("test", parquet.Group{
+		"y": parquet.Leaf(parquet.FloatType),
+	})
+
+	for _, filter := range []parquet.Filter{
+		parquet.Eq("score", parquet.ValueOf(int64(42))),
+		parquet.Lt("score", parquet.ValueOf(int64(-1<<31))),
+		parquet.Eq("tags", parquet.ValueOf(int32(1))),
+	} {
+		if _, err := parquet.FilterRowGroup(f.RowGroup(0), filter); err != nil {
+			t.Errorf("%s: %v", filter, err)
+		}
+	}
+
+	for _, filter := range []parquet.Filter{
+		parquet.Eq("score", parquet.ValueOf(int64(1<<33))),
+		parquet.Gt("score", parquet.ValueOf(int64(-1<<31-1))),
+	} {
+		if _, err := parquet.FilterRowGroup(f.RowGroup(0), filter); err == nil {
+			t.Errorf("%s: expected an error for a value out of the range of the column", filter)
+		}
+	}
+
+	buffer := parquet.NewBuffer(schema)
+	if _, err := parquet.FilterRowGroup(buffer, parquet.Eq("y", parquet.ValueOf(0.5))); err != nil {
+		t.Errorf("comparing a double which can be represented as a float: %v", err)
+	}
+	if _, err := parquet.FilterRowGroup(buffer, parquet.Eq("y", parquet.ValueOf(0.1))); err == nil {
+		t.Error("expected an error when comparing a double which cannot be represented as a float")
+	}
+}
@@ -0,0 +1,266 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/segmentio/parquet-go/format"
+	"github.com/segmentio/parquet-go/internal/bits"
+)
+
+// FLOAT16 values are IEEE 754 half-precision floating point numbers stored in
+// little-endian order in a FIXED_LEN_BYTE_ARRAY of two bytes.
+const (
+	float16Length = 2
+
+	float16SignBit      = 0x8000
+	float16ExponentMask = 0x7C00
+	float16MantissaMask = 0x03FF
+	float16QuietNaN     = 0x7E00
+)
+
+// float16Bits converts f to the closest half-precision value, rounding to
+// nearest even. Values too large to be represented become infinities.
+func float16Bits(f float32) uint16 {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & float16SignBit
+	exp := int32(b>>23) & 0xFF
+	mant := b & 0x7FFFFF
+
+	if exp == 0xFF {
+		if mant != 0 {
+			return sign | float16QuietNaN
+		}
+		return sign | float16ExponentMask
+	}
+
+	switch e := exp - 127 + 15; {
+	case e >= 0x1F:
+		return sign | float16ExponentMask
+	case e <= 0:
+		// The value is a subnormal half-precision number (or rounds to zero),
+		// the implicit leading bit of the mantissa becomes explicit.
+		if e < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint32(14 - e)
+		m := mant >> shift
+		rem := mant & (1<<shift - 1)
+		half := uint32(1) << (shift - 1)
+		if rem > half || (rem == half && m&1 != 0) {
+			m++
+		}
+		return sign | uint16(m)
+	default:
+		h := uint16(e)<<10 | uint16(mant>>13)
+		rem := mant & 0x1FFF
+		if rem > 0x1000 || (rem == 0x1000 && h&1 != 0) {
+			h++ // may carry into the exponent, up to infinity
+		}
+		return sign | h
+	}
+}
+
+// float16Float32 converts the half-precision value h to a float32, which is
+// always exact.
+func float16Float32(h uint16) float32 {
+	sign := uint32(h&float16SignBit) << 16
+	exp := uint32(h&float16ExponentMask) >> 10
+	mant := uint32(h & float16MantissaMask)
+
+	switch exp {
+	case 0x1F:
+		return math.Float32frombits(sign | 0x7F800000 | mant<<13)
+	case 0:
+		f := float32(mant) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	default:
+		return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+	}
+}
+
+func float16IsNaN(h uint16) bool {
+	return h&float16ExponentMask == float16ExponentMask && h&float16MantissaMask != 0
+}
+
+func float16IsZero(h uint16) bool {
+	return h&^float16SignBit == 0
+}
+
+func float16Of(b []byte) uint16 { return binary.LittleEndian.Uint16(b) }
+
+func float16Bytes(h uint16) []byte {
+	b := make([]byte, float16Length)
+	binary.LittleEndian.PutUint16(b, h)
+	return b
+}
+
+func makeFloat16Value(f float32) Value {
+	return makeValueBytes(FixedLenByteArray, float16Bytes(float16Bits(f)))
+}
+
+// compareFloat16 compares the FLOAT16 values a and b numerically. NaN values
+// are equal to each other and greater than all other values, so that sorting
+// groups them at the end.
+func compareFloat16(a, b []byte) int {
+	x, y := float16Of(a), float16Of(b)
+	switch nanX, nanY := float16IsNaN(x), float16IsNaN(y); {
+	case nanX && nanY:
+		return 0
+	case nanX:
+		return +1
+	case nanY:
+		return -1
+	}
+	return compareFloat32(float16Float32(x), float16Float32(y))
+}
+
+// float16Bounds returns the min and max of the n values returned by the value
+// function. NaN values are ignored, unless all values are NaN in which case
+// both bounds are NaN. As recommended by the parquet specification, a zero min
+// is always -0 and a zero max is always +0.
+func float16Bounds(n int, value func(int) uint16) (min, max uint16) {
+	min, max = float16QuietNaN, float16QuietNaN
+	found := false
+
+	for i := 0; i < n; i++ {
+		h := value(i)
+		switch {
+		case float16IsNaN(h):
+		case !found:
+			min, max, found = h, h, true
+		default:
+			f := float16Float32(h)
+			if f < float16Float32(min) {
+				min = h
+			}
+			if f > float16Float32(max) {
+				max = h
+			}
+		}
+	}
+
+	if found {
+		if float16IsZero(min) {
+			min = float16SignBit
+		}
+		if float16IsZero(max) {
+			max = 0
+		}
+	}
+	return min, max
+}
+
+func orderOfFloat16(values [][]byte) int {
+	floats := make([]float32, len(values))
+	for i, v := range values {
+		floats[i] = float16Float32(float16Of(v))
+	}
+	return bits.OrderOfFloat32(floats)
+}
+
+type float16Page struct{ *fixedLenByteArrayPage }
+
+func (page float16Page) value(i int) uint16 {
+	return float16Of(page.data[i*float16Length:])
+}
+
+func (page float16Page) bounds() (min, max uint16) {
+	return float16Bounds(len(page.data)/float16Length, page.value)
+}
+
+func (page float16Page) Bounds() (min, max Value) {
+	if len(page.data) > 0 {
+		minBits, maxBits := page.bounds()
+		min = makeValueBytes(FixedLenByteArray, float16Bytes(minBits))
+		max = makeValueBytes(FixedLenByteArray, float16Bytes(maxBits))
+	}
+	return min, max
+}
+
+func (page float16Page) Clone() BufferedPage {
+	return float16Page{page.fixedLenByteArrayPage.Clone().(*fixedLenByteArrayPage)}
+}
+
+func (page float16Page) Slice(i, j int64) BufferedPage {
+	return float16Page{page.fixedLenByteArrayPage.Slice(i, j).(*fixedLenByteArrayPage)}
+}
+
+func (page float16Page) Buffer() BufferedPage { return page }
+
+type float16ColumnIndex struct{ page float16Page }
+
+func (i float16ColumnIndex) NumPages() int       { return 1 }
+func (i float16ColumnIndex) NullCount(int) int64 { return 0 }
+func (i float16ColumnIndex) NullPage(int) bool   { return false }
+func (i float16ColumnIndex) MinValue(int) Value  { min, _ := i.page.Bounds(); return min }
+func (i float16ColumnIndex) MaxValue(int) Value  { _, max := i.page.Bounds(); return max }
+func (i float16ColumnIndex) IsAscending() bool   { return i.compareBounds() < 0 }
+func (i float16ColumnIndex) IsDescending() bool  { return i.compareBounds() > 0 }
+
+func (i float16ColumnIndex) compareBounds() int {
+	min, max := i.page.bounds()
+	return compareFloat32(float16Float32(min), float16Float32(max))
+}
+
+type float16ColumnBuffer struct{ *fixedLenByteArrayColumnBuffer }
+
+func newFloat16ColumnBuffer(typ Type, columnIndex int16, bufferSize int) float16ColumnBuffer {
+	return float16ColumnBuffer{newFixedLenByteArrayColumnBuffer(typ, columnIndex, bufferSize)}
+}
+
+func (col float16ColumnBuffer) Clone() ColumnBuffer {
+	return float16ColumnBuffer{col.fixedLenByteArrayColumnBuffer.Clone().(*fixedLenByteArrayColumnBuffer)}
+}
+
+func (col float16ColumnBuffer) ColumnIndex() ColumnIndex {
+	return float16ColumnIndex{float16Page{&col.fixedLenByteArrayPage}}
+}
+
+func (col float16ColumnBuffer) Pages() Pages { return onePage(col.Page()) }
+
+func (col float16ColumnBuffer) Page() BufferedPage {
+	return float16Page{&col.fixedLenByteArrayPage}
+}
+
+func (col float16ColumnBuffer) Less(i, j int) bool {
+	return compareFloat16(col.index(i), col.index(j)) < 0
+}
+
+type float16Dictionary struct{ *fixedLenByteArrayDictionary }
+
+func (d float16Dictionary) Type() Type { return newIndexedType(d.typ, d) }
+
+func (d float16Dictionary) Bounds(indexes []int32) (min, max Value) {
+	if len(indexes) > 0 {
+		minBits, maxBits := float16Bounds(len(indexes), func(i int) uint16 {
+			return float16Of(d.value(indexes[i]))
+		})
+		min = makeValueBytes(FixedLenByteArray, float16Bytes(minBits))
+		max = makeValueBytes(FixedLenByteArray, float16Bytes(maxBits))
+	}
+	return min, max
+}
+
+type float16ColumnIndexer struct {
+	*fixedLenByteArrayColumnIndexer
+}
+
+func newFloat16ColumnIndexer() float16ColumnIndexer {
+	return float16ColumnIndexer{newFixedLenByteArrayColumnIndexer(float16Length, 0)}
+}
+
+func (i float16ColumnIndexer) ColumnIndex() format.ColumnIndex {
+	minValues := splitFixedLenByteArrayList(float16Length, i.minValues)
+	maxValues := splitFixedLenByteArrayList(float16Length, i.maxValues)
+	return i.columnIndex(
+		minValues,
+		maxValues,
+		orderOfFloat16(minValues),
+		orderOfFloat16(maxValues),
+	)
+}
@@ -0,0 +1,215 @@
+package parquet_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+type float16Row struct {
+	Optional *float32  `parquet:"optional,float16,optional"`
+	Raw      [2]byte   `parquet:"raw,float16"`
+	Value    float32   `parquet:"value,float16"`
+	Values   []float32 `parquet:"values,float16"`
+}
+
+func TestFloat16Schema(t *testing.T) {
+	schema := parquet.SchemaOf(float16Row{})
+
+	for _, column := range []string{"optional", "raw", "value", "values"} {
+		t.Run(column, func(t *testing.T) {
+			node := schema.ChildByName(column)
+			typ := node.Type()
+			if typ.Kind() != parquet.FixedLenByteArray || typ.Length() != 2 {
+				t.Errorf("wrong physical type: %s", typ.PhysicalType())
+			}
+			if typ.LogicalType() == nil || typ.LogicalType().Float16 == nil {
+				t.Errorf("column is not a FLOAT16: %s", typ)
+			}
+		})
+	}
+
+	if !schema.ChildByName("values").Repeated() {
+		t.Error("slice of float32 must be repeated")
+	}
+}
+
+func TestFloat16RoundTrip(t *testing.T) {
+	optional := float32(-0.5)
+	rows := []float16Row{
+		{Optional: &optional, Raw: [2]byte{0x00, 0x3C}, Value: 1, Values: []float32{0.1, 65504, 1e-7}},
+		{Value: float32(math.Inf(-1)), Values: []float32{100000}},
+		{Value: 6e-8},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	for i := range rows {
+		if err := writer.Write(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ := f.Root().Column("value").Type(); typ.String() != "FLOAT16" {
+		t.Errorf("wrong type of column read from the file: %s", typ)
+	}
+
+	want := []float16Row{
+		{Optional: &optional, Raw: [2]byte{0x00, 0x3C}, Value: 1, Values: []float32{0.099975586, 65504, 1.1920929e-07}},
+		{Value: float32(math.Inf(-1)), Values: []float32{float32(math.Inf(+1))}},
+		{Value: 5.9604645e-08},
+	}
+
+	reader := parquet.NewReader(f)
+	for i := range want {
+		row := float16Row{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		if len(row.Values) == 0 {
+			row.Values = nil
+		}
+		if !reflect.DeepEqual(row, want[i]) {
+			t.Errorf("row %d: wrong values:\nwant = %+v\ngot  = %+v", i, want[i], row)
+		}
+	}
+	if err := reader.Read(new(float16Row)); err != io.EOF {
+		t.Errorf("expected io.EOF after the last row but got %v", err)
+	}
+}
+
+func TestFloat16Ordering(t *testing.T) {
+	type row struct {
+		Value float32 `parquet:"value,float16"`
+	}
+
+	nan := float32(math.NaN())
+	values := []float32{2, nan, -1, 0.5, float32(math.Inf(-1)), nan, 1000, -0.25}
+
+	buffer := parquet.NewBuffer(
+		parquet.SchemaOf(row{}),
+		parquet.SortingColumns(parquet.Ascending("value")),
+	)
+	for _, v := range values {
+		if err := buffer.Write(&row{Value: v}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sort.Sort(buffer)
+
+	rows := buffer.Rows()
+	want := []float32{float32(math.Inf(-1)), -1, -0.25, 0.5, 2, 1000}
+	for i := range values {
+		row, err := rows.ReadRow(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		v := float16Value(row[0].ByteArray())
+		switch {
+		case i < len(want):
+			if v != want[i] {
+				t.Errorf("value at index %d is out of order: want=%g got=%g", i, want[i], v)
+			}
+		case !math.IsNaN(float64(v)):
+			t.Errorf("value at index %d should be NaN but got %g", i, v)
+		}
+	}
+
+	output := new(bytes.Buffer)
+	writer := parquet.NewWriter(output)
+	if _, err := writer.WriteRowGroup(buffer); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	columnIndex := f.ColumnIndexes()[0]
+	if min := float16Value(columnIndex.MinValues[0]); min != float32(math.Inf(-1)) {
+		t.Errorf("wrong min value in the column index: %g", min)
+	}
+	if max := float16Value(columnIndex.MaxValues[0]); max != 1000 {
+		t.Errorf("wrong max value in the column index: %g", max)
+	}
+}
+
+func TestFloat16Bounds(t *testing.T) {
+	type row struct {
+		Value float32 `parquet:"value,float16"`
+	}
+
+	tests := []struct {
+		scenario string
+		values   []float32
+		min, max float32
+		nan      bool
+	}{
+		{scenario: "negative values", values: []float32{-1, -3, -2}, min: -3, max: -1},
+		{scenario: "zero min", values: []float32{0, 1}, min: float32(math.Copysign(0, -1)), max: 1},
+		{scenario: "zero max", values: []float32{float32(math.Copysign(0, -1)), -1}, min: -1, max: 0},
+		{scenario: "nan is ignored", values: []float32{float32(math.NaN()), 3, -3}, min: -3, max: 3},
+		{scenario: "only nan", values: []float32{float32(math.NaN())}, nan: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			buffer := parquet.NewBuffer(parquet.SchemaOf(row{}))
+			for _, v := range test.values {
+				if err := buffer.Write(&row{Value: v}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			columnIndex := buffer.Column(0).ColumnIndex()
+			min, max := columnIndex.MinValue(0), columnIndex.MaxValue(0)
+			minValue, maxValue := float16Value(min.ByteArray()), float16Value(max.ByteArray())
+
+			if test.nan {
+				if !math.IsNaN(float64(minValue)) || !math.IsNaN(float64(maxValue)) {
+					t.Errorf("bounds should be NaN: min=%g max=%g", minValue, maxValue)
+				}
+				return
+			}
+			if math.Float32bits(minValue) != math.Float32bits(test.min) {
+				t.Errorf("wrong min: want=%g got=%g", test.min, minValue)
+			}
+			if math.Float32bits(maxValue) != math.Float32bits(test.max) {
+				t.Errorf("wrong max: want=%g got=%g", test.max, maxValue)
+			}
+		})
+	}
+}
+
+// float16Value decodes a FLOAT16 value independently of the package, only
+// normal numbers, zeros, infinities and NaN are supported.
+func float16Value(b []byte) float32 {
+	h := binary.LittleEndian.Uint16(b)
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1F
+	mant := uint32(h & 0x3FF)
+	switch exp {
+	case 0:
+		return math.Float32frombits(sign)
+	case 0x1F:
+		return math.Float32frombits(sign | 0x7F800000 | mant<<13)
+	default:
+		return math.Float32frombits(sign | (exp+112)<<23 | mant<<13)
+	}
+}
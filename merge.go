@@ -131,6 +131,17 @@ func (r *mergedRowGroupRowReader) ReadRow(row Row) (Row, error) {
 	}
 }
 
+func (r *mergedRowGroupRowReader) ReadRows(rows []Row) (int, error) {
+	for i := range rows {
+		row, err := r.ReadRow(rows[i][:0])
+		rows[i] = row
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
 // func (r *mergedRowGroupRowReader) WriteRowsTo(w RowWriter) (int64, error) {
 // 	if r.rowGroup != nil {
 // 		defer func() { r.rowGroup = nil }()
@@ -263,5 +274,6 @@ func (cur optimizedRowGroupCursor) nextRowValuesOf(values []Value, columnIndex i
 
 var (
 	_ RowReaderWithSchema = (*mergedRowGroupRowReader)(nil)
+	_ RowBatchReader      = (*mergedRowGroupRowReader)(nil)
 	//_ RowWriterTo         = (*mergedRowGroupRowReader)(nil)
 )
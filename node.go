@@ -122,6 +122,8 @@ type wrappedNode struct{ Node }
 
 func (w wrappedNode) Unwrap() Node { return w.Node }
 
+func (w wrappedNode) ID() int { return fieldIDOf(w.Node) }
+
 func wrap(node Node) wrappedNode { return wrappedNode{node} }
 
 func unwrap(node Node) Node {
@@ -220,6 +222,39 @@ func (n *compressedNode) Compression() []compress.Codec {
 	return dedupeSortedCodecs(compression)
 }
 
+// FieldID wraps the node passed as argument to set its field ID.
+//
+// Field IDs are written to the schema of parquet files, and allow the columns
+// to be matched across schemas with different column names by
+// ConvertByFieldID.
+//
+// The field ID of a node is exposed by an ID() int method, which is optional so
+// that Node implementations outside of this package do not need to declare it;
+// nodes which do not have the method have no field ID.
+func FieldID(node Node, id int) Node { return &fieldIDNode{wrap(node), id} }
+
+type fieldIDNode struct {
+	wrappedNode
+	id int
+}
+
+func (n *fieldIDNode) ID() int { return n.id }
+
+// fieldIDOf returns the field ID of node, or zero if it has none. The wrappers
+// of nodes are removed until one of them has an ID method.
+func fieldIDOf(node Node) int {
+	for {
+		if n, ok := node.(interface{ ID() int }); ok {
+			return n.ID()
+		}
+		w, ok := node.(WrappedNode)
+		if !ok {
+			return 0
+		}
+		node = w.Unwrap()
+	}
+}
+
 // Optional wraps the given node to make it optional.
 func Optional(node Node) Node { return &optionalNode{wrap(node)} }
 
@@ -357,6 +392,73 @@ func (g Group) Compression() []compress.Codec {
 
 func (g Group) GoType() reflect.Type { return goTypeOfGroup(g) }
 
+// orderedGroup is a group node which retains the order in which its fields were
+// declared, unlike Group which sorts them by name. It is used for the schemas
+// parsed or imported from formats where the order of fields is significant, so
+// they can be printed or exported back with the same layout.
+type orderedGroup struct {
+	names  []string
+	fields []Node
+}
+
+// add appends a field to g, returning false if a field with the same name
+// already exists.
+func (g *orderedGroup) add(name string, node Node) bool {
+	if g.ChildByName(name) != nil {
+		return false
+	}
+	g.names = append(g.names, name)
+	g.fields = append(g.fields, node)
+	return true
+}
+
+func (g *orderedGroup) String() string { return sprint("", g) }
+
+func (g *orderedGroup) Type() Type { return groupType{} }
+
+func (g *orderedGroup) Optional() bool { return false }
+
+func (g *orderedGroup) Repeated() bool { return false }
+
+func (g *orderedGroup) Required() bool { return true }
+
+func (g *orderedGroup) NumChildren() int { return len(g.fields) }
+
+func (g *orderedGroup) ChildNames() []string { return g.names }
+
+func (g *orderedGroup) ChildByName(name string) Node {
+	for i, n := range g.names {
+		if n == name {
+			return g.fields[i]
+		}
+	}
+	return nil
+}
+
+func (g *orderedGroup) ValueByName(base reflect.Value, name string) reflect.Value {
+	return base.MapIndex(reflect.ValueOf(name))
+}
+
+func (g *orderedGroup) Encoding() []encoding.Encoding {
+	encodings := make([]encoding.Encoding, 0, len(g.fields))
+	for _, node := range g.fields {
+		encodings = append(encodings, node.Encoding()...)
+	}
+	sortEncodings(encodings)
+	return dedupeSortedEncodings(encodings)
+}
+
+func (g *orderedGroup) Compression() []compress.Codec {
+	codecs := make([]compress.Codec, 0, len(g.fields))
+	for _, node := range g.fields {
+		codecs = append(codecs, node.Compression()...)
+	}
+	sortCodecs(codecs)
+	return dedupeSortedCodecs(codecs)
+}
+
+func (g *orderedGroup) GoType() reflect.Type { return goTypeOfGroup(g) }
+
 func goTypeOf(node Node) reflect.Type {
 	switch {
 	case node.Optional():
@@ -508,9 +610,13 @@ func encodingAndCompressionOf(node Node) (encoding.Encoding, compress.Codec) {
 		encoding = &DeltaLengthByteArray
 	}
 
+	// Columns of parquet files also list the encodings of repetition and
+	// definition levels, which may not support the type of values.
 	for _, e := range node.Encoding() {
-		encoding = e
-		break
+		if e.CanEncode(format.Type(node.Type().Kind())) {
+			encoding = e
+			break
+		}
 	}
 
 	for _, c := range node.Compression() {
@@ -459,8 +459,8 @@ func (page *repeatedPage) Slice(i, j int64) BufferedPage {
 	rowIndex1 := int64(len(page.repetitionLevels))
 	rowIndex2 := int64(len(page.repetitionLevels))
 
-	for k, def := range page.repetitionLevels {
-		if def != page.maxRepetitionLevel {
+	for k, rep := range page.repetitionLevels {
+		if rep == 0 {
 			if rowIndex0 == i {
 				rowIndex1 = int64(k)
 			}
@@ -474,8 +474,10 @@ func (page *repeatedPage) Slice(i, j int64) BufferedPage {
 	numNulls1 := int64(countLevelsNotEqual(page.definitionLevels[:rowIndex1], page.maxDefinitionLevel))
 	numNulls2 := int64(countLevelsNotEqual(page.definitionLevels[rowIndex1:rowIndex2], page.maxDefinitionLevel))
 
-	i -= numNulls1
-	j = i + (rowIndex2 - (rowIndex1 + numNulls2))
+	// The base page only holds the non-null values, the bounds of the slice
+	// are the positions of the values of rows i and j in the base page.
+	i = rowIndex1 - numNulls1
+	j = rowIndex2 - (numNulls1 + numNulls2)
 
 	return newRepeatedPage(
 		page.base.Slice(i, j),
@@ -521,12 +523,14 @@ func (r *repeatedPageReader) ReadValues(values []Value) (n int, err error) {
 		r.values = r.page.base.Values()
 	}
 	maxDefinitionLevel := r.page.maxDefinitionLevel
+	columnIndex := ^int16(r.page.Column())
 
 	for n < len(values) && r.offset < len(r.page.definitionLevels) {
 		for n < len(values) && r.offset < len(r.page.definitionLevels) && r.page.definitionLevels[r.offset] != maxDefinitionLevel {
 			values[n] = Value{
 				repetitionLevel: r.page.repetitionLevels[r.offset],
 				definitionLevel: r.page.definitionLevels[r.offset],
+				columnIndex:     columnIndex,
 			}
 			r.offset++
 			n++
@@ -860,16 +864,40 @@ func (pool *fileBufferPool) GetPageBuffer() io.ReadWriter {
 	if err != nil {
 		return &errorBuffer{err: err}
 	}
-	return f
+	return &fileBuffer{file: f}
 }
 
 func (pool *fileBufferPool) PutPageBuffer(buf io.ReadWriter) {
-	if f, _ := buf.(*os.File); f != nil {
-		defer f.Close()
-		os.Remove(f.Name())
+	if f, _ := buf.(*fileBuffer); f != nil {
+		defer f.file.Close()
+		os.Remove(f.file.Name())
 	}
 }
 
+// fileBuffer is the implementation of io.ReadWriter used by file buffer pools.
+//
+// Writes are appended to the file while reads start from the beginning, which
+// gives temporary files the same behavior as in-memory buffers; the offset of
+// the file cannot be used since it would be positioned at the end of the data
+// after writing to it.
+type fileBuffer struct {
+	file   *os.File
+	offset int64
+}
+
+func (f *fileBuffer) Read(b []byte) (int, error) {
+	n, err := f.file.ReadAt(b, f.offset)
+	f.offset += int64(n)
+	if err == io.EOF && n > 0 {
+		err = nil
+	}
+	return n, err
+}
+
+func (f *fileBuffer) ReadAt(b []byte, off int64) (int, error) { return f.file.ReadAt(b, off) }
+
+func (f *fileBuffer) Write(b []byte) (int, error) { return f.file.Write(b) }
+
 type errorBuffer struct{ err error }
 
 func (errbuf *errorBuffer) Read([]byte) (int, error)          { return 0, errbuf.err }
@@ -882,4 +910,5 @@ var (
 
 	_ io.ReaderFrom = (*errorBuffer)(nil)
 	_ io.WriterTo   = (*errorBuffer)(nil)
+	_ io.ReaderAt   = (*fileBuffer)(nil)
 )
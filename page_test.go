@@ -491,3 +491,75 @@ func TestRepeatedPageTrailingNulls(t *testing.T) {
 		t.Errorf("wrong number of rows read: got=%d want=%d", len(resultRows), len(records))
 	}
 }
+
+func TestRepeatedPagePreserveIndex(t *testing.T) {
+	type testStruct struct {
+		A string   `parquet:"a"`
+		B []string `parquet:"b"`
+	}
+
+	schema := parquet.SchemaOf(&testStruct{})
+	buffer := parquet.NewBuffer(schema)
+
+	for _, row := range []testStruct{{A: "1"}, {A: "2", B: []string{"test"}}} {
+		if err := buffer.WriteRow(schema.Deconstruct(nil, &row)); err != nil {
+			t.Fatal("writing row:", err)
+		}
+	}
+
+	values := make([]parquet.Value, 2)
+	n, err := buffer.Column(1).(parquet.ColumnBuffer).Page().Values().ReadValues(values)
+	if err != nil && err != io.EOF {
+		t.Fatal("reading values:", err)
+	}
+	if n != 2 {
+		t.Fatalf("wrong number of values: got=%d want=%d", n, 2)
+	}
+
+	for i, v := range values {
+		if v.Column() != 1 {
+			t.Errorf("wrong index of value %d: got=%d want=%d", i, v.Column(), 1)
+		}
+	}
+}
+
+func TestRepeatedPageSlice(t *testing.T) {
+	type testStruct struct {
+		A []string `parquet:"a"`
+	}
+
+	schema := parquet.SchemaOf(&testStruct{})
+	buffer := parquet.NewBuffer(schema)
+
+	for _, row := range []testStruct{
+		{A: []string{"1", "2"}},
+		{A: nil},
+		{A: []string{"3", "4", "5"}},
+		{A: []string{"6"}},
+	} {
+		if err := buffer.WriteRow(schema.Deconstruct(nil, &row)); err != nil {
+			t.Fatal("writing row:", err)
+		}
+	}
+
+	page := buffer.Column(0).(parquet.ColumnBuffer).Page().Slice(1, 3)
+	if n := page.NumRows(); n != 2 {
+		t.Errorf("wrong number of rows: got=%d want=%d", n, 2)
+	}
+
+	values := make([]parquet.Value, 10)
+	n, err := page.Values().ReadValues(values)
+	if err != nil && err != io.EOF {
+		t.Fatal("reading values:", err)
+	}
+
+	want := []string{"<null>", "3", "4", "5"}
+	if n != len(want) {
+		t.Fatalf("wrong number of values: got=%d want=%d", n, len(want))
+	}
+	for i, v := range values[:n] {
+		if s := v.String(); s != want[i] {
+			t.Errorf("wrong value at index %d: got=%q want=%q", i, s, want[i])
+		}
+	}
+}
@@ -22,6 +22,13 @@ type AddressBook struct {
 	Contacts          []Contact `parquet:"contacts"`
 }
 
+func fieldIDOf(node parquet.Node) int {
+	if n, ok := node.(interface{ ID() int }); ok {
+		return n.ID()
+	}
+	return 0
+}
+
 func forEachLeafColumn(col *parquet.Column, do func(*parquet.Column) error) error {
 	children := col.Columns()
 
@@ -90,6 +90,7 @@ func printWithIndent(w io.StringWriter, name string, node Node, indent *printInd
 			w.WriteString(")")
 		}
 
+		printFieldID(w, node)
 		w.WriteString(";")
 	} else {
 		w.WriteString("group")
@@ -105,6 +106,7 @@ func printWithIndent(w io.StringWriter, name string, node Node, indent *printInd
 			w.WriteString(")")
 		}
 
+		printFieldID(w, node)
 		w.WriteString(" {")
 		indent.writeNewLine(w)
 		indent.push()
@@ -120,6 +122,13 @@ func printWithIndent(w io.StringWriter, name string, node Node, indent *printInd
 	}
 }
 
+func printFieldID(w io.StringWriter, node Node) {
+	if id := fieldIDOf(node); id != 0 {
+		w.WriteString(" = ")
+		w.WriteString(strconv.Itoa(id))
+	}
+}
+
 func annotationOf(node Node) string {
 	if logicalType := node.Type().LogicalType(); logicalType != nil {
 		return logicalType.String()
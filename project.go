@@ -0,0 +1,263 @@
+package parquet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// projectSchema returns a schema retaining only the columns of schema which
+// are selected by paths. A path selects a leaf column when it is equal to the
+// path of the column, or to the path of one of its parent groups.
+//
+// The function returns schema itself when all columns are selected, and nil
+// when none of them are.
+func projectSchema(schema *Schema, paths []columnPath) *Schema {
+	node, all := projectNode(schema, nil, paths)
+	switch {
+	case node == nil:
+		return nil
+	case all:
+		return schema
+	default:
+		return NewSchema(schema.Name(), node)
+	}
+}
+
+// projectNode returns the projection of node on paths, and whether all its
+// columns were selected, in which case node itself is returned.
+func projectNode(node Node, path columnPath, paths []columnPath) (Node, bool) {
+	for _, p := range paths {
+		if path.equal(p) {
+			return node, true
+		}
+	}
+
+	if isLeaf(node) {
+		return nil, false
+	}
+
+	names := node.ChildNames()
+	group := make(Group, len(names))
+	all := true
+
+	for _, name := range names {
+		child, childAll := projectNode(node.ChildByName(name), path.append(name), paths)
+		if child != nil {
+			group[name] = child
+		}
+		all = all && childAll
+	}
+
+	switch {
+	case len(group) == 0:
+		return nil, false
+	case all:
+		return node, true
+	}
+
+	// The keys of a map cannot be removed from the projection of its values,
+	// the projection is rebuilt with the key column selected.
+	if isMap(node) {
+		keyPath := path.append("key_value").append("key")
+		if keyValue, _ := projectNode(node.ChildByName("key_value"), path.append("key_value"), append(paths[:len(paths):len(paths)], keyPath)); keyValue != nil {
+			group["key_value"] = keyValue
+		}
+	}
+
+	// Leaf columns are retained with their encodings and compression codecs,
+	// the group must also retain the properties it does not derive from its
+	// children, including the LIST and MAP logical types.
+	var projected Node = group
+	switch {
+	case isList(node):
+		projected = listNode{group}
+	case isMap(node):
+		projected = mapNode{group}
+	}
+	switch {
+	case node.Optional():
+		projected = Optional(projected)
+	case node.Repeated():
+		projected = Repeated(projected)
+	}
+	if id := fieldIDOf(node); id != 0 {
+		projected = FieldID(projected, id)
+	}
+	return projected, false
+}
+
+// checkColumnPaths returns an error if one of the paths does not select any
+// column of schema.
+func checkColumnPaths(schema *Schema, paths []columnPath) error {
+	for _, path := range paths {
+		if !hasColumnPath(schema, path) {
+			return fmt.Errorf("cannot read column %q: column does not exist in schema %s", path, schema.Name())
+		}
+	}
+	return nil
+}
+
+func leafColumnPathsOf(node Node) []columnPath {
+	paths := make([]columnPath, 0, numLeafColumnsOf(node))
+	forEachLeafColumnOf(node, func(leaf leafColumn) {
+		paths = append(paths, leaf.path)
+	})
+	return paths
+}
+
+func splitColumnPaths(paths []string) []columnPath {
+	columnPaths := make([]columnPath, len(paths))
+	for i, path := range paths {
+		columnPaths[i] = columnPath(strings.Split(path, "."))
+	}
+	return columnPaths
+}
+
+// projectRowGroup returns a view of rowGroup exposing only the columns of
+// schema, which must be a projection of the row group schema obtained by
+// calling projectSchema.
+//
+// When the column chunks of the row group can be read independently, only the
+// chunks of the projected columns are read when reading rows, the other chunks
+// are never accessed. Otherwise, rows are read from the row group and the
+// values of columns that are not part of the projection are dropped.
+func projectRowGroup(rowGroup RowGroup, schema *Schema) *projectedRowGroup {
+	source := rowGroup.Schema()
+	remap := make([]int16, rowGroup.NumColumns())
+	for i := range remap {
+		remap[i] = -1
+	}
+
+	columns := make([]ColumnChunk, 0, numLeafColumnsOf(schema))
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		forEachLeafColumnOf(source, func(sourceLeaf leafColumn) {
+			if sourceLeaf.path.equal(leaf.path) {
+				remap[sourceLeaf.columnIndex] = leaf.columnIndex
+				columns = append(columns, rowGroup.Column(int(sourceLeaf.columnIndex)))
+			}
+		})
+	})
+
+	sorting := []SortingColumn{}
+	for _, col := range rowGroup.SortingColumns() {
+		if !hasColumnPath(schema, col.Path()) {
+			break
+		}
+		sorting = append(sorting, col)
+	}
+
+	return &projectedRowGroup{
+		schema:   schema,
+		rowGroup: rowGroup,
+		columns:  columns,
+		sorting:  sorting,
+		remap:    remap,
+		direct:   canProjectColumnChunks(rowGroup),
+	}
+}
+
+// canProjectColumnChunks returns true if the values read from the column
+// chunks of rowGroup are the values that its rows are made of, in which case
+// rows of a projection can be read directly from a subset of the chunks.
+func canProjectColumnChunks(rowGroup RowGroup) bool {
+	switch g := rowGroup.(type) {
+	case *fileRowGroup:
+		return true
+	case *concatenatedRowGroup:
+		for _, rowGroup := range g.rowGroups {
+			if !canProjectColumnChunks(rowGroup) {
+				return false
+			}
+		}
+		return true
+	case *asyncRowGroup:
+		return canProjectColumnChunks(g.base)
+	default:
+		return false
+	}
+}
+
+type projectedRowGroup struct {
+	schema   *Schema
+	rowGroup RowGroup
+	columns  []ColumnChunk
+	sorting  []SortingColumn
+	// Maps column indexes of the underlying row group to column indexes of
+	// the projection, or -1 for columns that are not part of it.
+	remap  []int16
+	direct bool
+}
+
+func (g *projectedRowGroup) NumRows() int64 { return g.rowGroup.NumRows() }
+
+func (g *projectedRowGroup) NumColumns() int { return len(g.columns) }
+
+func (g *projectedRowGroup) Column(i int) ColumnChunk { return g.columns[i] }
+
+func (g *projectedRowGroup) SortingColumns() []SortingColumn { return g.sorting }
+
+func (g *projectedRowGroup) Schema() *Schema { return g.schema }
+
+func (g *projectedRowGroup) Rows() Rows {
+	var rows Rows
+	if g.direct {
+		rows = &rowGroupRowReader{
+			rowGroup: &rowGroup{
+				schema:  g.schema,
+				numRows: g.rowGroup.NumRows(),
+				columns: g.columns,
+			},
+		}
+	} else {
+		rows = g.rowGroup.Rows()
+	}
+	return &projectedRows{rows: rows, schema: g.schema, remap: g.remap}
+}
+
+// projectedRows rewrites the column indexes of values read from rows to match
+// the projected schema, dropping the values of columns which are not part of
+// the projection.
+type projectedRows struct {
+	rows   Rows
+	schema *Schema
+	remap  []int16
+}
+
+func (r *projectedRows) ReadRow(row Row) (Row, error) {
+	n := len(row)
+	row, err := r.rows.ReadRow(row)
+	return r.project(row, n), err
+}
+
+func (r *projectedRows) ReadRows(rows []Row) (int, error) {
+	n, err := readRows(r.rows, rows)
+	for i, row := range rows[:n] {
+		rows[i] = r.project(row, 0)
+	}
+	return n, err
+}
+
+func (r *projectedRows) project(row Row, n int) Row {
+	i := n
+
+	for _, v := range row[n:] {
+		if columnIndex := r.remap[v.Column()]; columnIndex >= 0 {
+			v.columnIndex = ^columnIndex
+			row[i] = v
+			i++
+		}
+	}
+
+	clearValues(row[i:])
+	return row[:i]
+}
+
+func (r *projectedRows) Schema() *Schema { return r.schema }
+
+func (r *projectedRows) SeekToRow(rowIndex int64) error { return r.rows.SeekToRow(rowIndex) }
+
+var (
+	_ RowGroup       = (*projectedRowGroup)(nil)
+	_ Rows           = (*projectedRows)(nil)
+	_ RowBatchReader = (*projectedRows)(nil)
+)
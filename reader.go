@@ -76,40 +76,112 @@ func NewReader(input io.ReaderAt, options ...ReaderOption) *Reader {
 	column := f.Root()
 	schema := NewSchema(column.Name(), column)
 
-	r := &Reader{
-		file: reader{schema: schema},
+	rowGroups := make([]RowGroup, f.NumRowGroups())
+	for i := range rowGroups {
+		rowGroups[i] = f.RowGroup(i)
+	}
+	if c.ReadConcurrency > 1 {
+		rowGroups = asyncRowGroups(rowGroups, c.ReadConcurrency)
 	}
 
-	switch n := f.NumRowGroups(); n {
+	var rowGroup RowGroup
+	switch len(rowGroups) {
 	case 0:
-		r.file.rowGroup = newEmptyRowGroup(schema)
+		rowGroup = newEmptyRowGroup(schema)
 	case 1:
-		r.file.rowGroup = f.RowGroup(0)
+		rowGroup = rowGroups[0]
 	default:
-		rowGroups := make([]RowGroup, n)
-		for i := range rowGroups {
-			rowGroups[i] = f.RowGroup(i)
-		}
 		// TODO: should we attempt to merge the row groups via MergeRowGroups
 		// to preserve the global order of sorting columns within the file?
-		r.file.rowGroup = concat(schema, rowGroups)
+		rowGroup = concat(schema, rowGroups)
+	}
+
+	// When the reader is configured with a list of columns or a schema, the
+	// row group is projected on the selected columns so the other column
+	// chunks are never read. The filter may reference columns that are not
+	// part of the projection, in which case they are only read to evaluate
+	// the filter and dropped from the rows after.
+	projection := readerProjectionOf(c)
+	projectedSchema := schema
+	if c.Columns != nil {
+		if err := checkColumnPaths(schema, projection); err != nil {
+			panic(err)
+		}
+	}
+	if projection != nil {
+		if s := projectSchema(schema, projection); s != nil {
+			projectedSchema = s
+		}
+	}
+
+	rowGroupSchema := schema
+
+	if c.Filter != nil {
+		filter, err := c.Filter.bind(schema)
+		if err != nil {
+			panic(err)
+		}
+		if projectedSchema != schema {
+			filterSchema := projectSchema(schema, append(projection, filterColumnPathsOf(filter, schema)...))
+			if filterSchema != schema {
+				rowGroup, rowGroupSchema = projectRowGroup(rowGroup, filterSchema), filterSchema
+				if filter, err = c.Filter.bind(filterSchema); err != nil {
+					panic(err)
+				}
+			}
+		}
+		rowGroup = &filteredRowGroup{rowGroup: rowGroup, filter: filter}
+	}
+
+	if projectedSchema != rowGroupSchema {
+		rowGroup = projectRowGroup(rowGroup, projectedSchema)
 	}
 
-	if c.Schema != nil && !nodesAreEqual(c.Schema, r.file.schema) {
-		conv, err := Convert(c.Schema, r.file.schema)
+	if c.Schema != nil && !nodesAreEqual(c.Schema, projectedSchema) {
+		conv, err := Convert(c.Schema, projectedSchema)
 		if err != nil {
 			// TODO: this looks like something we should not be panicking on,
 			// but the current NewReader API does not offer a mechanism to
 			// report errors.
 			panic(err)
 		}
-		r.file.rowGroup = ConvertRowGroup(r.file.rowGroup, conv)
+		rowGroup = ConvertRowGroup(rowGroup, conv)
+	}
+
+	r := &Reader{
+		file: reader{
+			schema:   rowGroup.Schema(),
+			rowGroup: rowGroup,
+		},
 	}
 
 	r.read.init(r.file.schema, r.file.rowGroup)
 	return r
 }
 
+func readerProjectionOf(c *ReaderConfig) []columnPath {
+	switch {
+	case c.Columns != nil:
+		return splitColumnPaths(c.Columns)
+	case c.Schema != nil:
+		return leafColumnPathsOf(c.Schema)
+	default:
+		return nil
+	}
+}
+
+func filterColumnPathsOf(filter boundFilter, schema *Schema) []columnPath {
+	paths := []columnPath{}
+	filter.columns(func(columnIndex int) {
+		forEachLeafColumnOf(schema, func(leaf leafColumn) {
+			if int(leaf.columnIndex) == columnIndex {
+				paths = append(paths, leaf.path)
+			}
+		})
+	})
+	return paths
+}
+
 func sizeOf(r io.ReaderAt) (int64, error) {
 	switch f := r.(type) {
 	case interface{ Size() int64 }:
@@ -164,17 +236,54 @@ func (r *Reader) Read(row interface{}) (err error) {
 	return r.read.schema.Reconstruct(row, r.values)
 }
 
+// ReadMap reads the next row from r into a map of column names to values. The
+// row is reconstructed using the schema of the underlying parquet file, which
+// does not need to be known at compile time.
+//
+// Groups are represented by map[string]interface{} values, repeated columns by
+// []interface{} values and MAP columns by maps of the key type to interface{}
+// values. Null values are represented by nil interfaces, and leaf columns use
+// the go type of their parquet type (e.g. int32, int64, string, []byte...).
+//
+// Entries of the map that are not columns of the file are removed.
+//
+// The method returns io.EOF when no more rows can be read from r.
+func (r *Reader) ReadMap(row map[string]interface{}) (err error) {
+	r.values, err = r.ReadRow(r.values[:0])
+	if err != nil {
+		return err
+	}
+	for name := range row {
+		delete(row, name)
+	}
+	return r.file.schema.Reconstruct(&row, r.values)
+}
+
 func (r *Reader) updateReadSchema(rowType reflect.Type) error {
 	schema := schemaOf(rowType)
 
 	if nodesAreEqual(schema, r.file.schema) {
 		r.read.init(schema, r.file.rowGroup)
 	} else {
-		conv, err := Convert(schema, r.file.schema)
-		if err != nil {
-			return err
+		rowGroup := r.file.rowGroup
+		// Reading into a Go value with a subset of the columns of the file is
+		// a common use case; when possible, the row group is projected on the
+		// columns of the Go value to avoid reading the other column chunks.
+		if canProjectColumnChunks(rowGroup) {
+			projectedSchema := projectSchema(r.file.schema, leafColumnPathsOf(schema))
+			if projectedSchema != nil && projectedSchema != r.file.schema {
+				rowGroup = projectRowGroup(rowGroup, projectedSchema)
+			}
+		}
+		if nodesAreEqual(schema, rowGroup.Schema()) {
+			r.read.init(schema, rowGroup)
+		} else {
+			conv, err := Convert(schema, rowGroup.Schema())
+			if err != nil {
+				return err
+			}
+			r.read.init(schema, ConvertRowGroup(rowGroup, conv))
 		}
-		r.read.init(schema, ConvertRowGroup(r.file.rowGroup, conv))
 	}
 
 	r.seen = rowType
@@ -198,15 +307,40 @@ func (r *Reader) ReadRow(row Row) (Row, error) {
 	return row, err
 }
 
+// ReadRows reads the next rows from r into the given Row buffers, returning
+// the number of rows read.
+//
+// The method returns io.EOF when no more rows can be read from r, the number
+// of rows returned may be non-zero in this case.
+func (r *Reader) ReadRows(rows []Row) (int, error) {
+	if err := r.file.SeekToRow(r.rowIndex); err != nil {
+		return 0, err
+	}
+	n, err := r.file.ReadRows(rows)
+	r.rowIndex += int64(n)
+	return n, err
+}
+
 // Schema returns the schema of rows read by r.
 func (r *Reader) Schema() *Schema { return r.file.schema }
 
 // NumRows returns the number of rows that can be read from r.
+//
+// When the reader was configured with a filter, the returned value is the
+// number of rows in the file, which is an upper bound of the number of rows
+// matching the filter.
 func (r *Reader) NumRows() int64 { return r.file.rowGroup.NumRows() }
 
 // SeekToRow positions r at the given row index.
+//
+// When the reader was configured with a filter, the row index is relative to
+// the rows of the file, the first row read after seeking is the next row at or
+// after this index that matches the filter.
 func (r *Reader) SeekToRow(rowIndex int64) error {
-	if err := r.file.SeekToRow(rowIndex); err != nil {
+	if err := r.file.seek(rowIndex); err != nil {
+		return err
+	}
+	if err := r.read.seek(rowIndex); err != nil {
 		return err
 	}
 	r.rowIndex = rowIndex
@@ -236,15 +370,22 @@ func (r *reader) Reset() {
 	r.rowIndex = 0
 }
 
-func (r *reader) ReadRow(row Row) (Row, error) {
+func (r *reader) open() error {
 	if r.rows == nil {
 		r.rows = r.rowGroup.Rows()
 		if r.rowIndex > 0 {
 			if err := r.rows.SeekToRow(r.rowIndex); err != nil {
-				return row, err
+				return err
 			}
 		}
 	}
+	return nil
+}
+
+func (r *reader) ReadRow(row Row) (Row, error) {
+	if err := r.open(); err != nil {
+		return row, err
+	}
 	n := len(row)
 	row, err := r.rows.ReadRow(row)
 	if err == nil && len(row) == n {
@@ -255,20 +396,39 @@ func (r *reader) ReadRow(row Row) (Row, error) {
 	return row, err
 }
 
+func (r *reader) ReadRows(rows []Row) (int, error) {
+	if err := r.open(); err != nil {
+		return 0, err
+	}
+	n, err := readRows(r.rows, rows)
+	r.rowIndex += int64(n)
+	return n, err
+}
+
 func (r *reader) SeekToRow(rowIndex int64) error {
 	if rowIndex != r.rowIndex {
-		if r.rows != nil {
-			if err := r.rows.SeekToRow(rowIndex); err != nil {
-				return err
-			}
+		return r.seek(rowIndex)
+	}
+	return nil
+}
+
+// seek positions the rows at rowIndex even when it is equal to the current
+// row index. The row index counts the rows read since the last seek, which is
+// not the position in the row group when a filter skips rows.
+func (r *reader) seek(rowIndex int64) error {
+	if r.rows != nil {
+		if err := r.rows.SeekToRow(rowIndex); err != nil {
+			return err
 		}
-		r.rowIndex = rowIndex
 	}
+	r.rowIndex = rowIndex
 	return nil
 }
 
 var (
-	_ Rows      = (*Reader)(nil)
-	_ RowReader = (*reader)(nil)
-	_ RowSeeker = (*reader)(nil)
+	_ Rows           = (*Reader)(nil)
+	_ RowBatchReader = (*Reader)(nil)
+	_ RowReader      = (*reader)(nil)
+	_ RowBatchReader = (*reader)(nil)
+	_ RowSeeker      = (*reader)(nil)
 )
@@ -0,0 +1,144 @@
+//go:build go1.18
+
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// GenericReader is similar to a Reader but uses a type parameter to define the
+// Go type representing the schema of rows being read.
+//
+// This example showcases a typical use of generic parquet readers:
+//
+//	reader := parquet.NewGenericReader[RowType](file)
+//	rows := make([]RowType, 100)
+//	for {
+//		n, err := reader.Read(rows)
+//		for _, row := range rows[:n] {
+//			...
+//		}
+//		if err != nil {
+//			if err == io.EOF {
+//				break
+//			}
+//			...
+//		}
+//	}
+//
+// The schema of rows is derived once from the type parameter T, which must be a
+// struct type or a pointer to a struct type, and the functions used to
+// reconstruct Go values from parquet rows are cached with the schema.
+type GenericReader[T any] struct {
+	base   *Reader
+	schema *Schema
+	conv   Conversion
+	buffer []Value
+	values []Value
+}
+
+// NewGenericReader is like NewReader but returns a GenericReader[T] suited to
+// read rows of Go type T.
+//
+// The schema of rows is derived from T, and the rows read from the file are
+// converted to it. If the reader configuration has a schema, the rows are
+// first converted to this schema, then to the schema of T.
+//
+// The function panics if the reader configuration is invalid, or if the schema
+// of the file cannot be converted to the schema of T.
+func NewGenericReader[T any](input io.ReaderAt, options ...ReaderOption) *GenericReader[T] {
+	c, err := NewReaderConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+
+	schema := schemaOf(dereference(typeOf[T]()))
+	if c.Schema == nil {
+		c.Schema = schema
+	}
+
+	r := &GenericReader[T]{
+		base:   NewReader(input, c),
+		schema: schema,
+	}
+
+	if !nodesAreEqual(r.base.Schema(), schema) {
+		if r.conv, err = Convert(schema, r.base.Schema()); err != nil {
+			panic(err)
+		}
+	}
+
+	return r
+}
+
+// Reset repositions the reader at the beginning of the underlying parquet file.
+func (r *GenericReader[T]) Reset() { r.base.Reset() }
+
+// Read reads rows from r into the slice passed as argument, returning the
+// number of rows read.
+//
+// The method returns io.EOF when no more rows can be read from r, the number
+// of rows returned may be non-zero in this case.
+func (r *GenericReader[T]) Read(rows []T) (int, error) {
+	values := reflect.ValueOf(rows)
+
+	for i := range rows {
+		if err := r.readRow(); err != nil {
+			return i, err
+		}
+
+		v := values.Index(i)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		} else {
+			v.Set(reflect.Zero(v.Type()))
+		}
+
+		if err := r.reconstruct(v); err != nil {
+			return i, err
+		}
+	}
+
+	return len(rows), nil
+}
+
+func (r *GenericReader[T]) readRow() (err error) {
+	if r.conv == nil {
+		r.values, err = r.base.ReadRow(r.values[:0])
+		return err
+	}
+	defer clearValues(r.buffer)
+	if r.buffer, err = r.base.ReadRow(r.buffer[:0]); err != nil {
+		return err
+	}
+	r.values, err = r.conv.Convert(r.values[:0], r.buffer)
+	return err
+}
+
+func (r *GenericReader[T]) reconstruct(value reflect.Value) error {
+	defer clearValues(r.values)
+	row, err := r.schema.reconstruct(value, levels{}, r.values)
+	if len(row) > 0 && err == nil {
+		err = fmt.Errorf("%d values remain unused after reconstructing go value of type %s from parquet row", len(row), value.Type())
+	}
+	return err
+}
+
+// Schema returns the schema of rows read by r.
+func (r *GenericReader[T]) Schema() *Schema { return r.schema }
+
+// NumRows returns the number of rows that can be read from r.
+func (r *GenericReader[T]) NumRows() int64 { return r.base.NumRows() }
+
+// SeekToRow positions r at the given row index.
+func (r *GenericReader[T]) SeekToRow(rowIndex int64) error { return r.base.SeekToRow(rowIndex) }
+
+func typeOf[T any]() reflect.Type {
+	var v T
+	return reflect.TypeOf(&v).Elem()
+}
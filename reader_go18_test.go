@@ -0,0 +1,106 @@
+//go:build go1.18
+
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+func TestGenericReaderWriter(t *testing.T) {
+	rows := makeFilterRows(250)
+	buffer := new(bytes.Buffer)
+
+	writer := parquet.NewGenericWriter[filterRow](buffer)
+	if n, err := writer.Write(rows[:100]); err != nil {
+		t.Fatal(err)
+	} else if n != 100 {
+		t.Fatalf("wrong number of rows written: want=100 got=%d", n)
+	}
+	if n, err := writer.Write(rows[100:]); err != nil {
+		t.Fatal(err)
+	} else if n != 150 {
+		t.Fatalf("wrong number of rows written: want=150 got=%d", n)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewGenericReader[filterRow](bytes.NewReader(buffer.Bytes()))
+	if numRows := reader.NumRows(); numRows != int64(len(rows)) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), numRows)
+	}
+
+	batch := make([]filterRow, 64)
+	found := make([]filterRow, 0, len(rows))
+	for {
+		n, err := reader.Read(batch)
+		found = append(found, batch[:n]...)
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	if len(found) != len(rows) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d", len(rows), len(found))
+	}
+	for i := range rows {
+		if !reflect.DeepEqual(rows[i], found[i]) {
+			t.Fatalf("rows at index %d mismatch:\nwant = %+v\ngot  = %+v", i, rows[i], found[i])
+		}
+	}
+
+	if err := reader.SeekToRow(200); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.Read(batch[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if batch[0].ID != 200 {
+		t.Fatalf("wrong row read after seeking: %+v", batch[0])
+	}
+}
+
+func TestGenericReaderConversion(t *testing.T) {
+	type narrowRow struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	rows := makeFilterRows(10)
+	f, err := createParquetFile(makeRows(rows))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pointers := parquet.NewGenericReader[*filterRow](f)
+	values := make([]*filterRow, 1)
+	if _, err := pointers.Read(values); err != nil {
+		t.Fatal(err)
+	}
+	if values[0] == nil || values[0].ID != rows[0].ID {
+		t.Fatalf("wrong row read through pointer type: %+v", values[0])
+	}
+
+	reader := parquet.NewGenericReader[narrowRow](f)
+	found := make([]narrowRow, len(rows)+1)
+	n, err := reader.Read(found)
+	if err != io.EOF {
+		t.Fatalf("expected io.EOF after reading all rows, got %v", err)
+	}
+	if n != len(rows) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d", len(rows), n)
+	}
+	for i, row := range found[:n] {
+		if row.ID != rows[i].ID || row.Name != rows[i].Name {
+			t.Fatalf("rows at index %d mismatch: want=%+v got=%+v", i, rows[i], row)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/segmentio/parquet-go"
 	"github.com/segmentio/parquet-go/deprecated"
+	"github.com/segmentio/parquet-go/format"
 )
 
 type booleanColumn struct {
@@ -407,3 +408,391 @@ func TestReaderSeekToRow(t *testing.T) {
 		}
 	}
 }
+
+type rangeRecordingReader struct {
+	io.ReaderAt
+	ranges [][2]int64
+}
+
+func (r *rangeRecordingReader) ReadAt(b []byte, off int64) (int, error) {
+	r.ranges = append(r.ranges, [2]int64{off, off + int64(len(b))})
+	return r.ReaderAt.ReadAt(b, off)
+}
+
+func TestReaderColumns(t *testing.T) {
+	type wideRow struct {
+		A int64      `parquet:"a"`
+		B utf8string `parquet:"b"`
+		C float64    `parquet:"c"`
+		D []int32    `parquet:"d"`
+		E utf8string `parquet:"e,optional"`
+	}
+	type narrowRow struct {
+		B utf8string `parquet:"b"`
+		D []int32    `parquet:"d"`
+	}
+
+	rows := rowsOf(200, wideRow{})
+	buf := new(bytes.Buffer)
+	if err := writeParquetFile(buf, rows, parquet.PageBufferSize(100)); err != nil {
+		t.Fatal(err)
+	}
+
+	input := &rangeRecordingReader{ReaderAt: bytes.NewReader(buf.Bytes())}
+	f, err := parquet.OpenFile(input, int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input.ranges = nil
+
+	for _, options := range [][]parquet.ReaderOption{
+		{parquet.ReaderColumns("b", "d")},
+		{parquet.SchemaOf(narrowRow{})},
+	} {
+		reader := parquet.NewReader(f, options...)
+		if n := len(reader.Schema().ChildNames()); n != 2 {
+			t.Fatalf("wrong number of columns in the reader schema: %d", n)
+		}
+
+		for i, row := range rows {
+			want := narrowRow{B: row.(wideRow).B, D: row.(wideRow).D}
+			got := narrowRow{}
+			if err := reader.Read(&got); err != nil {
+				t.Fatalf("reading row %d: %v", i, err)
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Fatalf("row %d mismatch: want=%+v got=%+v", i, want, got)
+			}
+		}
+		if err := reader.Read(new(narrowRow)); err != io.EOF {
+			t.Fatalf("expected EOF after reading all rows but got: %v", err)
+		}
+	}
+
+	// The column chunks of columns "a", "c", and "e" must never have been read.
+	offsetIndexes := f.OffsetIndexes()
+	for _, columnIndex := range []int{0, 2, 4} {
+		for _, page := range offsetIndexes[columnIndex].PageLocations {
+			start := page.Offset
+			end := page.Offset + int64(page.CompressedPageSize)
+			for _, r := range input.ranges {
+				if r[0] < end && r[1] > start {
+					t.Fatalf("read of range [%d:%d] overlaps with page of column %d at [%d:%d]", r[0], r[1], columnIndex, start, end)
+				}
+			}
+		}
+	}
+}
+
+func TestReaderColumnsNotFound(t *testing.T) {
+	type Row struct {
+		Name string `parquet:"name"`
+	}
+
+	f, err := createParquetFile(makeRows([]Row{{Name: "A"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, columns := range [][]string{{"nmae"}, {"name", "name.first"}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%q: expected NewReader to panic when a column does not exist", columns)
+				}
+			}()
+			parquet.NewReader(f, parquet.ReaderColumns(columns...))
+		}()
+	}
+}
+
+func TestReaderColumnsFieldIDs(t *testing.T) {
+	type Info struct {
+		X int64  `parquet:"x,id=2,delta"`
+		Y string `parquet:"y,id=3"`
+	}
+	type Row struct {
+		ID   int64 `parquet:"id"`
+		Info Info  `parquet:"info,id=1"`
+	}
+
+	f, err := createParquetFile(makeRows([]Row{{ID: 1, Info: Info{X: 2, Y: "3"}}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewReader(f, parquet.ReaderColumns("info.x"))
+	info := reader.Schema().ChildByName("info")
+	if info == nil {
+		t.Fatalf("missing group in the projected schema: %s", reader.Schema())
+	}
+	if id := fieldIDOf(info); id != 1 {
+		t.Errorf("wrong field ID of the projected group: want=1 got=%d", id)
+	}
+	x := info.ChildByName("x")
+	if id := fieldIDOf(x); id != 2 {
+		t.Errorf("wrong field ID of the projected column: want=2 got=%d", id)
+	}
+	if encodings := x.Encoding(); len(encodings) == 0 || encodings[0].Encoding() != format.DeltaBinaryPacked {
+		t.Errorf("wrong encodings of the projected column: %v", encodings)
+	}
+	if names := info.ChildNames(); len(names) != 1 {
+		t.Errorf("wrong columns in the projected group: %v", names)
+	}
+}
+
+func TestReaderColumnsListAndMap(t *testing.T) {
+	type Point struct {
+		X int64 `parquet:"x"`
+		Y int64 `parquet:"y"`
+	}
+	type Row struct {
+		ID     int64            `parquet:"id"`
+		Labels map[string]Point `parquet:"labels"`
+		Points []Point          `parquet:"points,list"`
+	}
+
+	f, err := createParquetFile(makeRows([]Row{
+		{ID: 1, Points: []Point{{X: 1, Y: 2}, {X: 3, Y: 4}}, Labels: map[string]Point{"a": {X: 5, Y: 6}}},
+		{ID: 2},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewReader(f, parquet.ReaderColumns("points.list.element.x", "labels.key_value.value.y"))
+
+	const want = `message Row {
+	required group labels (MAP) {
+		repeated group key_value {
+			required binary key (STRING);
+			required group value {
+				required int64 y (INT(64,true));
+			}
+		}
+	}
+	required group points (LIST) {
+		repeated group list {
+			required group element {
+				required int64 x (INT(64,true));
+			}
+		}
+	}
+}`
+	if s := reader.Schema().String(); s != want {
+		t.Fatalf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", want, s)
+	}
+
+	for _, want := range []Row{
+		{Points: []Point{{X: 1}, {X: 3}}, Labels: map[string]Point{"a": {Y: 6}}},
+		{Points: []Point{}, Labels: map[string]Point{}},
+	} {
+		row := Row{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(row, want) {
+			t.Errorf("row mismatch:\nwant = %+v\ngot  = %+v", want, row)
+		}
+	}
+}
+
+func TestReaderReadConcurrency(t *testing.T) {
+	rows := makeFilterRows(1000)
+	file, err := createParquetFile(makeRows(rows), filterFileOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, concurrency := range []int{1, 2, 4, 16} {
+		reader := parquet.NewReader(file, parquet.ReadConcurrency(concurrency))
+		for i := range rows {
+			row := filterRow{}
+			if err := reader.Read(&row); err != nil {
+				t.Fatalf("concurrency=%d: reading row %d: %v", concurrency, i, err)
+			}
+			if !reflect.DeepEqual(row, rows[i]) {
+				t.Fatalf("concurrency=%d: row %d mismatch:\nwant = %+v\ngot  = %+v", concurrency, i, rows[i], row)
+			}
+		}
+		if err := reader.Read(new(filterRow)); err != io.EOF {
+			t.Fatalf("concurrency=%d: expected EOF after reading all rows but got: %v", concurrency, err)
+		}
+
+		if err := reader.SeekToRow(450); err != nil {
+			t.Fatalf("concurrency=%d: seeking to row 450: %v", concurrency, err)
+		}
+		for i := 450; i < 650; i++ {
+			row := filterRow{}
+			if err := reader.Read(&row); err != nil {
+				t.Fatalf("concurrency=%d: reading row %d after seek: %v", concurrency, i, err)
+			}
+			if !reflect.DeepEqual(row, rows[i]) {
+				t.Fatalf("concurrency=%d: row %d mismatch after seek:\nwant = %+v\ngot  = %+v", concurrency, i, rows[i], row)
+			}
+		}
+	}
+
+	reader := parquet.NewReader(file,
+		parquet.ReadConcurrency(4),
+		parquet.ReaderColumns("id", "tags"),
+		parquet.ReaderFilter(parquet.Gt("id", parquet.ValueOf(int64(500)))),
+	)
+	for i := 501; i < len(rows); i++ {
+		row := filterRow{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatalf("reading filtered row %d: %v", i, err)
+		}
+		want := filterRow{ID: rows[i].ID, Tags: rows[i].Tags}
+		if !reflect.DeepEqual(row, want) {
+			t.Fatalf("filtered row %d mismatch:\nwant = %+v\ngot  = %+v", i, want, row)
+		}
+	}
+	if err := reader.Read(new(filterRow)); err != io.EOF {
+		t.Fatalf("expected EOF after reading all filtered rows but got: %v", err)
+	}
+}
+
+func TestReaderReadConcurrencyInvalid(t *testing.T) {
+	if _, err := parquet.NewReaderConfig(parquet.ReadConcurrency(-1)); err == nil {
+		t.Fatal("expected an error for a negative read concurrency")
+	}
+}
+
+func TestReaderReadMap(t *testing.T) {
+	schema := parquet.NewSchema("event", parquet.Group{
+		"id":   parquet.Int(64),
+		"name": parquet.Optional(parquet.String()),
+		"tags": parquet.Repeated(parquet.String()),
+		"scores": parquet.List(
+			parquet.Leaf(parquet.DoubleType),
+		),
+		"attributes": parquet.Map(
+			parquet.String(),
+			parquet.Optional(parquet.Int(32)),
+		),
+		"location": parquet.Optional(parquet.Group{
+			"lat": parquet.Leaf(parquet.DoubleType),
+			"lon": parquet.Leaf(parquet.DoubleType),
+		}),
+	})
+
+	rows := []map[string]interface{}{
+		{
+			"id":         int64(1),
+			"name":       "first",
+			"tags":       []interface{}{"a", "b"},
+			"scores":     []interface{}{0.5, 1.5},
+			"attributes": map[string]interface{}{"x": int32(0), "y": nil},
+			"location":   map[string]interface{}{"lat": 48.85, "lon": 2.35},
+		},
+		{
+			"id":         int64(2),
+			"name":       nil,
+			"tags":       []interface{}{},
+			"scores":     []interface{}{},
+			"attributes": map[string]interface{}{},
+			"location":   nil,
+		},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, schema)
+	for _, row := range rows {
+		if err := writer.WriteMap(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(buffer.Bytes()))
+	row := map[string]interface{}{"unknown": true}
+	for i := range rows {
+		if err := reader.ReadMap(row); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(row, rows[i]) {
+			t.Errorf("row %d mismatch:\nwant = %#v\ngot  = %#v", i, rows[i], row)
+		}
+	}
+	if err := reader.ReadMap(row); err != io.EOF {
+		t.Errorf("expected EOF after reading all rows but got: %v", err)
+	}
+}
+
+func TestWriterWriteMap(t *testing.T) {
+	type mapRow struct {
+		ID   int64    `parquet:"id"`
+		Name *string  `parquet:"name,optional"`
+		Tags []string `parquet:"tags"`
+	}
+
+	if err := parquet.NewWriter(io.Discard).WriteMap(map[string]interface{}{}); err != parquet.ErrRowGroupSchemaMissing {
+		t.Errorf("expected an error when writing a map without a schema but got: %v", err)
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, parquet.SchemaOf(mapRow{}))
+	if err := writer.WriteMap(map[string]interface{}{"id": 42, "tags": []string{"a", "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(buffer.Bytes()))
+	row := mapRow{}
+	if err := reader.Read(&row); err != nil {
+		t.Fatal(err)
+	}
+	if want := (mapRow{ID: 42, Tags: []string{"a", "b"}}); !reflect.DeepEqual(row, want) {
+		t.Errorf("row mismatch:\nwant = %+v\ngot  = %+v", want, row)
+	}
+}
+
+func TestWriterWriteMapErrors(t *testing.T) {
+	schema := parquet.NewSchema("event", parquet.Group{
+		"id":   parquet.Int(64),
+		"tags": parquet.Repeated(parquet.String()),
+		"location": parquet.Optional(parquet.Group{
+			"lat": parquet.Leaf(parquet.DoubleType),
+		}),
+	})
+
+	tests := []struct {
+		scenario string
+		row      map[string]interface{}
+	}{
+		{"wrong leaf type", map[string]interface{}{"id": "42"}},
+		{"wrong group type", map[string]interface{}{"id": int64(1), "location": "paris"}},
+		{"missing required column", map[string]interface{}{"tags": []string{"a"}}},
+		{"null required column", map[string]interface{}{"id": nil}},
+		{"missing nested required column", map[string]interface{}{"id": int64(1), "location": map[string]interface{}{}}},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, schema)
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			if err := writer.WriteMap(test.row); err == nil {
+				t.Error("expected an error but got nil")
+			}
+		})
+	}
+
+	if err := writer.WriteMap(map[string]interface{}{"id": int64(1), "location": nil}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := f.Metadata().NumRows; n != 1 {
+		t.Errorf("wrong number of rows written: want=1 got=%d", n)
+	}
+}
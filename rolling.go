@@ -0,0 +1,522 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultMaxFileRows     = math.MaxInt64
+	DefaultMaxFileSize     = math.MaxInt64
+	DefaultMaxFileDuration = time.Duration(math.MaxInt64)
+
+	// HivePartitionDefault is the partition value used for rows where the
+	// value of a partition column is null.
+	HivePartitionDefault = "__HIVE_DEFAULT_PARTITION__"
+)
+
+// The RollingWriterConfig type carries configuration options for rolling
+// writers.
+//
+// RollingWriterConfig implements the RollingWriterOption interface so it can be
+// used directly as argument to the NewRollingWriter function when needed, for
+// example:
+//
+//	writer := parquet.NewRollingWriter(createFile, &parquet.RollingWriterConfig{
+//		MaxFileRows: 1e6,
+//	})
+//
+type RollingWriterConfig struct {
+	MaxFileRows      int64
+	MaxFileSize      int64
+	MaxFileDuration  time.Duration
+	PartitionColumns []string
+	FileClosed       func(RollingFile)
+	WriterOptions    []WriterOption
+}
+
+// DefaultRollingWriterConfig returns a new RollingWriterConfig value
+// initialized with the default rolling writer configuration.
+func DefaultRollingWriterConfig() *RollingWriterConfig {
+	return &RollingWriterConfig{
+		MaxFileRows:     DefaultMaxFileRows,
+		MaxFileSize:     DefaultMaxFileSize,
+		MaxFileDuration: DefaultMaxFileDuration,
+	}
+}
+
+// NewRollingWriterConfig constructs a new rolling writer configuration applying
+// the options passed as arguments.
+//
+// The function returns an non-nil error if some of the options carried invalid
+// configuration values.
+func NewRollingWriterConfig(options ...RollingWriterOption) (*RollingWriterConfig, error) {
+	config := DefaultRollingWriterConfig()
+	config.Apply(options...)
+	return config, config.Validate()
+}
+
+// Apply applies the given list of options to c.
+func (c *RollingWriterConfig) Apply(options ...RollingWriterOption) {
+	for _, opt := range options {
+		opt.ConfigureRollingWriter(c)
+	}
+}
+
+// ConfigureRollingWriter applies configuration options from c to config.
+func (c *RollingWriterConfig) ConfigureRollingWriter(config *RollingWriterConfig) {
+	fileClosed := config.FileClosed
+	if c.FileClosed != nil {
+		fileClosed = c.FileClosed
+	}
+	*config = RollingWriterConfig{
+		MaxFileRows:      coalesceInt64(c.MaxFileRows, config.MaxFileRows),
+		MaxFileSize:      coalesceInt64(c.MaxFileSize, config.MaxFileSize),
+		MaxFileDuration:  time.Duration(coalesceInt64(int64(c.MaxFileDuration), int64(config.MaxFileDuration))),
+		PartitionColumns: coalesceStrings(c.PartitionColumns, config.PartitionColumns),
+		FileClosed:       fileClosed,
+		WriterOptions:    append(config.WriterOptions[:len(config.WriterOptions):len(config.WriterOptions)], c.WriterOptions...),
+	}
+}
+
+// Validate returns a non-nil error if the configuration of c is invalid.
+func (c *RollingWriterConfig) Validate() error {
+	const baseName = "parquet.(*RollingWriterConfig)."
+	return errorInvalidConfiguration(
+		validatePositiveInt64(baseName+"MaxFileRows", c.MaxFileRows),
+		validatePositiveInt64(baseName+"MaxFileSize", c.MaxFileSize),
+		validatePositiveInt64(baseName+"MaxFileDuration", int64(c.MaxFileDuration)),
+	)
+}
+
+// RollingWriterOption is an interface implemented by types that carry
+// configuration options for rolling writers.
+type RollingWriterOption interface {
+	ConfigureRollingWriter(*RollingWriterConfig)
+}
+
+type rollingWriterOption func(*RollingWriterConfig)
+
+func (opt rollingWriterOption) ConfigureRollingWriter(config *RollingWriterConfig) { opt(config) }
+
+// MaxFileRows creates a configuration option which sets the maximum number of
+// rows written to each file produced by a rolling writer.
+//
+// Defaults to no limit.
+func MaxFileRows(numRows int64) RollingWriterOption {
+	return rollingWriterOption(func(config *RollingWriterConfig) { config.MaxFileRows = numRows })
+}
+
+// MaxFileSize creates a configuration option which sets the size in bytes at
+// which rolling writers start a new file.
+//
+// The size of a file is estimated from the row groups already written to it
+// and the rows that are still buffered by its writer, files are closed as soon
+// as the estimate reaches the limit. The size of buffered rows is extrapolated
+// from the last estimate made by the writer, which is refreshed when pages are
+// flushed, so the limit may be exceeded by a fraction of a page.
+//
+// Defaults to no limit.
+func MaxFileSize(size int64) RollingWriterOption {
+	return rollingWriterOption(func(config *RollingWriterConfig) { config.MaxFileSize = size })
+}
+
+// MaxFileDuration creates a configuration option which sets the maximum amount
+// of time during which a rolling writer keeps writing rows to the same file.
+//
+// A file which reached its maximum age is closed before the next row routed to
+// it is written, files that do not receive rows anymore are only closed when
+// the program calls Roll or Close on the writer.
+//
+// Defaults to no limit.
+func MaxFileDuration(duration time.Duration) RollingWriterOption {
+	return rollingWriterOption(func(config *RollingWriterConfig) { config.MaxFileDuration = duration })
+}
+
+// PartitionBy creates a configuration option which sets the columns used to
+// route rows to partitioned files.
+//
+// Each column is identified by its path in the schema, with the names of the
+// fields separated by dots. Partition columns must not be repeated.
+//
+// Defaults to no partitioning, all rows are written to the same file.
+func PartitionBy(columns ...string) RollingWriterOption {
+	columns = append([]string{}, columns...)
+	return rollingWriterOption(func(config *RollingWriterConfig) { config.PartitionColumns = columns })
+}
+
+// FileClosed creates a configuration option which installs a callback invoked
+// by rolling writers each time they finish writing a file.
+func FileClosed(callback func(RollingFile)) RollingWriterOption {
+	return rollingWriterOption(func(config *RollingWriterConfig) { config.FileClosed = callback })
+}
+
+// FileWriterOptions creates a configuration option which sets the options used
+// to create the writer of each file produced by a rolling writer.
+func FileWriterOptions(options ...WriterOption) RollingWriterOption {
+	options = append([]WriterOption{}, options...)
+	return rollingWriterOption(func(config *RollingWriterConfig) {
+		config.WriterOptions = append(config.WriterOptions, options...)
+	})
+}
+
+// FileFactory is the type of functions used by rolling writers to create the
+// files that they write rows to.
+//
+// The partition argument is the Hive-style path of the partition that the rows
+// written to the file belong to, for example "country=FR/year=2022", or an
+// empty string when the writer does not partition rows. The writer calls Close
+// on the returned value after writing the footer of the file.
+type FileFactory func(partition string) (io.WriteCloser, error)
+
+// RollingFile carries information about a file written by a rolling writer.
+type RollingFile struct {
+	// The partition that the file was created for.
+	Partition string
+	// The number of rows and the size of the file.
+	NumRows int64
+	Size    int64
+	// Statistics of the file columns, in the order of the schema leaves.
+	Columns []RollingFileColumn
+}
+
+// RollingFileColumn carries statistics about a column of a file written by a
+// rolling writer.
+type RollingFileColumn struct {
+	Path      []string
+	NullCount int64
+	Min       Value
+	Max       Value
+}
+
+// RollingWriter is a type similar to Writer which splits the rows written to it
+// across multiple parquet files.
+//
+// A new file is started when the current one reaches one of the configured
+// thresholds on the number of rows, size, or age. Rows can also be routed to
+// per-partition files depending on the values of partition columns; each
+// partition then has its own current file.
+//
+// Files are created by calling the FileFactory passed to NewRollingWriter, and
+// the FileClosed callback reports each finished file, for example:
+//
+//	writer := parquet.NewRollingWriter(
+//		func(partition string) (io.WriteCloser, error) {
+//			dir := filepath.Join(root, partition)
+//			if err := os.MkdirAll(dir, 0755); err != nil {
+//				return nil, err
+//			}
+//			return os.CreateTemp(dir, "*.parquet")
+//		},
+//		parquet.MaxFileRows(1e6),
+//		parquet.MaxFileDuration(time.Hour),
+//		parquet.PartitionBy("country"),
+//		parquet.FileClosed(func(f parquet.RollingFile) {
+//			...
+//		}),
+//	)
+//
+// The partition columns remain present in the rows written to the files.
+type RollingWriter struct {
+	config     *RollingWriterConfig
+	createFile FileFactory
+	schema     *Schema
+	columns    []leafColumn
+	partitions []int16
+	files      map[string]*rollingFile
+	writers    []*Writer
+	values     []Value
+	path       strings.Builder
+	// Starts the timers expiring files after the maximum duration, returning
+	// a function to stop them. Tests replace it to expire files on demand.
+	afterFunc func(time.Duration, func()) (stop func() bool)
+}
+
+// NewRollingWriter constructs a rolling writer creating files with the given
+// factory, and using the list of options passed as arguments to configure the
+// rolling writer returned by the function.
+//
+// The function panics if the rolling writer configuration is invalid, or if
+// the partition columns cannot be found in a schema passed as writer option.
+func NewRollingWriter(createFile FileFactory, options ...RollingWriterOption) *RollingWriter {
+	config, err := NewRollingWriterConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+	writerConfig, err := NewWriterConfig(config.WriterOptions...)
+	if err != nil {
+		panic(err)
+	}
+	w := &RollingWriter{
+		config:     config,
+		createFile: createFile,
+		files:      make(map[string]*rollingFile),
+		afterFunc:  afterFunc,
+	}
+	if writerConfig.Schema != nil {
+		if err := w.configure(writerConfig.Schema); err != nil {
+			panic(err)
+		}
+	}
+	return w
+}
+
+func (w *RollingWriter) configure(schema *Schema) error {
+	columns := make([]leafColumn, 0, 16)
+	forEachLeafColumnOf(schema, func(leaf leafColumn) { columns = append(columns, leaf) })
+
+	partitions := make([]int16, len(w.config.PartitionColumns))
+	for i, name := range w.config.PartitionColumns {
+		path := columnPath(strings.Split(name, "."))
+		found := false
+		for _, leaf := range columns {
+			if leaf.path.equal(path) {
+				if leaf.maxRepetitionLevel > 0 {
+					return fmt.Errorf("cannot partition rows by repeated column %q", name)
+				}
+				partitions[i], found = leaf.columnIndex, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("partition column %q not found in schema %s", name, schema.Name())
+		}
+	}
+
+	w.schema = schema
+	w.columns = columns
+	w.partitions = partitions
+	return nil
+}
+
+// Close closes all the files currently open in the writer.
+func (w *RollingWriter) Close() error { return w.Roll() }
+
+// Roll closes all the files currently open in the writer; rows written after
+// Roll returns are written to new files.
+//
+// Programs can call Roll periodically to close the files that stopped
+// receiving rows before they reached any of the configured thresholds.
+func (w *RollingWriter) Roll() error {
+	partitions := make([]string, 0, len(w.files))
+	for partition := range w.files {
+		partitions = append(partitions, partition)
+	}
+	sort.Strings(partitions)
+
+	var lastErr error
+	for _, partition := range partitions {
+		f := w.files[partition]
+		delete(w.files, partition)
+		if err := w.closeFile(f); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+// Write writes a row held in a Go value to the writer.
+func (w *RollingWriter) Write(row interface{}) error {
+	if w.schema == nil {
+		if err := w.configure(SchemaOf(row)); err != nil {
+			return err
+		}
+	}
+	defer func() {
+		clearValues(w.values)
+	}()
+	var err error
+	if w.values, err = deconstructRow(w.schema, w.values[:0], row); err != nil {
+		return err
+	}
+	return w.WriteRow(w.values)
+}
+
+// WriteRow writes a parquet row to the writer.
+func (w *RollingWriter) WriteRow(row Row) error {
+	if w.schema == nil {
+		return ErrRowGroupSchemaMissing
+	}
+
+	partition := w.partitionOf(row)
+	f := w.files[partition]
+	if f != nil && f.isExpired() {
+		// The file reached its maximum age while it was not receiving rows.
+		delete(w.files, partition)
+		if err := w.closeFile(f); err != nil {
+			return err
+		}
+		f = nil
+	}
+	if f == nil {
+		var err error
+		if f, err = w.openFile(partition); err != nil {
+			return err
+		}
+		w.files[partition] = f
+	}
+
+	if err := f.writer.WriteRow(row); err != nil {
+		return err
+	}
+	f.numRows++
+
+	if f.isFull(w.config) {
+		delete(w.files, partition)
+		return w.closeFile(f)
+	}
+	return nil
+}
+
+// WriteRows writes parquet rows to the writer, returning the number of rows
+// written.
+func (w *RollingWriter) WriteRows(rows []Row) (int, error) {
+	for i, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
+// Schema returns the schema of rows written by w.
+//
+// The returned value will be nil if no schema has yet been configured on w.
+func (w *RollingWriter) Schema() *Schema { return w.schema }
+
+// partitionOf returns the Hive-style path of the partition that row belongs to.
+func (w *RollingWriter) partitionOf(row Row) string {
+	if len(w.partitions) == 0 {
+		return ""
+	}
+	w.path.Reset()
+	for i, columnIndex := range w.partitions {
+		if i > 0 {
+			w.path.WriteByte('/')
+		}
+		w.path.WriteString(url.PathEscape(strings.Join(w.columns[columnIndex].path, ".")))
+		w.path.WriteByte('=')
+		w.path.WriteString(partitionValueOf(row, columnIndex))
+	}
+	return w.path.String()
+}
+
+func partitionValueOf(row Row, columnIndex int16) string {
+	for _, value := range row {
+		if value.Column() == int(columnIndex) {
+			if value.IsNull() {
+				break
+			}
+			return url.PathEscape(value.String())
+		}
+	}
+	return HivePartitionDefault
+}
+
+func (w *RollingWriter) openFile(partition string) (*rollingFile, error) {
+	output, err := w.createFile(partition)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &rollingFile{
+		partition: partition,
+		output:    output,
+	}
+	f.counter.Reset(output)
+
+	if w.config.MaxFileDuration != DefaultMaxFileDuration {
+		f.expire = w.afterFunc(w.config.MaxFileDuration, func() {
+			atomic.StoreInt32(&f.expired, 1)
+		})
+	}
+
+	if n := len(w.writers); n > 0 {
+		f.writer, w.writers = w.writers[n-1], w.writers[:n-1]
+		f.writer.Reset(&f.counter)
+	} else {
+		options := append(w.config.WriterOptions[:len(w.config.WriterOptions):len(w.config.WriterOptions)], w.schema)
+		f.writer = NewWriter(&f.counter, options...)
+	}
+	return f, nil
+}
+
+func (w *RollingWriter) closeFile(f *rollingFile) error {
+	if f.expire != nil {
+		f.expire()
+	}
+
+	err := f.writer.Close()
+	// The statistics of the columns are accumulated by the writer as it flushes
+	// pages, they must be collected before it is reused for another file.
+	columns := make([]RollingFileColumn, len(w.columns))
+	for i, c := range f.writer.writer.columns {
+		columns[i] = RollingFileColumn{
+			Path:      w.columns[i].path,
+			NullCount: c.stats.nullCount,
+			Min:       c.stats.min,
+			Max:       c.stats.max,
+		}
+	}
+	w.writers = append(w.writers, f.writer)
+	f.writer = nil
+
+	if closeErr := f.output.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("closing file of partition %q: %w", f.partition, err)
+	}
+
+	if w.config.FileClosed != nil {
+		w.config.FileClosed(RollingFile{
+			Partition: f.partition,
+			NumRows:   f.numRows,
+			Size:      f.counter.offset,
+			Columns:   columns,
+		})
+	}
+	return nil
+}
+
+type rollingFile struct {
+	partition string
+	output    io.WriteCloser
+	counter   offsetTrackingWriter
+	writer    *Writer
+	numRows   int64
+	// Set to one by the expire timer when the file reaches the maximum age
+	// configured on the rolling writer, which avoids reading the clock after
+	// each row.
+	expire  func() bool
+	expired int32
+}
+
+func afterFunc(d time.Duration, f func()) func() bool { return time.AfterFunc(d, f).Stop }
+
+func (f *rollingFile) isExpired() bool { return atomic.LoadInt32(&f.expired) != 0 }
+
+func (f *rollingFile) isFull(config *RollingWriterConfig) bool {
+	switch {
+	case f.numRows >= config.MaxFileRows:
+		return true
+	case f.isExpired():
+		return true
+	case config.MaxFileSize == DefaultMaxFileSize:
+		return false
+	default:
+		limit := config.MaxFileSize - f.counter.offset
+		return limit <= 0 || f.writer.writer.estimateRowGroupSize(limit) >= limit
+	}
+}
+
+var (
+	_ RowWriter      = (*RollingWriter)(nil)
+	_ RowBatchWriter = (*RollingWriter)(nil)
+)
@@ -0,0 +1,65 @@
+package parquet
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+type discardCloser struct{ io.Writer }
+
+func (discardCloser) Close() error { return nil }
+
+func TestRollingWriterMaxFileDuration(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	numFiles := 0
+	writer := NewRollingWriter(
+		func(string) (io.WriteCloser, error) {
+			numFiles++
+			return discardCloser{io.Discard}, nil
+		},
+		MaxFileDuration(time.Hour),
+	)
+
+	timers := []func(){}
+	writer.afterFunc = func(d time.Duration, f func()) func() bool {
+		if d != time.Hour {
+			t.Errorf("wrong duration of the file timer: want=%s got=%s", time.Hour, d)
+		}
+		timers = append(timers, f)
+		return func() bool { return true }
+	}
+
+	write := func(id int64) {
+		if err := writer.Write(&Row{ID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(0)
+	write(1)
+	if numFiles != 1 {
+		t.Fatalf("wrong number of files before expiration: want=1 got=%d", numFiles)
+	}
+
+	timers[len(timers)-1]()
+	write(2)
+	if numFiles != 2 {
+		t.Fatalf("wrong number of files after expiration: want=2 got=%d", numFiles)
+	}
+
+	timers[len(timers)-1]()
+	write(3)
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if numFiles != 3 {
+		t.Fatalf("wrong number of files: want=3 got=%d", numFiles)
+	}
+	if len(timers) != numFiles {
+		t.Fatalf("wrong number of timers started: want=%d got=%d", numFiles, len(timers))
+	}
+}
@@ -0,0 +1,211 @@
+package parquet_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+type rollingOutput struct {
+	partition string
+	buffer    bytes.Buffer
+	closed    bool
+}
+
+func (out *rollingOutput) Write(b []byte) (int, error) { return out.buffer.Write(b) }
+
+func (out *rollingOutput) Close() error { out.closed = true; return nil }
+
+func (out *rollingOutput) rows(t *testing.T) []filterRow {
+	reader := parquet.NewReader(bytes.NewReader(out.buffer.Bytes()))
+	rows := []filterRow{}
+	for {
+		row := filterRow{}
+		if err := reader.Read(&row); err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			return rows
+		}
+		rows = append(rows, row)
+	}
+}
+
+func writeRollingRows(t *testing.T, rows rows, options ...parquet.RollingWriterOption) ([]*rollingOutput, []parquet.RollingFile) {
+	outputs := []*rollingOutput{}
+	files := []parquet.RollingFile{}
+
+	writer := parquet.NewRollingWriter(
+		func(partition string) (io.WriteCloser, error) {
+			out := &rollingOutput{partition: partition}
+			outputs = append(outputs, out)
+			return out, nil
+		},
+		append(options, parquet.FileClosed(func(f parquet.RollingFile) {
+			files = append(files, f)
+		}))...,
+	)
+
+	for _, row := range rows {
+		if err := writer.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, out := range outputs {
+		if !out.closed {
+			t.Fatalf("output %d was not closed", i)
+		}
+	}
+	if len(files) != len(outputs) {
+		t.Fatalf("wrong number of closed files reported: want=%d got=%d", len(outputs), len(files))
+	}
+	return outputs, files
+}
+
+func numNullScores(rows []filterRow) (n int64) {
+	for _, row := range rows {
+		if row.Score == nil {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRollingWriterMaxFileRows(t *testing.T) {
+	rows := makeFilterRows(250)
+	outputs, files := writeRollingRows(t, makeRows(rows), parquet.MaxFileRows(100))
+
+	if len(outputs) != 3 {
+		t.Fatalf("wrong number of files: want=3 got=%d", len(outputs))
+	}
+
+	offset := 0
+	for i, out := range outputs {
+		found := out.rows(t)
+		if !reflect.DeepEqual(found, rows[offset:offset+len(found)]) {
+			t.Fatalf("rows of file %d mismatch", i)
+		}
+
+		f := files[i]
+		if f.NumRows != int64(len(found)) {
+			t.Errorf("wrong number of rows reported for file %d: want=%d got=%d", i, len(found), f.NumRows)
+		}
+		if f.Size != int64(out.buffer.Len()) {
+			t.Errorf("wrong size reported for file %d: want=%d got=%d", i, out.buffer.Len(), f.Size)
+		}
+		if min, max := f.Columns[0].Min.Int64(), f.Columns[0].Max.Int64(); min != int64(offset) || max != int64(offset+len(found)-1) {
+			t.Errorf("wrong bounds reported for file %d: min=%d max=%d", i, min, max)
+		}
+		if want, nullCount := numNullScores(found), f.Columns[2].NullCount; nullCount != want {
+			t.Errorf("wrong null count reported for file %d: want=%d got=%d", i, want, nullCount)
+		}
+		offset += len(found)
+	}
+
+	if offset != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), offset)
+	}
+}
+
+func TestRollingWriterPartitionBy(t *testing.T) {
+	rows := makeFilterRows(100)
+	outputs, files := writeRollingRows(t, makeRows(rows), parquet.PartitionBy("score"))
+
+	partitions := map[string]bool{}
+	for _, row := range rows {
+		partitions[scorePartition(row)] = true
+	}
+	if len(outputs) != len(partitions) {
+		t.Fatalf("wrong number of files: want=%d got=%d", len(partitions), len(outputs))
+	}
+	if !partitions["score="+parquet.HivePartitionDefault] {
+		t.Fatal("the rows do not have null values in the partition column")
+	}
+
+	numRows := 0
+	for i, out := range outputs {
+		if !partitions[out.partition] {
+			t.Fatalf("unexpected partition: %q", out.partition)
+		}
+		for _, row := range out.rows(t) {
+			if partition := scorePartition(row); partition != out.partition {
+				t.Fatalf("row %d of partition %q written to the wrong partition %q", row.ID, partition, out.partition)
+			}
+			numRows++
+		}
+		if !partitions[files[i].Partition] {
+			t.Errorf("unexpected partition reported for file %d: %q", i, files[i].Partition)
+		}
+	}
+
+	if numRows != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), numRows)
+	}
+}
+
+func scorePartition(row filterRow) string {
+	if row.Score == nil {
+		return "score=" + parquet.HivePartitionDefault
+	}
+	return fmt.Sprintf("score=%d", *row.Score)
+}
+
+func TestRollingWriterMaxFileSize(t *testing.T) {
+	rows := makeFilterRows(1000)
+	outputs, _ := writeRollingRows(t, makeRows(rows), parquet.MaxFileSize(2048))
+
+	if len(outputs) < 2 {
+		t.Fatalf("expected the rows to be written to multiple files, got %d", len(outputs))
+	}
+
+	numRows := 0
+	for _, out := range outputs {
+		numRows += len(out.rows(t))
+	}
+	if numRows != len(rows) {
+		t.Fatalf("wrong number of rows: want=%d got=%d", len(rows), numRows)
+	}
+}
+
+func TestRollingWriterStatisticsAcrossRowGroups(t *testing.T) {
+	rows := makeFilterRows(1000)
+	outputs, files := writeRollingRows(t, makeRows(rows),
+		parquet.FileWriterOptions(parquet.MaxRowsPerRowGroup(300), parquet.PageBufferSize(256)),
+	)
+
+	if len(outputs) != 1 {
+		t.Fatalf("wrong number of files: want=1 got=%d", len(outputs))
+	}
+	f := files[0]
+	if min, max := f.Columns[0].Min.Int64(), f.Columns[0].Max.Int64(); min != 0 || max != int64(len(rows)-1) {
+		t.Errorf("wrong bounds reported: min=%d max=%d", min, max)
+	}
+	if min, max := f.Columns[1].Min.String(), f.Columns[1].Max.String(); min != "a" || max != "z" {
+		t.Errorf("wrong bounds reported: min=%q max=%q", min, max)
+	}
+	if want, nullCount := numNullScores(rows), f.Columns[2].NullCount; nullCount != want {
+		t.Errorf("wrong null count reported: want=%d got=%d", want, nullCount)
+	}
+	if path := f.Columns[1].Path; len(path) != 1 || path[0] != "name" {
+		t.Errorf("wrong column path reported: %q", path)
+	}
+}
+
+func TestRollingWriterInvalidPartition(t *testing.T) {
+	writer := parquet.NewRollingWriter(func(string) (io.WriteCloser, error) {
+		t.Fatal("no file should be created")
+		return nil, nil
+	}, parquet.PartitionBy("missing"))
+
+	if err := writer.Write(&filterRow{}); err == nil {
+		t.Fatal("expected an error when partitioning by a missing column")
+	}
+}
@@ -6,6 +6,12 @@ import (
 	"reflect"
 )
 
+const (
+	// Number of rows read and written per batch when copying rows between
+	// readers and writers that support batch operations.
+	defaultRowBufferSize = 64
+)
+
 // Row represents a parquet row as a slice of values.
 //
 // Each value should embed a column index, repetition level, and definition
@@ -52,6 +58,17 @@ type RowReader interface {
 	ReadRow(Row) (Row, error)
 }
 
+// RowBatchReader is an extension of the RowReader interface implemented by
+// readers which can read multiple rows per call.
+//
+// The ReadRows method reads rows into the buffers passed as argument, each
+// row is truncated to zero length before values are appended to it, and
+// returns the number of rows read. The method returns io.EOF when no more rows
+// can be read, the number of rows returned may be non-zero in this case.
+type RowBatchReader interface {
+	ReadRows([]Row) (int, error)
+}
+
 // RowReaderAt reads parquet rows at specific indexes.
 type RowReaderAt interface {
 	ReadRowAt(Row, int64) (Row, error)
@@ -81,6 +98,15 @@ type RowWriter interface {
 	WriteRow(Row) error
 }
 
+// RowBatchWriter is an extension of the RowWriter interface implemented by
+// writers which can write multiple rows per call.
+//
+// The WriteRows method returns the number of rows written, which is less than
+// the number of rows passed as argument if an error occurred.
+type RowBatchWriter interface {
+	WriteRows([]Row) (int, error)
+}
+
 // RowWriterAt writes parquet rows at specific indexes.
 type RowWriterAt interface {
 	WriteRowAt(Row, int64) error
@@ -137,7 +163,8 @@ func (r *forwardRowSeeker) SeekToRow(rowIndex int64) error {
 //
 // As an optimization, the src argument may implement RowWriterTo to bypass
 // the default row copy logic and provide its own. The dst argument may also
-// implement RowReaderFrom for the same purpose.
+// implement RowReaderFrom for the same purpose. Otherwise, rows are copied in
+// batches if src implements RowBatchReader or dst implements RowBatchWriter.
 //
 // The function returns the number of rows written, or any error encountered
 // other than io.EOF.
@@ -178,6 +205,13 @@ func copyRows(dst RowWriter, src RowReader, buf []Value) (written int64, ret []V
 		return written, buf, err
 	}
 
+	_, srcBatch := src.(RowBatchReader)
+	_, dstBatch := dst.(RowBatchWriter)
+	if srcBatch || dstBatch {
+		written, err = copyRowBatches(dst, src)
+		return written, buf, err
+	}
+
 	if len(buf) == 0 {
 		buf = make([]Value, 42)
 	}
@@ -200,6 +234,65 @@ func copyRows(dst RowWriter, src RowReader, buf []Value) (written int64, ret []V
 	}
 }
 
+func copyRowBatches(dst RowWriter, src RowReader) (written int64, err error) {
+	rows := make([]Row, defaultRowBufferSize)
+	defer func() {
+		for _, row := range rows {
+			clearValues(row)
+		}
+	}()
+
+	for {
+		n, err := readRows(src, rows)
+
+		if n > 0 {
+			m, err := writeRows(dst, rows[:n])
+			written += int64(m)
+			if err != nil {
+				return written, err
+			}
+		}
+
+		if err != nil {
+			if err == io.EOF {
+				err = nil
+			}
+			return written, err
+		}
+	}
+}
+
+// readRows reads rows from r into the buffers passed as argument, using the
+// ReadRows method if r implements RowBatchReader, or calling ReadRow for each
+// row otherwise.
+func readRows(r RowReader, rows []Row) (int, error) {
+	if rb, ok := r.(RowBatchReader); ok {
+		return rb.ReadRows(rows)
+	}
+	for i := range rows {
+		row, err := r.ReadRow(rows[i][:0])
+		rows[i] = row
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
+// writeRows writes rows to w, using the WriteRows method if w implements
+// RowBatchWriter, or calling WriteRow for each row otherwise.
+func writeRows(w RowWriter, rows []Row) (int, error) {
+	if wb, ok := w.(RowBatchWriter); ok {
+		return wb.WriteRows(rows)
+	}
+	for i, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
 func sourceSchemaOf(r RowReader) *Schema {
 	if rrs, ok := r.(RowReaderWithSchema); ok {
 		return rrs.Schema()
@@ -263,19 +356,28 @@ func limitRowValues(values []Value, rowCount int) []Value {
 	} else {
 		var row Row
 		var limit int
-		for len(values) > 0 {
-			row, values = splitRowValues(values)
-			limit += len(row)
+		for tail := values; len(tail) > 0; limit += len(row) {
+			row, tail = splitRowValues(tail)
+			if row[0].repetitionLevel == 0 {
+				if rowCount == 0 {
+					break
+				}
+				rowCount--
+			}
 		}
 		values = values[:limit]
 	}
 	return values
 }
 
+// splitRowValues splits values at the start of the next row, which is the
+// first value after values[0] that has a repetition level of zero. The head
+// holds the values of the first row, or the continuation of a row if values
+// did not start at a row boundary.
 func splitRowValues(values []Value) (head, tail []Value) {
-	for i, v := range values {
-		if v.repetitionLevel == 0 {
-			return values[:i+1], values[i+1:]
+	for i := 1; i < len(values); i++ {
+		if values[i].repetitionLevel == 0 {
+			return values[:i], values[i:]
 		}
 	}
 	return values, nil
@@ -303,6 +405,16 @@ func forEachRepeatedRowOf(values []Value, do func(Row) error) error {
 // when reading CPU or memory profiles.
 // =============================================================================
 
+// Go values of dynamic types are represented by the following types when rows
+// are reconstructed into interface{} values: groups become maps of strings to
+// values, repeated columns become slices of values, and leaf columns use the
+// go type of their parquet type.
+var (
+	interfaceType    = reflect.TypeOf((*interface{})(nil)).Elem()
+	dynamicMapType   = reflect.TypeOf(map[string]interface{}(nil))
+	dynamicSliceType = reflect.TypeOf([]interface{}(nil))
+)
+
 type levels struct {
 	repetitionDepth int8
 	repetitionLevel int8
@@ -330,7 +442,20 @@ func deconstructFuncOf(columnIndex int16, node Node) (int16, deconstructFunc) {
 func deconstructFuncOfOptional(columnIndex int16, node Node) (int16, deconstructFunc) {
 	columnIndex, deconstruct := deconstructFuncOf(columnIndex, Required(node))
 	return columnIndex, func(row Row, levels levels, value reflect.Value) Row {
-		if value.IsValid() {
+		if value.Kind() == reflect.Interface {
+			// Dynamic values are null when the interface is nil, zero values
+			// held in the interface are not null.
+			if value = value.Elem(); value.Kind() == reflect.Ptr {
+				if value.IsNil() {
+					value = reflect.Value{}
+				} else {
+					value = value.Elem()
+				}
+			}
+			if value.IsValid() {
+				levels.definitionLevel++
+			}
+		} else if value.IsValid() {
 			if value.IsZero() {
 				value = reflect.Value{}
 			} else {
@@ -348,6 +473,9 @@ func deconstructFuncOfOptional(columnIndex int16, node Node) (int16, deconstruct
 func deconstructFuncOfRepeated(columnIndex int16, node Node) (int16, deconstructFunc) {
 	columnIndex, deconstruct := deconstructFuncOf(columnIndex, Required(node))
 	return columnIndex, func(row Row, levels levels, value reflect.Value) Row {
+		if value.Kind() == reflect.Interface {
+			value = value.Elem()
+		}
 		if !value.IsValid() || value.Len() == 0 {
 			return deconstruct(row, levels, reflect.Value{})
 		}
@@ -384,8 +512,12 @@ func deconstructFuncOfMap(columnIndex int16, node Node) (int16, deconstructFunc)
 	keyValueElem := keyValueType.Elem()
 	keyType := keyValueElem.Field(0).Type
 	valueType := keyValueElem.Field(1).Type
+	_, deconstructDynamic := deconstructFuncOf(columnIndex, Required(keyValue))
 	columnIndex, deconstruct := deconstructFuncOf(columnIndex, schemaOf(keyValueElem))
 	return columnIndex, func(row Row, levels levels, mapValue reflect.Value) Row {
+		if mapValue.Kind() == reflect.Interface {
+			mapValue = mapValue.Elem()
+		}
 		if !mapValue.IsValid() || mapValue.Len() == 0 {
 			return deconstruct(row, levels, reflect.Value{})
 		}
@@ -393,6 +525,20 @@ func deconstructFuncOfMap(columnIndex int16, node Node) (int16, deconstructFunc)
 		levels.repetitionDepth++
 		levels.definitionLevel++
 
+		if mapValue.Type().Elem() == interfaceType {
+			// The values of dynamic maps may not be convertible to the go type
+			// of the map node, each entry is deconstructed as a key/value group.
+			for _, key := range mapValue.MapKeys() {
+				entry := map[string]interface{}{
+					"key":   key.Interface(),
+					"value": mapValue.MapIndex(key).Interface(),
+				}
+				row = deconstructDynamic(row, levels, reflect.ValueOf(entry))
+				levels.repetitionLevel = levels.repetitionDepth
+			}
+			return row
+		}
+
 		elem := reflect.New(keyValueElem).Elem()
 		k := elem.Field(0)
 		v := elem.Field(1)
@@ -429,10 +575,19 @@ func deconstructFuncOfGroup(columnIndex int16, node Node) (int16, deconstructFun
 	return columnIndex, func(row Row, levels levels, value reflect.Value) Row {
 		valueAt := valueByIndex
 
-		if !value.IsValid() {
+		if value.Kind() == reflect.Interface {
+			value = value.Elem()
+		}
+
+		switch {
+		case !value.IsValid():
 			valueAt = func(value reflect.Value, _ int) reflect.Value {
 				return value
 			}
+		case value.Kind() == reflect.Map:
+			valueAt = func(value reflect.Value, index int) reflect.Value {
+				return value.MapIndex(reflect.ValueOf(names[index]).Convert(value.Type().Key()))
+			}
 		}
 
 		for i, f := range funcs {
@@ -448,13 +603,16 @@ func deconstructFuncOfLeaf(columnIndex int16, node Node) (int16, deconstructFunc
 	if columnIndex > MaxColumnIndex {
 		panic("row cannot be deconstructed because it has more than 127 columns")
 	}
-	kind := node.Type().Kind()
+	makeValue := makeValueFuncOf(node.Type())
 	valueColumnIndex := ^columnIndex
 	return columnIndex + 1, func(row Row, levels levels, value reflect.Value) Row {
 		v := Value{}
 
+		if value.Kind() == reflect.Interface {
+			value = value.Elem()
+		}
 		if value.IsValid() {
-			v = makeValue(kind, value)
+			v = makeValue(value)
 		}
 
 		v.repetitionLevel = levels.repetitionLevel
@@ -515,7 +673,12 @@ func reconstructFuncOfOptional(columnIndex int16, node Node) (int16, reconstruct
 func reconstructFuncOfRepeated(columnIndex int16, node Node) (int16, reconstructFunc) {
 	nextColumnIndex, reconstruct := reconstructFuncOf(columnIndex, Required(node))
 	rowLength := nextColumnIndex - columnIndex
-	return nextColumnIndex, func(value reflect.Value, lvls levels, row Row) (Row, error) {
+	var reconstructSlice reconstructFunc
+	reconstructSlice = func(value reflect.Value, lvls levels, row Row) (Row, error) {
+		if value.Kind() == reflect.Interface {
+			return reconstructInterface(value, dynamicSliceType, lvls, row, reconstructSlice)
+		}
+
 		t := value.Type()
 		c := value.Cap()
 		n := 0
@@ -542,6 +705,16 @@ func reconstructFuncOfRepeated(columnIndex int16, node Node) (int16, reconstruct
 			return row, err
 		})
 	}
+	return nextColumnIndex, reconstructSlice
+}
+
+// reconstructInterface reconstructs a value of type t from the row and assigns
+// it to the interface value.
+func reconstructInterface(value reflect.Value, t reflect.Type, levels levels, row Row, reconstruct reconstructFunc) (Row, error) {
+	v := reflect.New(t).Elem()
+	row, err := reconstruct(v, levels, row)
+	value.Set(v)
+	return row, err
 }
 
 func reconstructRepeated(columnIndex, rowLength int16, levels levels, row Row, do func(levels, Row) (Row, error)) (Row, error) {
@@ -588,9 +761,20 @@ func reconstructFuncOfMap(columnIndex int16, node Node) (int16, reconstructFunc)
 	keyValueType := keyValue.GoType()
 	keyValueElem := keyValueType.Elem()
 	keyValueZero := reflect.Zero(keyValueElem)
+	dynamicKeyType := keyValueElem.Field(0).Type
+	if !dynamicKeyType.Comparable() {
+		dynamicKeyType = reflect.TypeOf("")
+	}
+	dynamicType := reflect.MapOf(dynamicKeyType, interfaceType)
+	_, reconstructDynamic := reconstructFuncOf(columnIndex, Required(keyValue))
 	nextColumnIndex, reconstruct := reconstructFuncOf(columnIndex, schemaOf(keyValueElem))
 	rowLength := nextColumnIndex - columnIndex
-	return nextColumnIndex, func(mapValue reflect.Value, lvls levels, row Row) (Row, error) {
+	var reconstructMap reconstructFunc
+	reconstructMap = func(mapValue reflect.Value, lvls levels, row Row) (Row, error) {
+		if mapValue.Kind() == reflect.Interface {
+			return reconstructInterface(mapValue, dynamicType, lvls, row, reconstructMap)
+		}
+
 		t := mapValue.Type()
 		k := t.Key()
 		v := t.Elem()
@@ -599,6 +783,22 @@ func reconstructFuncOfMap(columnIndex int16, node Node) (int16, reconstructFunc)
 			mapValue.Set(reflect.MakeMap(t))
 		}
 
+		if v == interfaceType {
+			entry := reflect.New(dynamicMapType).Elem()
+			return reconstructRepeated(columnIndex, rowLength, lvls, row, func(levels levels, row Row) (Row, error) {
+				entry.Set(reflect.MakeMapWithSize(dynamicMapType, 2))
+				row, err := reconstructDynamic(entry, levels, row)
+				if err == nil {
+					key := entry.MapIndex(reflect.ValueOf("key")).Elem()
+					if key.Kind() == reflect.Slice {
+						key = reflect.ValueOf(string(key.Bytes()))
+					}
+					mapValue.SetMapIndex(key.Convert(k), entry.MapIndex(reflect.ValueOf("value")))
+				}
+				return row, err
+			})
+		}
+
 		elem := reflect.New(keyValueElem).Elem()
 		return reconstructRepeated(columnIndex, rowLength, lvls, row, func(levels levels, row Row) (Row, error) {
 			row, err := reconstruct(elem, levels, row)
@@ -609,6 +809,7 @@ func reconstructFuncOfMap(columnIndex int16, node Node) (int16, reconstructFunc)
 			return row, err
 		})
 	}
+	return nextColumnIndex, reconstructMap
 }
 
 //go:noinline
@@ -631,10 +832,18 @@ func reconstructFuncOfGroup(columnIndex int16, node Node) (int16, reconstructFun
 		valueByIndex = n.ValueByIndex
 	}
 
-	return columnIndex, func(value reflect.Value, levels levels, row Row) (Row, error) {
+	var reconstructGroup reconstructFunc
+	reconstructGroup = func(value reflect.Value, levels levels, row Row) (Row, error) {
 		var valueAt = valueByIndex
 		var err error
 
+		switch value.Kind() {
+		case reflect.Interface:
+			return reconstructInterface(value, dynamicMapType, levels, row, reconstructGroup)
+		case reflect.Map:
+			return reconstructGroupOfMap(value, levels, row, names, funcs)
+		}
+
 		for i, f := range funcs {
 			if row, err = f(valueAt(value, i), levels, row); err != nil {
 				err = fmt.Errorf("%s → %w", names[i], err)
@@ -644,14 +853,47 @@ func reconstructFuncOfGroup(columnIndex int16, node Node) (int16, reconstructFun
 
 		return row, err
 	}
+	return columnIndex, reconstructGroup
+}
+
+// reconstructGroupOfMap reconstructs the columns of a group into the entries of
+// a map, since map values are not addressable the columns are reconstructed
+// into a temporary value before being set in the map.
+func reconstructGroupOfMap(mapValue reflect.Value, levels levels, row Row, names []string, funcs []reconstructFunc) (Row, error) {
+	if mapValue.IsNil() {
+		mapValue.Set(reflect.MakeMapWithSize(mapValue.Type(), len(names)))
+	}
+
+	keyType := mapValue.Type().Key()
+	elem := reflect.New(mapValue.Type().Elem()).Elem()
+	elemZero := reflect.Zero(elem.Type())
+	var err error
+
+	for i, f := range funcs {
+		elem.Set(elemZero)
+		if row, err = f(elem, levels, row); err != nil {
+			return row, fmt.Errorf("%s → %w", names[i], err)
+		}
+		mapValue.SetMapIndex(reflect.ValueOf(names[i]).Convert(keyType), elem)
+	}
+
+	return row, nil
 }
 
 //go:noinline
 func reconstructFuncOfLeaf(columnIndex int16, node Node) (int16, reconstructFunc) {
+	assignValue := assignValueFuncOf(node.Type())
+	goType := goTypeOfLeaf(node)
 	return columnIndex + 1, func(value reflect.Value, _ levels, row Row) (Row, error) {
 		if !row.startsWith(columnIndex) {
 			return row, fmt.Errorf("no values found in parquet row for column %d", columnIndex)
 		}
+		if value.Kind() == reflect.Interface {
+			v := reflect.New(goType).Elem()
+			err := assignValue(v, row[0])
+			value.Set(v)
+			return row[1:], err
+		}
 		return row[1:], assignValue(value, row[0])
 	}
 }
@@ -243,10 +243,11 @@ func NewRowGroupRowReader(rowGroup RowGroup) Rows {
 }
 
 type rowGroupRowReader struct {
-	rowGroup RowGroup
-	schema   *Schema
-	columns  []columnChunkReader
-	seek     int64
+	rowGroup   RowGroup
+	schema     *Schema
+	columns    []columnChunkReader
+	seek       int64
+	contiguous bool
 }
 
 func (r *rowGroupRowReader) init(rowGroup RowGroup) error {
@@ -256,6 +257,7 @@ func (r *rowGroupRowReader) init(rowGroup RowGroup) error {
 
 	r.schema = rowGroup.Schema()
 	r.columns = make([]columnChunkReader, numColumns)
+	r.contiguous = rowValuesAreContiguous(r.schema)
 
 	for i := 0; i < numColumns; i++ {
 		r.columns[i].column = rowGroup.Column(i)
@@ -290,17 +292,88 @@ func (r *rowGroupRowReader) SeekToRow(rowIndex int64) error {
 	return nil
 }
 
-func (r *rowGroupRowReader) ReadRow(row Row) (Row, error) {
+func (r *rowGroupRowReader) open() error {
 	if r.rowGroup != nil {
 		err := r.init(r.rowGroup)
 		r.rowGroup = nil
 		if err != nil {
-			return row, err
+			return err
 		}
 	}
 	if r.schema == nil {
-		return row, io.EOF
+		return io.EOF
+	}
+	return nil
+}
+
+func (r *rowGroupRowReader) ReadRow(row Row) (Row, error) {
+	if err := r.open(); err != nil {
+		return row, err
+	}
+	return r.readRow(row)
+}
+
+func (r *rowGroupRowReader) ReadRows(rows []Row) (int, error) {
+	if err := r.open(); err != nil {
+		return 0, err
 	}
+	if !r.contiguous {
+		for i := range rows {
+			row, err := r.readRow(rows[i][:0])
+			rows[i] = row
+			if err != nil {
+				return i, err
+			}
+		}
+		return len(rows), nil
+	}
+
+	// The values of each row are grouped by column, so rows can be assembled
+	// one column at a time, each column appending its values to all the rows
+	// of the batch.
+	for i := range rows {
+		rows[i] = rows[i][:0]
+	}
+
+	numRows, err := len(rows), error(nil)
+	for i := range r.columns {
+		n, readErr := r.columns[i].readRows(rows[:numRows])
+		if readErr != nil && readErr != io.EOF {
+			return 0, readErr
+		}
+		if i == 0 {
+			numRows, err = n, readErr
+		} else if n != numRows {
+			return 0, fmt.Errorf("column %d has %d rows but the previous column(s) have %d rows", i, n, numRows)
+		}
+	}
+
+	if numRows < len(rows) {
+		err = io.EOF
+	}
+	return numRows, err
+}
+
+// rowValuesAreContiguous returns true if the values that rows of node hold
+// for each leaf column are contiguous, which is the case unless node contains
+// repeated groups of more than one column, where the values of the columns
+// are interleaved for each repetition of the group.
+func rowValuesAreContiguous(node Node) bool {
+	if isLeaf(node) {
+		return true
+	}
+	if node.Repeated() && numLeafColumnsOf(node) > 1 {
+		return false
+	}
+	for _, name := range node.ChildNames() {
+		if !rowValuesAreContiguous(node.ChildByName(name)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *rowGroupRowReader) readRow(row Row) (Row, error) {
 	n := len(row)
 	row, err := r.schema.readRow(row, 0, r.columns)
 	if err == nil && len(row) == n {
@@ -311,7 +384,7 @@ func (r *rowGroupRowReader) ReadRow(row Row) (Row, error) {
 
 func (r *rowGroupRowReader) WriteRowsTo(w RowWriter) (int64, error) {
 	if r.rowGroup == nil {
-		return CopyRows(w, struct{ RowReaderWithSchema }{r})
+		return CopyRows(w, rowGroupRows{r})
 	}
 	defer func() { r.rowGroup, r.seek = nil, 0 }()
 	rowGroup := r.rowGroup
@@ -333,9 +406,18 @@ func (r *rowGroupRowReader) WriteRowsTo(w RowWriter) (int64, error) {
 		return rowGroup.NumRows(), nil
 	}
 
-	return CopyRows(w, struct{ RowReaderWithSchema }{r})
+	return CopyRows(w, rowGroupRows{r})
 }
 
+// rowGroupRows hides the WriteRowsTo method of rowGroupRowReader, which would
+// otherwise cause CopyRows to call back into it, while retaining its ability
+// to read rows in batches.
+type rowGroupRows struct{ rows *rowGroupRowReader }
+
+func (r rowGroupRows) ReadRow(row Row) (Row, error)     { return r.rows.ReadRow(row) }
+func (r rowGroupRows) ReadRows(rows []Row) (int, error) { return r.rows.ReadRows(rows) }
+func (r rowGroupRows) Schema() *Schema                  { return r.rows.Schema() }
+
 func (r *rowGroupRowReader) writeRowsTo(w pageAndValueWriter, limit int64) (numRows int64, err error) {
 	for i := range r.columns {
 		n, err := r.columns[i].writeRowsTo(w, limit)
@@ -464,6 +546,7 @@ type emptyRowReader struct{ schema *Schema }
 
 func (r emptyRowReader) Schema() *Schema                      { return r.schema }
 func (r emptyRowReader) ReadRow(row Row) (Row, error)         { return row, io.EOF }
+func (r emptyRowReader) ReadRows([]Row) (int, error)          { return 0, io.EOF }
 func (r emptyRowReader) SeekToRow(int64) error                { return nil }
 func (r emptyRowReader) WriteRowsTo(RowWriter) (int64, error) { return 0, nil }
 
@@ -474,8 +557,13 @@ func (emptyPages) SeekToRow(int64) error   { return nil }
 
 var (
 	_ RowReaderWithSchema = (*rowGroupRowReader)(nil)
+	_ RowBatchReader      = (*rowGroupRowReader)(nil)
 	_ RowWriterTo         = (*rowGroupRowReader)(nil)
 
+	_ RowReaderWithSchema = rowGroupRows{}
+	_ RowBatchReader      = rowGroupRows{}
+
 	_ RowReaderWithSchema = emptyRowReader{}
+	_ RowBatchReader      = emptyRowReader{}
 	_ RowWriterTo         = emptyRowReader{}
 )
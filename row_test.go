@@ -1,6 +1,9 @@
 package parquet_test
 
 import (
+	"bytes"
+	"fmt"
+	"io"
 	"reflect"
 	"testing"
 
@@ -509,6 +512,264 @@ func assertEqualValues(t *testing.T, columnIndex int, want, got []parquet.Value)
 	}
 }
 
+type batchRecordingWriter struct {
+	schema    *parquet.Schema
+	rows      []parquet.Row
+	writeRow  int
+	writeRows int
+}
+
+func (w *batchRecordingWriter) Schema() *parquet.Schema { return w.schema }
+
+func (w *batchRecordingWriter) WriteRow(row parquet.Row) error {
+	w.writeRow++
+	w.rows = append(w.rows, cloneRow(row))
+	return nil
+}
+
+func (w *batchRecordingWriter) WriteRows(rows []parquet.Row) (int, error) {
+	w.writeRows++
+	for _, row := range rows {
+		w.rows = append(w.rows, cloneRow(row))
+	}
+	return len(rows), nil
+}
+
+func cloneRow(row parquet.Row) parquet.Row {
+	clone := make(parquet.Row, len(row))
+	for i, v := range row {
+		clone[i] = v.Clone()
+	}
+	return clone
+}
+
+func TestCopyRowsBatches(t *testing.T) {
+	f, err := createParquetFile(makeRows(makeFilterRows(250)), filterFileOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < f.NumRowGroups(); i++ {
+		rowGroup := f.RowGroup(i)
+		w := &batchRecordingWriter{schema: rowGroup.Schema()}
+
+		n, err := parquet.CopyRows(w, rowGroup.Rows())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != rowGroup.NumRows() {
+			t.Fatalf("wrong number of rows copied from row group %d: want=%d got=%d", i, rowGroup.NumRows(), n)
+		}
+		if w.writeRow != 0 {
+			t.Errorf("rows of row group %d were copied one at a time: %d calls to WriteRow", i, w.writeRow)
+		}
+		if w.writeRows == 0 {
+			t.Errorf("rows of row group %d were not copied in batches", i)
+		}
+
+		r := rowGroup.Rows()
+		for j, got := range w.rows {
+			want, err := r.ReadRow(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !want.Equal(got) {
+				t.Fatalf("row %d of row group %d mismatch:\nwant = %+v\ngot  = %+v", j, i, want, got)
+			}
+		}
+	}
+}
+
+func TestReadRowsWriteRows(t *testing.T) {
+	rows := makeFilterRows(250)
+	file, err := createParquetFile(makeRows(rows), filterFileOptions...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewReader(file)
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, reader.Schema())
+
+	batch := make([]parquet.Row, 64)
+	numRows := 0
+	for {
+		n, err := reader.ReadRows(batch)
+		numRows += n
+		if _, err := writer.WriteRows(batch[:n]); err != nil {
+			t.Fatal(err)
+		}
+		if err != nil {
+			if err != io.EOF {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+	if numRows != len(rows) {
+		t.Fatalf("wrong number of rows read: want=%d got=%d", len(rows), numRows)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader = parquet.NewReader(bytes.NewReader(buffer.Bytes()))
+	for i := range rows {
+		row := filterRow{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatalf("reading row %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(row, rows[i]) {
+			t.Fatalf("row %d mismatch:\nwant = %+v\ngot  = %+v", i, rows[i], row)
+		}
+	}
+}
+
+func TestReadRowsWriteRowsNested(t *testing.T) {
+	type Nested struct {
+		ID       int64    `parquet:"id"`
+		Name     *string  `parquet:"name,optional"`
+		Tags     []string `parquet:"tags"`
+		Contacts []Contact
+	}
+
+	rows := make([]Nested, 1000)
+	for i := range rows {
+		rows[i].ID = int64(i)
+		if i%3 != 0 {
+			name := fmt.Sprintf("name-%d", i)
+			rows[i].Name = &name
+		}
+		for j := 0; j < i%4; j++ {
+			rows[i].Tags = append(rows[i].Tags, fmt.Sprintf("tag-%d", j))
+		}
+		for j := 0; j < i%3; j++ {
+			rows[i].Contacts = append(rows[i].Contacts, Contact{Name: fmt.Sprintf("contact-%d", j)})
+		}
+	}
+
+	type Flat struct {
+		ID   int64    `parquet:"id"`
+		Name *string  `parquet:"name,optional"`
+		Tags []string `parquet:"tags"`
+	}
+
+	for _, test := range []struct {
+		scenario string
+		schema   *parquet.Schema
+		row      func(int) interface{}
+	}{
+		{
+			scenario: "contiguous",
+			schema:   parquet.SchemaOf(Flat{}),
+			row:      func(i int) interface{} { return &Flat{ID: rows[i].ID, Name: rows[i].Name, Tags: rows[i].Tags} },
+		},
+		{
+			scenario: "interleaved",
+			schema:   parquet.SchemaOf(Nested{}),
+			row:      func(i int) interface{} { return &rows[i] },
+		},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			writer := parquet.NewWriter(buffer, test.schema, parquet.MaxRowsPerRowGroup(300))
+			for i := range rows {
+				if err := writer.Write(test.row(i)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			output := new(bytes.Buffer)
+			copied := parquet.NewWriter(output, f.RowGroup(0).Schema(), parquet.MaxRowsPerRowGroup(300))
+
+			for i := 0; i < f.NumRowGroups(); i++ {
+				want := f.RowGroup(i).Rows()
+				got := f.RowGroup(i).Rows()
+				batch := make([]parquet.Row, 7)
+				numRows := int64(0)
+
+				for {
+					n, err := got.(parquet.RowBatchReader).ReadRows(batch)
+					for _, row := range batch[:n] {
+						expect, err := want.ReadRow(nil)
+						if err != nil {
+							t.Fatal(err)
+						}
+						if !expect.Equal(row) {
+							t.Fatalf("row %d of row group %d mismatch:\nwant = %+v\ngot  = %+v", numRows, i, expect, row)
+						}
+						numRows++
+					}
+					if _, err := copied.WriteRows(batch[:n]); err != nil {
+						t.Fatal(err)
+					}
+					if err != nil {
+						if err != io.EOF {
+							t.Fatal(err)
+						}
+						break
+					}
+				}
+
+				if numRows != f.RowGroup(i).NumRows() {
+					t.Fatalf("wrong number of rows read from row group %d: want=%d got=%d", i, f.RowGroup(i).NumRows(), numRows)
+				}
+			}
+
+			if err := copied.Close(); err != nil {
+				t.Fatal(err)
+			}
+			g, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := g.NumRowGroups(); n != 4 {
+				t.Fatalf("wrong number of row groups written: want=4 got=%d", n)
+			}
+
+			want := parquet.NewReader(bytes.NewReader(buffer.Bytes()))
+			got := parquet.NewReader(g)
+			for i := range rows {
+				expect, err := want.ReadRow(nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				row, err := got.ReadRow(nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !expect.Equal(row) {
+					t.Fatalf("row %d mismatch:\nwant = %+v\ngot  = %+v", i, expect, row)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteRowsTooFewValues(t *testing.T) {
+	schema := parquet.NewSchema("test", parquet.Group{
+		"a": parquet.Int(64),
+		"b": parquet.Int(64),
+	})
+	writer := parquet.NewWriter(new(bytes.Buffer), schema)
+
+	rows := []parquet.Row{
+		{parquet.ValueOf(int64(1)).Level(0, 0, 0), parquet.ValueOf(int64(2)).Level(0, 0, 1)},
+		{parquet.ValueOf(int64(3)).Level(0, 0, 0)},
+	}
+	if n, err := writer.WriteRows(rows); err == nil {
+		t.Fatalf("writing rows with missing values did not fail: n=%d", n)
+	}
+}
+
 func BenchmarkDeconstruct(b *testing.B) {
 	row := &AddressBook{
 		Owner: "Julien Le Dem",
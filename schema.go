@@ -39,19 +39,24 @@ type Schema struct {
 //
 // The following options are also supported in the "parquet" struct tag:
 //
-//	optional | make the parquet column optional
-//	snappy   | sets the parquet column compression codec to snappy
-//	gzip     | sets the parquet column compression codec to gzip
-//	brotli   | sets the parquet column compression codec to brotli
-//	lz4      | sets the parquet column compression codec to lz4
-//	zstd     | sets the parquet column compression codec to zstd
-//	plain    | enables the plain encoding (no-op default)
-//	dict     | enables dictionary encoding on the parquet column
-//	delta    | enables delta encoding on the parquet column
-//	list     | for slice types, use the parquet LIST logical type
-//	enum     | for string types, use the parquet ENUM logical type
-//	uuid     | for string and [16]byte types, use the parquet UUID logical type
-//	decimal  | for int32 and int64 types, use the parquet DECIMAL logical type
+//	optional  | make the parquet column optional
+//	snappy    | sets the parquet column compression codec to snappy
+//	gzip      | sets the parquet column compression codec to gzip
+//	brotli    | sets the parquet column compression codec to brotli
+//	lz4       | sets the parquet column compression codec to lz4
+//	zstd      | sets the parquet column compression codec to zstd
+//	plain     | enables the plain encoding (no-op default)
+//	dict      | enables dictionary encoding on the parquet column
+//	delta     | enables delta encoding on the parquet column
+//	list      | for slice types, use the parquet LIST logical type
+//	enum      | for string types, use the parquet ENUM logical type
+//	uuid      | for string and [16]byte types, use the parquet UUID logical type
+//	decimal   | for integer, byte array and math/big types, use the parquet DECIMAL logical type
+//	timestamp | for time.Time and int64 types, use the parquet TIMESTAMP logical type
+//	date      | for time.Time and int32 types, use the parquet DATE logical type
+//	time      | for time.Duration types, use the parquet TIME logical type
+//	float16   | for float32, []float32 and [2]byte types, use the parquet FLOAT16 logical type
+//	id=N      | sets the field ID of the parquet column to the positive integer N
 //
 // The decimal tag must be followed by two integer parameters, the first integer
 // representing the scale and the second the precision; for example:
@@ -60,6 +65,53 @@ type Schema struct {
 //		Cost int64 `parquet:"cost,decimal(0:3)"`
 //	}
 //
+// Integer fields hold the unscaled value of the decimal, byte array fields hold
+// it as a big-endian two's complement integer. Fields of type big.Int also hold
+// the unscaled value, while fields of type big.Rat hold the actual value of the
+// decimal, truncated to the scale when written. For math/big types, the column
+// uses the smallest physical type that can represent the precision:
+//
+//	type Item struct {
+//		Price *big.Rat `parquet:"price,decimal(2:38),optional"`
+//	}
+//
+// Fields of type time.Time use the TIMESTAMP logical type in microseconds by
+// default, which can represent the zero value of time.Time. The timestamp and
+// time tags accept an optional unit (millisecond, microsecond or nanosecond),
+// which may be followed by ":local" to declare that the values are not adjusted
+// to UTC. The unit defaults to microseconds for timestamps and nanoseconds for
+// times; for example:
+//
+//	type Event struct {
+//		Time     time.Time     `parquet:"time,timestamp(millisecond)"`
+//		Day      time.Time     `parquet:"day,date"`
+//		Local    time.Time     `parquet:"local,timestamp(microsecond:local)"`
+//		Duration time.Duration `parquet:"duration,time(microsecond)"`
+//	}
+//
+// Local timestamps record the date and clock of the time.Time values in their
+// own time zone, and are read back with the same date and clock in UTC so the
+// values do not depend on the time zone of the reading program. Programs which
+// need local timestamps in a specific location rebuild them with time.Date.
+// Timestamps in nanoseconds can only represent the years 1678 to 2262, writers
+// return ErrTimestampOverflow for time.Time values outside of this range.
+// Fields of type time.Duration without the time tag are stored as INT64 values
+// in nanoseconds.
+//
+// The float16 tag stores float32 values as half-precision floating point
+// numbers, rounded to the nearest representable value:
+//
+//	type Embedding struct {
+//		Values []float32 `parquet:"values,float16"`
+//	}
+//
+// Field IDs allow columns to be matched by ConvertByFieldID after they were
+// renamed; for example:
+//
+//	type Item struct {
+//		Cost int64 `parquet:"cost,id=1"`
+//	}
+//
 // Invalid combination of struct tags and Go types, or repeating options will
 // cause the function to panic.
 //
@@ -166,6 +218,9 @@ func (s *Schema) Repeated() bool { return s.root.Repeated() }
 // Required returns true since the root node of a parquet schema is always required.
 func (s *Schema) Required() bool { return s.root.Required() }
 
+// ID returns the field ID of the root node of s.
+func (s *Schema) ID() int { return fieldIDOf(s.root) }
+
 // NumChildren returns the number of child nodes of s.
 func (s *Schema) NumChildren() int { return s.root.NumChildren() }
 
@@ -191,6 +246,9 @@ func (s *Schema) ValueByName(base reflect.Value, name string) reflect.Value {
 
 // Deconstruct deconstructs a Go value and appends it to a row.
 //
+// The value may also be a map of column names to values, in which case nested
+// groups are represented by maps and null values by nil interfaces.
+//
 // The method panics is the structure of the go value does not match the
 // parquet schema.
 func (s *Schema) Deconstruct(row Row, value interface{}) Row {
@@ -208,11 +266,38 @@ func (s *Schema) Deconstruct(row Row, value interface{}) Row {
 	return row
 }
 
+// valueError is the type of panics raised when a go value cannot be converted
+// to a parquet value of the schema, which writers recover into errors.
+type valueError struct{ err error }
+
+// deconstructRow is like Schema.Deconstruct but returns the errors of values
+// which cannot be converted to parquet values instead of panicking.
+func deconstructRow(schema *Schema, row Row, value interface{}) (values Row, err error) {
+	values = row[:0]
+	defer recoverValueError(&err)
+	return schema.Deconstruct(row, value), nil
+}
+
+// recoverValueError is deferred by functions deconstructing go values to turn
+// panics raised with a *valueError into the error returned by the function.
+func recoverValueError(err *error) {
+	switch e := recover().(type) {
+	case nil:
+	case *valueError:
+		*err = e.err
+	default:
+		panic(e)
+	}
+}
+
 // Reconstruct reconstructs a Go value from a row.
 //
 // The go value passed as first argument must be a non-nil pointer for the
 // row to be decoded into.
 //
+// When the pointer refers to a map or an interface{}, the row is reconstructed
+// into dynamic values as described in Reader.ReadMap.
+//
 // The method panics if the structure of the go value and parquet row do not
 // match.
 func (s *Schema) Reconstruct(value interface{}, row Row) error {
@@ -422,6 +507,7 @@ func makeStructField(f reflect.StructField) structField {
 		list      bool
 		encodings []encoding.Encoding
 		codecs    []compress.Codec
+		fieldID   int
 	)
 
 	setNode := func(node Node) {
@@ -559,15 +645,92 @@ func makeStructField(f reflect.StructField) structField {
 				switch f.Type.Kind() {
 				case reflect.Int32:
 					baseType = Int32Type
-				case reflect.Int64:
+				case reflect.Int64, reflect.Int:
 					baseType = Int64Type
+				case reflect.Array:
+					if f.Type.Elem().Kind() != reflect.Uint8 {
+						throwInvalidFieldTag(f, option)
+					}
+					baseType = FixedLenByteArrayType(f.Type.Len())
+				case reflect.Slice:
+					if f.Type.Elem().Kind() != reflect.Uint8 {
+						throwInvalidFieldTag(f, option)
+					}
+					baseType = ByteArrayType
 				default:
-					throwInvalidFieldTag(f, option)
+					if !isDecimalGoType(f.Type) {
+						throwInvalidFieldTag(f, option)
+					}
+					baseType = decimalTypeOf(precision)
+				}
+				if max := maxDecimalPrecision(baseType); precision < 1 || (max != 0 && precision > max) || scale < 0 || scale > precision {
+					throwInvalidFieldTag(f, option+args)
 				}
 				setNode(Decimal(scale, precision, baseType))
 
+			case "timestamp":
+				// Timestamps default to microseconds like fields of type
+				// time.Time without tags, which can represent the zero value.
+				unit, local, err := parseTimeArgs(args, Microsecond)
+				if err != nil {
+					throwInvalidFieldTag(f, option+args)
+				}
+				switch elemTypeOf(f.Type) {
+				case timeTimeType, reflect.TypeOf(int64(0)):
+				default:
+					throwInvalidFieldTag(f, option)
+				}
+				setNode(Leaf(&timestampType{IsAdjustedToUTC: !local, Unit: unit.TimeUnit()}))
+
+			case "date":
+				switch elemTypeOf(f.Type) {
+				case timeTimeType, reflect.TypeOf(int32(0)):
+				default:
+					throwInvalidFieldTag(f, option)
+				}
+				setNode(Date())
+
+			case "time":
+				unit, local, err := parseTimeArgs(args, Nanosecond)
+				if err != nil {
+					throwInvalidFieldTag(f, option+args)
+				}
+				switch elemTypeOf(f.Type) {
+				case timeDurationType:
+				default:
+					throwInvalidFieldTag(f, option)
+				}
+				setNode(Leaf(&timeType{IsAdjustedToUTC: !local, Unit: unit.TimeUnit()}))
+
+			case "float16":
+				node := Float16()
+				t := elemTypeOf(f.Type)
+				if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Float32 {
+					node, t = Repeated(node), t.Elem()
+				}
+				switch t.Kind() {
+				case reflect.Float32:
+				case reflect.Array:
+					if t.Elem().Kind() != reflect.Uint8 || t.Len() != float16Length {
+						throwInvalidFieldTag(f, option)
+					}
+				default:
+					throwInvalidFieldTag(f, option)
+				}
+				setNode(node)
+
 			default:
-				throwUnknownFieldTag(f, option)
+				if !strings.HasPrefix(option, "id=") {
+					throwUnknownFieldTag(f, option)
+				}
+				if fieldID != 0 {
+					throwInvalidStructField("struct field has multiple declaration of the id tag", f)
+				}
+				id, err := strconv.ParseInt(strings.TrimPrefix(option, "id="), 10, 32)
+				if err != nil || id <= 0 {
+					throwInvalidFieldTag(f, option)
+				}
+				fieldID = int(id)
 			}
 		}
 	}
@@ -587,6 +750,10 @@ func makeStructField(f reflect.StructField) structField {
 		field.Node = Optional(field.Node)
 	}
 
+	if fieldID != 0 {
+		field.Node = FieldID(field.Node, fieldID)
+	}
+
 	return field
 }
 
@@ -596,6 +763,10 @@ func nodeOf(t reflect.Type) Node {
 		return Leaf(Int96Type)
 	case reflect.TypeOf(uuid.UUID{}):
 		return UUID()
+	case timeTimeType:
+		return &goNode{wrappedNode: wrap(Timestamp(Microsecond)), gotype: t}
+	case intervalGoType:
+		return &goNode{wrappedNode: wrap(Leaf(IntervalType)), gotype: t}
 	}
 
 	var n Node
@@ -653,6 +824,14 @@ func nodeOf(t reflect.Type) Node {
 	return &goNode{wrappedNode: wrap(n), gotype: t}
 }
 
+// elemTypeOf returns the type that t points to, or t if it is not a pointer.
+func elemTypeOf(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
 func split(s string) (head, tail string) {
 	if i := strings.IndexByte(s, ','); i < 0 {
 		head = s
@@ -0,0 +1,561 @@
+package parquet
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/segmentio/parquet-go/format"
+)
+
+// SchemaFromAvro converts the Avro schema in data, which must be the JSON
+// definition of a record, to a parquet schema named after the record.
+//
+// Avro types are mapped to parquet nodes as follows:
+//
+//	boolean          | BOOLEAN
+//	int, long        | INT(32,true), INT(64,true)
+//	float, double    | FLOAT, DOUBLE
+//	bytes, fixed     | BYTE_ARRAY, FIXED_LEN_BYTE_ARRAY
+//	string, enum     | STRING, ENUM
+//	record           | group
+//	array            | LIST
+//	map              | MAP with STRING keys
+//	["null", T]      | optional T
+//
+// The logical types decimal, uuid, date, time-millis, time-micros,
+// timestamp-millis, timestamp-micros, timestamp-nanos, their local-timestamp
+// variants, and duration are converted to the equivalent parquet logical
+// types. Following the Avro specification, unknown or invalid logical types
+// are ignored and the underlying type is used instead.
+//
+// The fields of the groups converted from records retain the order in which
+// they are declared, SchemaToAvro reproduces the same order.
+//
+// Unions of more than one non-null type and recursive types cannot be
+// represented in parquet and cause the function to return an error. Field IDs
+// may be declared with the "field-id" property of record fields.
+func SchemaFromAvro(data []byte) (*Schema, error) {
+	var schema interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("decoding avro schema: %w", err)
+	}
+	record, _ := schema.(map[string]interface{})
+	if record == nil || record["type"] != "record" {
+		return nil, fmt.Errorf("avro schema must be a record")
+	}
+	c := &avroImporter{named: make(map[string]Node), defining: make(map[string]bool)}
+	root, err := c.nodeOf(schema, "")
+	if err != nil {
+		return nil, err
+	}
+	name, _ := record["name"].(string)
+	return NewSchema(name, root), nil
+}
+
+type avroImporter struct {
+	named    map[string]Node
+	defining map[string]bool
+}
+
+func (c *avroImporter) nodeOf(schema interface{}, namespace string) (Node, error) {
+	switch s := schema.(type) {
+	case string:
+		return c.primitiveNodeOf(s, nil, namespace)
+	case []interface{}:
+		return c.unionNodeOf(s, namespace)
+	case map[string]interface{}:
+		typ, ok := s["type"].(string)
+		if !ok {
+			return c.nodeOf(s["type"], namespace)
+		}
+		switch typ {
+		case "record", "error":
+			return c.recordNodeOf(s, namespace)
+		case "enum":
+			return c.define(s, namespace, func(string) (Node, error) { return Enum(), nil })
+		case "fixed":
+			return c.define(s, namespace, func(string) (Node, error) { return c.fixedNodeOf(s) })
+		case "array":
+			items, err := c.nodeOf(s["items"], namespace)
+			if err != nil {
+				return nil, err
+			}
+			return List(items), nil
+		case "map":
+			values, err := c.nodeOf(s["values"], namespace)
+			if err != nil {
+				return nil, err
+			}
+			return Map(String(), values), nil
+		default:
+			return c.primitiveNodeOf(typ, s, namespace)
+		}
+	default:
+		return nil, fmt.Errorf("invalid avro schema: %v", schema)
+	}
+}
+
+func (c *avroImporter) primitiveNodeOf(typ string, props map[string]interface{}, namespace string) (Node, error) {
+	logicalType, _ := props["logicalType"].(string)
+
+	switch typ {
+	case "null":
+		return nil, fmt.Errorf("avro null type is only supported in unions with another type")
+	case "boolean":
+		return Leaf(BooleanType), nil
+	case "int":
+		switch logicalType {
+		case "date":
+			return Date(), nil
+		case "time-millis":
+			return Time(Millisecond), nil
+		case "decimal":
+			if node := avroDecimalOf(props, Int32Type); node != nil {
+				return node, nil
+			}
+		}
+		return Int(32), nil
+	case "long":
+		switch logicalType {
+		case "time-micros":
+			return Time(Microsecond), nil
+		case "decimal":
+			if node := avroDecimalOf(props, Int64Type); node != nil {
+				return node, nil
+			}
+		}
+		if unit, local, ok := avroTimestampOf(logicalType); ok {
+			return Leaf(&timestampType{IsAdjustedToUTC: !local, Unit: unit.TimeUnit()}), nil
+		}
+		return Int(64), nil
+	case "float":
+		return Leaf(FloatType), nil
+	case "double":
+		return Leaf(DoubleType), nil
+	case "bytes":
+		if logicalType == "decimal" {
+			if node := avroDecimalOf(props, ByteArrayType); node != nil {
+				return node, nil
+			}
+		}
+		return Leaf(ByteArrayType), nil
+	case "string":
+		if logicalType == "uuid" {
+			return UUID(), nil
+		}
+		return String(), nil
+	}
+
+	name := avroFullName(typ, namespace)
+	if c.defining[name] || c.defining[typ] {
+		return nil, fmt.Errorf("recursive avro type %q cannot be represented in parquet", typ)
+	}
+	if node, ok := c.named[name]; ok {
+		return node, nil
+	}
+	if node, ok := c.named[typ]; ok {
+		return node, nil
+	}
+	return nil, fmt.Errorf("unknown avro type %q", typ)
+}
+
+func (c *avroImporter) unionNodeOf(union []interface{}, namespace string) (Node, error) {
+	var types []interface{}
+	for _, t := range union {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	if len(types) != 1 {
+		return nil, fmt.Errorf("avro unions must contain exactly one type other than null: %v", union)
+	}
+	node, err := c.nodeOf(types[0], namespace)
+	if err != nil {
+		return nil, err
+	}
+	if len(union) > 1 {
+		node = Optional(node)
+	}
+	return node, nil
+}
+
+func (c *avroImporter) recordNodeOf(record map[string]interface{}, namespace string) (Node, error) {
+	return c.define(record, namespace, func(name string) (Node, error) {
+		fields, _ := record["fields"].([]interface{})
+		group := new(orderedGroup)
+
+		// Named types declared in the fields of a record default to the
+		// namespace of the record.
+		if i := strings.LastIndexByte(name, '.'); i >= 0 {
+			namespace = name[:i]
+		} else {
+			namespace = ""
+		}
+
+		for _, f := range fields {
+			field, _ := f.(map[string]interface{})
+			fieldName, _ := field["name"].(string)
+			if fieldName == "" {
+				return nil, fmt.Errorf("avro record %q has a field with no name", name)
+			}
+			if group.ChildByName(fieldName) != nil {
+				return nil, fmt.Errorf("avro record %q has multiple fields named %q", name, fieldName)
+			}
+			node, err := c.nodeOf(field["type"], namespace)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", fieldName, err)
+			}
+			if id, ok := field["field-id"].(float64); ok && id > 0 {
+				node = FieldID(node, int(id))
+			}
+			group.add(fieldName, node)
+		}
+
+		return group, nil
+	})
+}
+
+func (c *avroImporter) fixedNodeOf(fixed map[string]interface{}) (Node, error) {
+	size, ok := fixed["size"].(float64)
+	if !ok || size < 1 || size != math.Trunc(size) {
+		return nil, fmt.Errorf("invalid size of avro fixed type %v: %v", fixed["name"], fixed["size"])
+	}
+	typ := FixedLenByteArrayType(int(size))
+
+	switch fixed["logicalType"] {
+	case "decimal":
+		if node := avroDecimalOf(fixed, typ); node != nil {
+			return node, nil
+		}
+	case "duration":
+		if size == 12 {
+			return Leaf(IntervalType), nil
+		}
+	case "uuid":
+		if size == 16 {
+			return UUID(), nil
+		}
+	}
+	return Leaf(typ), nil
+}
+
+// define converts the avro named type declared by schema with the function f,
+// and registers the result so later references to the type name are resolved.
+func (c *avroImporter) define(schema map[string]interface{}, namespace string, f func(name string) (Node, error)) (Node, error) {
+	name, _ := schema["name"].(string)
+	if name == "" {
+		return nil, fmt.Errorf("avro %v type has no name", schema["type"])
+	}
+	if ns, ok := schema["namespace"].(string); ok {
+		namespace = ns
+	}
+	name = avroFullName(name, namespace)
+	if _, exists := c.named[name]; exists {
+		return nil, fmt.Errorf("avro type %q is defined multiple times", name)
+	}
+
+	c.defining[name] = true
+	node, err := f(name)
+	delete(c.defining, name)
+	if err != nil {
+		return nil, err
+	}
+	c.named[name] = node
+	return node, nil
+}
+
+func avroFullName(name, namespace string) string {
+	if namespace == "" || strings.IndexByte(name, '.') >= 0 {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// avroDecimalOf returns a DECIMAL node of the given physical type, or nil if
+// the precision and scale of the avro decimal are invalid for the type.
+func avroDecimalOf(props map[string]interface{}, typ Type) Node {
+	precision, _ := props["precision"].(float64)
+	scale, _ := props["scale"].(float64)
+	if precision < 1 || scale < 0 || scale > precision || precision != math.Trunc(precision) || scale != math.Trunc(scale) {
+		return nil
+	}
+	if max := maxDecimalPrecision(typ); max != 0 && int(precision) > max {
+		return nil
+	}
+	return Decimal(int(scale), int(precision), typ)
+}
+
+func avroTimestampOf(logicalType string) (unit TimeUnit, local, ok bool) {
+	if strings.HasPrefix(logicalType, "local-") {
+		logicalType, local = logicalType[6:], true
+	}
+	switch logicalType {
+	case "timestamp-millis":
+		return Millisecond, local, true
+	case "timestamp-micros":
+		return Microsecond, local, true
+	case "timestamp-nanos":
+		return Nanosecond, local, true
+	default:
+		return nil, false, false
+	}
+}
+
+// SchemaToAvro converts a parquet schema to the JSON definition of an Avro
+// record, performing the inverse of the mapping applied by SchemaFromAvro.
+//
+// Groups become records named after their field, in a namespace made of the
+// path to the group, and optional columns become unions of null and the
+// column type, with a null default value. Repeated columns which are not
+// annotated with the LIST logical type are also converted to arrays.
+//
+// ENUM columns are converted to strings since the parquet schema does not
+// carry the enum symbols, and unsigned 32 bits integers are widened to long.
+// The function returns an error if the schema contains INT96 columns, MAP
+// columns with keys that are not strings, or names which are not valid in
+// Avro.
+func SchemaToAvro(schema *Schema) ([]byte, error) {
+	name := schema.Name()
+	if name == "" {
+		name = "root"
+	}
+	if !isAvroName(name) {
+		return nil, fmt.Errorf("invalid avro record name: %q", name)
+	}
+	record, err := avroRecordOf(schema, columnPath{name})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(record)
+}
+
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Fields    []avroField `json:"fields"`
+}
+
+type avroField struct {
+	Name    string          `json:"name"`
+	Type    interface{}     `json:"type"`
+	Default json.RawMessage `json:"default,omitempty"`
+	FieldID int             `json:"field-id,omitempty"`
+}
+
+type avroArray struct {
+	Type  string      `json:"type"`
+	Items interface{} `json:"items"`
+}
+
+type avroMap struct {
+	Type   string      `json:"type"`
+	Values interface{} `json:"values"`
+}
+
+type avroFixed struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	Size        int    `json:"size"`
+	LogicalType string `json:"logicalType,omitempty"`
+	Precision   int    `json:"precision,omitempty"`
+	Scale       int    `json:"scale,omitempty"`
+}
+
+type avroPrimitive struct {
+	Type        string `json:"type"`
+	LogicalType string `json:"logicalType"`
+	Precision   int    `json:"precision,omitempty"`
+	Scale       int    `json:"scale,omitempty"`
+}
+
+func avroRecordOf(node Node, path columnPath) (*avroRecord, error) {
+	record := &avroRecord{
+		Type:      "record",
+		Name:      path[len(path)-1],
+		Namespace: strings.Join(path[:len(path)-1], "."),
+		Fields:    make([]avroField, 0, node.NumChildren()),
+	}
+
+	for _, name := range node.ChildNames() {
+		if !isAvroName(name) {
+			return nil, fmt.Errorf("invalid avro field name: %q", path[1:].append(name))
+		}
+		child := node.ChildByName(name)
+		typ, err := avroFieldTypeOf(child, path.append(name))
+		if err != nil {
+			return nil, err
+		}
+		field := avroField{Name: name, Type: typ, FieldID: fieldIDOf(child)}
+		if child.Optional() {
+			field.Default = json.RawMessage("null")
+		}
+		record.Fields = append(record.Fields, field)
+	}
+
+	return record, nil
+}
+
+func avroFieldTypeOf(node Node, path columnPath) (interface{}, error) {
+	typ, err := avroTypeOf(node, path)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case node.Optional():
+		return []interface{}{"null", typ}, nil
+	case node.Repeated():
+		return &avroArray{Type: "array", Items: typ}, nil
+	default:
+		return typ, nil
+	}
+}
+
+func avroTypeOf(node Node, path columnPath) (interface{}, error) {
+	switch {
+	case isLeaf(node):
+		return avroLeafTypeOf(node.Type(), path)
+
+	case isList(node):
+		list := node.ChildByName("list")
+		if list == nil || isLeaf(list) || list.ChildByName("element") == nil {
+			return nil, fmt.Errorf("%s: LIST group is not composed of a repeated .list.element", path[1:])
+		}
+		items, err := avroFieldTypeOf(list.ChildByName("element"), path.append("list").append("element"))
+		if err != nil {
+			return nil, err
+		}
+		return &avroArray{Type: "array", Items: items}, nil
+
+	case isMap(node):
+		keyValue := node.ChildByName("key_value")
+		if keyValue == nil || isLeaf(keyValue) || keyValue.ChildByName("key") == nil || keyValue.ChildByName("value") == nil {
+			return nil, fmt.Errorf("%s: MAP group is not composed of a repeated .key_value.(key, value)", path[1:])
+		}
+		if key := keyValue.ChildByName("key"); !isLeaf(key) || !isStringLike(key.Type()) {
+			return nil, fmt.Errorf("%s: avro maps must have string keys", path[1:])
+		}
+		values, err := avroFieldTypeOf(keyValue.ChildByName("value"), path.append("key_value").append("value"))
+		if err != nil {
+			return nil, err
+		}
+		return &avroMap{Type: "map", Values: values}, nil
+
+	default:
+		return avroRecordOf(node, path)
+	}
+}
+
+func avroLeafTypeOf(t Type, path columnPath) (interface{}, error) {
+	lt := t.LogicalType()
+	if lt == nil {
+		lt = new(format.LogicalType)
+	}
+
+	switch t.Kind() {
+	case Boolean:
+		return "boolean", nil
+
+	case Int32:
+		switch {
+		case lt.Date != nil:
+			return &avroPrimitive{Type: "int", LogicalType: "date"}, nil
+		case lt.Time != nil:
+			return &avroPrimitive{Type: "int", LogicalType: "time-millis"}, nil
+		case lt.Decimal != nil:
+			return &avroPrimitive{Type: "int", LogicalType: "decimal", Precision: int(lt.Decimal.Precision), Scale: int(lt.Decimal.Scale)}, nil
+		case lt.Integer != nil && !lt.Integer.IsSigned && lt.Integer.BitWidth == 32:
+			return "long", nil
+		default:
+			return "int", nil
+		}
+
+	case Int64:
+		switch {
+		case lt.Time != nil && lt.Time.Unit.Micros != nil:
+			return &avroPrimitive{Type: "long", LogicalType: "time-micros"}, nil
+		case lt.Timestamp != nil:
+			logicalType := "timestamp-" + avroTimeUnitName(lt.Timestamp.Unit)
+			if !lt.Timestamp.IsAdjustedToUTC {
+				logicalType = "local-" + logicalType
+			}
+			return &avroPrimitive{Type: "long", LogicalType: logicalType}, nil
+		case lt.Decimal != nil:
+			return &avroPrimitive{Type: "long", LogicalType: "decimal", Precision: int(lt.Decimal.Precision), Scale: int(lt.Decimal.Scale)}, nil
+		default:
+			return "long", nil
+		}
+
+	case Float:
+		return "float", nil
+
+	case Double:
+		return "double", nil
+
+	case ByteArray:
+		switch {
+		case lt.UTF8 != nil || lt.Enum != nil || lt.Json != nil:
+			return "string", nil
+		case lt.Decimal != nil:
+			return &avroPrimitive{Type: "bytes", LogicalType: "decimal", Precision: int(lt.Decimal.Precision), Scale: int(lt.Decimal.Scale)}, nil
+		default:
+			return "bytes", nil
+		}
+
+	case FixedLenByteArray:
+		if lt.UUID != nil {
+			return &avroPrimitive{Type: "string", LogicalType: "uuid"}, nil
+		}
+		fixed := &avroFixed{
+			Type:      "fixed",
+			Name:      path[len(path)-1],
+			Namespace: strings.Join(path[:len(path)-1], "."),
+			Size:      t.Length(),
+		}
+		switch {
+		case lt.Decimal != nil:
+			fixed.LogicalType = "decimal"
+			fixed.Precision = int(lt.Decimal.Precision)
+			fixed.Scale = int(lt.Decimal.Scale)
+		case isIntervalType(t):
+			fixed.LogicalType = "duration"
+		}
+		return fixed, nil
+
+	default:
+		return nil, fmt.Errorf("%s: cannot represent parquet columns of type %s in avro", path[1:], t)
+	}
+}
+
+func avroTimeUnitName(unit format.TimeUnit) string {
+	switch {
+	case unit.Millis != nil:
+		return "millis"
+	case unit.Micros != nil:
+		return "micros"
+	default:
+		return "nanos"
+	}
+}
+
+// isStringLike returns true if values of t are UTF-8 strings, which is the
+// case of STRING and ENUM columns.
+func isStringLike(t Type) bool {
+	lt := t.LogicalType()
+	return t.Kind() == ByteArray && lt != nil && (lt.UTF8 != nil || lt.Enum != nil)
+}
+
+func isAvroName(name string) bool {
+	for i, c := range name {
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return name != ""
+}
@@ -0,0 +1,263 @@
+package parquet_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+const avroTestSchema = `{
+	"type": "record",
+	"name": "Event",
+	"namespace": "com.example",
+	"fields": [
+		{"name": "id", "type": "long", "field-id": 1},
+		{"name": "name", "type": ["null", "string"], "default": null},
+		{"name": "valid", "type": "boolean"},
+		{"name": "score", "type": "float"},
+		{"name": "ratio", "type": "double"},
+		{"name": "count", "type": "int"},
+		{"name": "payload", "type": "bytes"},
+		{"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["A", "B"]}},
+		{"name": "hash", "type": {"type": "fixed", "name": "Hash", "size": 8}},
+		{"name": "uuid", "type": {"type": "string", "logicalType": "uuid"}},
+		{"name": "day", "type": {"type": "int", "logicalType": "date"}},
+		{"name": "at", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+		{"name": "local", "type": {"type": "long", "logicalType": "local-timestamp-millis"}},
+		{"name": "of_day", "type": {"type": "int", "logicalType": "time-millis"}},
+		{"name": "price", "type": {"type": "bytes", "logicalType": "decimal", "precision": 20, "scale": 4}},
+		{"name": "amount", "type": {"type": "fixed", "name": "Amount", "size": 16, "logicalType": "decimal", "precision": 38, "scale": 2}},
+		{"name": "elapsed", "type": {"type": "fixed", "name": "Elapsed", "size": 12, "logicalType": "duration"}},
+		{"name": "unknown", "type": {"type": "long", "logicalType": "unknown"}},
+		{"name": "tags", "type": {"type": "array", "items": "string"}},
+		{"name": "attributes", "type": ["null", {"type": "map", "values": ["null", "long"]}]},
+		{"name": "address", "type": {
+			"type": "record",
+			"name": "Address",
+			"fields": [
+				{"name": "city", "type": "string"},
+				{"name": "zip", "type": ["int", "null"]}
+			]
+		}},
+		{"name": "previous", "type": ["null", "Address"]},
+		{"name": "other_hash", "type": "com.example.Hash"}
+	]
+}`
+
+const avroTestSchemaPrint = `message Event {
+	required int64 id (INT(64,true)) = 1;
+	optional binary name (STRING);
+	required boolean valid;
+	required float score;
+	required double ratio;
+	required int32 count (INT(32,true));
+	required binary payload;
+	required binary kind (ENUM);
+	required fixed_len_byte_array(8) hash;
+	required fixed_len_byte_array(16) uuid (UUID);
+	required int32 day (DATE);
+	required int64 at (TIMESTAMP(isAdjustedToUTC=true,unit=MICROS));
+	required int64 local (TIMESTAMP(isAdjustedToUTC=false,unit=MILLIS));
+	required int32 of_day (TIME(isAdjustedToUTC=true,unit=MILLIS));
+	required binary price (DECIMAL(4,20));
+	required fixed_len_byte_array(16) amount (DECIMAL(2,38));
+	required fixed_len_byte_array(12) elapsed;
+	required int64 unknown (INT(64,true));
+	required group tags (LIST) {
+		repeated group list {
+			required binary element (STRING);
+		}
+	}
+	optional group attributes (MAP) {
+		repeated group key_value {
+			required binary key (STRING);
+			optional int64 value (INT(64,true));
+		}
+	}
+	required group address {
+		required binary city (STRING);
+		optional int32 zip (INT(32,true));
+	}
+	optional group previous {
+		required binary city (STRING);
+		optional int32 zip (INT(32,true));
+	}
+	required fixed_len_byte_array(8) other_hash;
+}`
+
+func TestSchemaFromAvro(t *testing.T) {
+	schema, err := parquet.SchemaFromAvro([]byte(avroTestSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := schema.String(); s != avroTestSchemaPrint {
+		t.Errorf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", avroTestSchemaPrint, s)
+	}
+	if typ := schema.ChildByName("elapsed").Type(); typ != parquet.IntervalType {
+		t.Errorf("avro duration must be converted to INTERVAL but got %s", typ)
+	}
+}
+
+func TestSchemaToAvro(t *testing.T) {
+	type Item struct {
+		Name  string `parquet:"name"`
+		Price int64  `parquet:"price,decimal(2:18)"`
+	}
+	type Order struct {
+		ID       int64             `parquet:"id"`
+		Customer *string           `parquet:"customer,optional"`
+		Items    []Item            `parquet:"items,list"`
+		Labels   map[string]string `parquet:"labels"`
+		Notes    []string          `parquet:"notes"`
+		Size     uint32            `parquet:"size"`
+	}
+
+	schema := parquet.SchemaOf(Order{})
+	avro, err := parquet.SchemaToAvro(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `{"type":"record","name":"Order","fields":[` +
+		`{"name":"id","type":"long"},` +
+		`{"name":"customer","type":["null","string"],"default":null},` +
+		`{"name":"items","type":{"type":"array","items":{"type":"record","name":"element","namespace":"Order.items.list","fields":[` +
+		`{"name":"name","type":"string"},` +
+		`{"name":"price","type":{"type":"long","logicalType":"decimal","precision":18,"scale":2}}]}}},` +
+		`{"name":"labels","type":{"type":"map","values":"string"}},` +
+		`{"name":"notes","type":{"type":"array","items":"string"}},` +
+		`{"name":"size","type":"long"}]}`
+
+	if string(avro) != want {
+		t.Errorf("\nexpected:\n%s\nfound:\n%s", want, avro)
+	}
+}
+
+func TestSchemaAvroRoundTrip(t *testing.T) {
+	schema, err := parquet.SchemaFromAvro([]byte(avroTestSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	avro, err := parquet.SchemaToAvro(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := parquet.SchemaFromAvro(avro)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ENUM columns are exported as strings since parquet does not retain
+	// the enum symbols.
+	want := strings.Replace(schema.String(), "kind (ENUM)", "kind (STRING)", 1)
+	if s := roundTrip.String(); s != want {
+		t.Errorf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", want, s)
+	}
+}
+
+func TestSchemaFromAvroErrors(t *testing.T) {
+	tests := []struct {
+		scenario string
+		schema   string
+		err      string
+	}{
+		{
+			scenario: "not a record",
+			schema:   `"string"`,
+			err:      `avro schema must be a record`,
+		},
+		{
+			scenario: "union of multiple types",
+			schema:   `{"type":"record","name":"r","fields":[{"name":"a","type":["null","int","string"]}]}`,
+			err:      `a: avro unions must contain exactly one type other than null: [null int string]`,
+		},
+		{
+			scenario: "recursive type",
+			schema:   `{"type":"record","name":"node","fields":[{"name":"next","type":["null","node"]}]}`,
+			err:      `next: recursive avro type "node" cannot be represented in parquet`,
+		},
+		{
+			scenario: "unknown type",
+			schema:   `{"type":"record","name":"r","fields":[{"name":"a","type":"Missing"}]}`,
+			err:      `a: unknown avro type "Missing"`,
+		},
+		{
+			scenario: "null field",
+			schema:   `{"type":"record","name":"r","fields":[{"name":"a","type":"null"}]}`,
+			err:      `a: avro null type is only supported in unions with another type`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := parquet.SchemaFromAvro([]byte(test.schema))
+			if err == nil {
+				t.Fatal("expected an error but the schema was converted")
+			}
+			if err.Error() != test.err {
+				t.Errorf("wrong error:\nwant = %s\ngot  = %s", test.err, err)
+			}
+		})
+	}
+}
+
+func TestSchemaToAvroErrors(t *testing.T) {
+	tests := []struct {
+		scenario string
+		node     parquet.Node
+		err      string
+	}{
+		{
+			scenario: "int96 column",
+			node:     parquet.Group{"time": parquet.Leaf(parquet.Int96Type)},
+			err:      `time: cannot represent parquet columns of type INT96 in avro`,
+		},
+		{
+			scenario: "map with integer keys",
+			node:     parquet.Group{"m": parquet.Map(parquet.Int(64), parquet.String())},
+			err:      `m: avro maps must have string keys`,
+		},
+		{
+			scenario: "invalid field name",
+			node:     parquet.Group{"a-b": parquet.String()},
+			err:      `invalid avro field name: "a-b"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := parquet.SchemaToAvro(parquet.NewSchema("test", test.node))
+			if err == nil {
+				t.Fatal("expected an error but the schema was converted")
+			}
+			if err.Error() != test.err {
+				t.Errorf("wrong error:\nwant = %s\ngot  = %s", test.err, err)
+			}
+		})
+	}
+}
+
+func TestSchemaFromAvroFieldOrder(t *testing.T) {
+	schema, err := parquet.SchemaFromAvro([]byte(`{
+	"type": "record",
+	"name": "Event",
+	"fields": [
+		{"name": "time", "type": "long"},
+		{"name": "id", "type": "long"},
+		{"name": "country", "type": "string"}
+	]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := schema.ChildNames(); len(names) != 3 || names[0] != "time" || names[1] != "id" || names[2] != "country" {
+		t.Errorf("wrong order of the record fields: %q", names)
+	}
+	b, err := parquet.SchemaToAvro(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"record","name":"Event","fields":[{"name":"time","type":"long"},{"name":"id","type":"long"},{"name":"country","type":"string"}]}`; string(b) != want {
+		t.Errorf("exported avro schema does not preserve the order of fields:\nwant = %s\ngot  = %s", want, b)
+	}
+}
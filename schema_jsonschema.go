@@ -0,0 +1,516 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/segmentio/parquet-go/format"
+)
+
+// SchemaFromJSONSchema converts the JSON Schema document in data to a parquet
+// schema. The document must describe an object, the schema is named after its
+// title.
+//
+// JSON Schema types are mapped to parquet nodes as follows:
+//
+//	boolean          | BOOLEAN
+//	integer          | INT(64,true), or INT(32,true) with the "int32" format
+//	number           | DOUBLE, or FLOAT with the "float" format
+//	string           | STRING, or ENUM when the schema has an "enum" keyword
+//	object           | group of the properties, in the order of the document
+//	array            | LIST of the items
+//
+// Strings with the "date-time", "date", "time", "uuid" and "duration" formats
+// are converted to the TIMESTAMP(MICROS), DATE, TIME(MICROS), UUID and INTERVAL
+// types, and strings with the "base64" content encoding to BYTE_ARRAY columns.
+// Objects with no properties but an "additionalProperties" schema become maps
+// of string keys, and schemas which do not constrain the type of values are
+// converted to JSON columns.
+//
+// Properties which are not listed as required, or which accept null values
+// (e.g. with "type": ["string", "null"]), become optional columns. Local
+// references to other parts of the document with "$ref" are resolved, but
+// recursive references cannot be represented in parquet and cause the function
+// to return an error.
+func SchemaFromJSONSchema(data []byte) (*Schema, error) {
+	d := &jsonDecoder{decoder: json.NewDecoder(bytes.NewReader(data)), keys: make(map[uintptr][]string)}
+	v, err := d.decode()
+	if err == nil {
+		if _, err = d.decoder.Token(); err == io.EOF {
+			err = nil
+		} else if err == nil {
+			err = fmt.Errorf("invalid data after the end of the document")
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decoding json schema: %w", err)
+	}
+	doc, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("decoding json schema: the document is not an object")
+	}
+	c := &jsonSchemaImporter{doc: doc, keys: d.keys, resolving: make(map[string]bool)}
+	root, _, err := c.nodeOf(doc, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, isGroup := root.(*orderedGroup); !isGroup {
+		return nil, fmt.Errorf("json schema must describe an object with properties")
+	}
+	title, _ := doc["title"].(string)
+	return NewSchema(title, root), nil
+}
+
+// jsonDecoder decodes JSON documents to the same values as json.Unmarshal into
+// an interface{}, and records the order of the keys of the decoded objects,
+// which is lost in the maps they are decoded to. The keys are indexed by the
+// pointers of the maps.
+type jsonDecoder struct {
+	decoder *json.Decoder
+	keys    map[uintptr][]string
+}
+
+func (d *jsonDecoder) decode() (interface{}, error) {
+	tok, err := d.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		object := make(map[string]interface{})
+		keys := []string{}
+		for d.decoder.More() {
+			tok, err := d.decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := tok.(string)
+			value, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			if _, exists := object[key]; !exists {
+				keys = append(keys, key)
+			}
+			object[key] = value
+		}
+		d.keys[reflect.ValueOf(object).Pointer()] = keys
+		_, err := d.decoder.Token()
+		return object, err
+
+	case json.Delim('['):
+		array := []interface{}{}
+		for d.decoder.More() {
+			value, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := d.decoder.Token()
+		return array, err
+
+	default:
+		return tok, nil
+	}
+}
+
+type jsonSchemaImporter struct {
+	doc       map[string]interface{}
+	keys      map[uintptr][]string
+	resolving map[string]bool
+}
+
+func (c *jsonSchemaImporter) nodeOf(schema map[string]interface{}, path columnPath) (node Node, nullable bool, err error) {
+	if ref, ok := schema["$ref"].(string); ok {
+		if c.resolving[ref] {
+			return nil, false, fmt.Errorf("%s: recursive json schema reference %q cannot be represented in parquet", path, ref)
+		}
+		target, err := c.resolve(ref)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s: %w", path, err)
+		}
+		c.resolving[ref] = true
+		defer delete(c.resolving, ref)
+		return c.nodeOf(target, path)
+	}
+
+	for _, keyword := range [...]string{"anyOf", "oneOf"} {
+		if alternatives, ok := schema[keyword].([]interface{}); ok {
+			return c.alternativeNodeOf(keyword, alternatives, path)
+		}
+	}
+
+	var types []string
+	switch t := schema["type"].(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, v := range t {
+			s, _ := v.(string)
+			types = append(types, s)
+		}
+	}
+
+	typ := ""
+	for _, t := range types {
+		switch {
+		case t == "null":
+			nullable = true
+		case typ != "":
+			return nil, false, fmt.Errorf("%s: json schema types with multiple values other than null are not supported: %v", path, types)
+		default:
+			typ = t
+		}
+	}
+
+	typeFormat, _ := schema["format"].(string)
+
+	switch typ {
+	case "":
+		if len(types) != 0 {
+			return nil, false, fmt.Errorf("%s: json schema null type is only supported with another type", path)
+		}
+		return JSON(), true, nil
+	case "boolean":
+		return Leaf(BooleanType), nullable, nil
+	case "integer":
+		if typeFormat == "int32" {
+			return Int(32), nullable, nil
+		}
+		return Int(64), nullable, nil
+	case "number":
+		if typeFormat == "float" {
+			return Leaf(FloatType), nullable, nil
+		}
+		return Leaf(DoubleType), nullable, nil
+	case "string":
+		if _, ok := schema["enum"]; ok {
+			return Enum(), nullable, nil
+		}
+		if schema["contentEncoding"] == "base64" {
+			return Leaf(ByteArrayType), nullable, nil
+		}
+		switch typeFormat {
+		case "date-time":
+			return Timestamp(Microsecond), nullable, nil
+		case "date":
+			return Date(), nullable, nil
+		case "time":
+			return Time(Microsecond), nullable, nil
+		case "uuid":
+			return UUID(), nullable, nil
+		case "duration":
+			return Leaf(IntervalType), nullable, nil
+		}
+		return String(), nullable, nil
+	case "array":
+		items, ok := schema["items"].(map[string]interface{})
+		if !ok {
+			if _, exists := schema["items"]; exists {
+				return nil, false, fmt.Errorf("%s: json schema arrays must have a single schema for their items", path)
+			}
+			return List(JSON()), nullable, nil
+		}
+		elem, elemNullable, err := c.nodeOf(items, path.append("element"))
+		if err != nil {
+			return nil, false, err
+		}
+		if elemNullable {
+			elem = Optional(elem)
+		}
+		return List(elem), nullable, nil
+	case "object":
+		node, err := c.objectNodeOf(schema, path)
+		return node, nullable, err
+	default:
+		return nil, false, fmt.Errorf("%s: unsupported json schema type %q", path, typ)
+	}
+}
+
+func (c *jsonSchemaImporter) objectNodeOf(schema map[string]interface{}, path columnPath) (Node, error) {
+	properties, hasProperties := schema["properties"].(map[string]interface{})
+
+	if !hasProperties {
+		values, ok := schema["additionalProperties"].(map[string]interface{})
+		if !ok {
+			return JSON(), nil
+		}
+		value, nullable, err := c.nodeOf(values, path.append("value"))
+		if err != nil {
+			return nil, err
+		}
+		if nullable {
+			value = Optional(value)
+		}
+		return Map(String(), value), nil
+	}
+
+	required := make(map[string]bool)
+	if names, ok := schema["required"].([]interface{}); ok {
+		for _, name := range names {
+			if s, ok := name.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	group := new(orderedGroup)
+	for _, name := range c.keys[reflect.ValueOf(properties).Pointer()] {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid json schema", path.append(name))
+		}
+		node, nullable, err := c.nodeOf(property, path.append(name))
+		if err != nil {
+			return nil, err
+		}
+		if nullable || !required[name] {
+			node = Optional(node)
+		}
+		group.add(name, node)
+	}
+	return group, nil
+}
+
+func (c *jsonSchemaImporter) alternativeNodeOf(keyword string, alternatives []interface{}, path columnPath) (Node, bool, error) {
+	var schema map[string]interface{}
+	nullable := false
+
+	for _, alternative := range alternatives {
+		s, ok := alternative.(map[string]interface{})
+		switch {
+		case !ok:
+			return nil, false, fmt.Errorf("%s: invalid json schema in %s", path, keyword)
+		case s["type"] == "null":
+			nullable = true
+		case schema != nil:
+			return nil, false, fmt.Errorf("%s: json schema %s must contain exactly one schema other than null", path, keyword)
+		default:
+			schema = s
+		}
+	}
+	if schema == nil {
+		return nil, false, fmt.Errorf("%s: json schema %s must contain exactly one schema other than null", path, keyword)
+	}
+
+	node, nodeNullable, err := c.nodeOf(schema, path)
+	return node, nullable || nodeNullable, err
+}
+
+// resolve returns the part of the document referenced by the JSON pointer in
+// ref, which must be relative to the root of the document (e.g. "#/$defs/id").
+func (c *jsonSchemaImporter) resolve(ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("json schema reference %q is not local to the document", ref)
+	}
+
+	var value interface{} = c.doc
+	for _, token := range strings.Split(strings.TrimPrefix(ref[1:], "/"), "/") {
+		if token == "" {
+			continue
+		}
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			value = v[token]
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("json schema reference %q not found", ref)
+			}
+			value = v[i]
+		default:
+			value = nil
+		}
+	}
+
+	schema, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("json schema reference %q not found", ref)
+	}
+	return schema, nil
+}
+
+// SchemaToJSONSchema converts a parquet schema to a JSON Schema document which
+// describes the JSON representation of its rows, performing the inverse of the
+// mapping applied by SchemaFromJSONSchema.
+//
+// Groups become objects listing required columns in their "required" keyword,
+// optional columns accept null values, repeated and LIST columns become arrays,
+// and MAP columns become objects with "additionalProperties". DECIMAL columns
+// are described as numbers, and binary columns as base64 encoded strings.
+//
+// The function returns an error if the schema contains INT96 columns, or MAP
+// columns with keys that are not strings.
+func SchemaToJSONSchema(schema *Schema) ([]byte, error) {
+	root, err := jsonSchemaOf(schema, nil)
+	if err != nil {
+		return nil, err
+	}
+	root.Schema = "https://json-schema.org/draft/2020-12/schema"
+	root.Title = schema.Name()
+	return json.Marshal(root)
+}
+
+type jsonSchema struct {
+	Schema               string          `json:"$schema,omitempty"`
+	Title                string          `json:"title,omitempty"`
+	Type                 interface{}     `json:"type,omitempty"`
+	Format               string          `json:"format,omitempty"`
+	ContentEncoding      string          `json:"contentEncoding,omitempty"`
+	Properties           *jsonProperties `json:"properties,omitempty"`
+	Required             []string        `json:"required,omitempty"`
+	Items                *jsonSchema     `json:"items,omitempty"`
+	AdditionalProperties *jsonSchema     `json:"additionalProperties,omitempty"`
+}
+
+// jsonProperties is the list of properties of an object, which are encoded in
+// the order of the columns of the group.
+type jsonProperties struct {
+	names   []string
+	schemas []*jsonSchema
+}
+
+func (p *jsonProperties) MarshalJSON() ([]byte, error) {
+	b := []byte{'{'}
+	for i, name := range p.names {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		k, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(p.schemas[i])
+		if err != nil {
+			return nil, err
+		}
+		b = append(b, k...)
+		b = append(b, ':')
+		b = append(b, v...)
+	}
+	return append(b, '}'), nil
+}
+
+func jsonSchemaFieldOf(node Node, path columnPath) (*jsonSchema, error) {
+	s, err := jsonSchemaOf(node, path)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case node.Optional():
+		if s.Type != nil {
+			s.Type = []string{s.Type.(string), "null"}
+		}
+	case node.Repeated():
+		s = &jsonSchema{Type: "array", Items: s}
+	}
+	return s, nil
+}
+
+func jsonSchemaOf(node Node, path columnPath) (*jsonSchema, error) {
+	switch {
+	case isLeaf(node):
+		return jsonSchemaOfLeaf(node.Type(), path)
+
+	case isList(node):
+		list := node.ChildByName("list")
+		if list == nil || isLeaf(list) || list.ChildByName("element") == nil {
+			return nil, fmt.Errorf("%s: LIST group is not composed of a repeated .list.element", path)
+		}
+		items, err := jsonSchemaFieldOf(list.ChildByName("element"), path.append("list").append("element"))
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "array", Items: items}, nil
+
+	case isMap(node):
+		keyValue := node.ChildByName("key_value")
+		if keyValue == nil || isLeaf(keyValue) || keyValue.ChildByName("key") == nil || keyValue.ChildByName("value") == nil {
+			return nil, fmt.Errorf("%s: MAP group is not composed of a repeated .key_value.(key, value)", path)
+		}
+		if key := keyValue.ChildByName("key"); !isLeaf(key) || !isStringLike(key.Type()) {
+			return nil, fmt.Errorf("%s: json objects must have string keys", path)
+		}
+		values, err := jsonSchemaFieldOf(keyValue.ChildByName("value"), path.append("key_value").append("value"))
+		if err != nil {
+			return nil, err
+		}
+		return &jsonSchema{Type: "object", AdditionalProperties: values}, nil
+
+	default:
+		object := &jsonSchema{
+			Type:       "object",
+			Properties: new(jsonProperties),
+		}
+		for _, name := range node.ChildNames() {
+			child := node.ChildByName(name)
+			property, err := jsonSchemaFieldOf(child, path.append(name))
+			if err != nil {
+				return nil, err
+			}
+			object.Properties.names = append(object.Properties.names, name)
+			object.Properties.schemas = append(object.Properties.schemas, property)
+			if !child.Optional() {
+				object.Required = append(object.Required, name)
+			}
+		}
+		return object, nil
+	}
+}
+
+func jsonSchemaOfLeaf(t Type, path columnPath) (*jsonSchema, error) {
+	lt := t.LogicalType()
+	if lt == nil {
+		lt = new(format.LogicalType)
+	}
+
+	switch {
+	case lt.Decimal != nil:
+		return &jsonSchema{Type: "number"}, nil
+	case lt.Date != nil:
+		return &jsonSchema{Type: "string", Format: "date"}, nil
+	case lt.Time != nil:
+		return &jsonSchema{Type: "string", Format: "time"}, nil
+	case lt.Timestamp != nil:
+		return &jsonSchema{Type: "string", Format: "date-time"}, nil
+	case lt.UTF8 != nil, lt.Enum != nil:
+		return &jsonSchema{Type: "string"}, nil
+	case lt.UUID != nil:
+		return &jsonSchema{Type: "string", Format: "uuid"}, nil
+	case lt.Json != nil:
+		return &jsonSchema{}, nil
+	case lt.Float16 != nil:
+		return &jsonSchema{Type: "number", Format: "float"}, nil
+	case isIntervalType(t):
+		return &jsonSchema{Type: "string", Format: "duration"}, nil
+	}
+
+	switch t.Kind() {
+	case Boolean:
+		return &jsonSchema{Type: "boolean"}, nil
+	case Int32:
+		if lt.Integer != nil && !lt.Integer.IsSigned && lt.Integer.BitWidth == 32 {
+			return &jsonSchema{Type: "integer", Format: "int64"}, nil
+		}
+		return &jsonSchema{Type: "integer", Format: "int32"}, nil
+	case Int64:
+		return &jsonSchema{Type: "integer", Format: "int64"}, nil
+	case Float:
+		return &jsonSchema{Type: "number", Format: "float"}, nil
+	case Double:
+		return &jsonSchema{Type: "number", Format: "double"}, nil
+	case ByteArray, FixedLenByteArray:
+		return &jsonSchema{Type: "string", ContentEncoding: "base64"}, nil
+	default:
+		return nil, fmt.Errorf("%s: cannot represent parquet columns of type %s in json schema", path, t)
+	}
+}
@@ -0,0 +1,217 @@
+package parquet_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+const jsonSchemaTest = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"title": "Event",
+	"type": "object",
+	"required": ["id", "time", "tags", "user", "kind", "payload", "meta"],
+	"properties": {
+		"id": {"type": "integer"},
+		"count": {"type": "integer", "format": "int32"},
+		"score": {"type": ["number", "null"]},
+		"ratio": {"type": "number", "format": "float"},
+		"valid": {"type": "boolean"},
+		"name": {"type": "string"},
+		"kind": {"type": "string", "enum": ["a", "b"]},
+		"payload": {"type": "string", "contentEncoding": "base64"},
+		"time": {"type": "string", "format": "date-time"},
+		"day": {"type": "string", "format": "date"},
+		"uuid": {"type": "string", "format": "uuid"},
+		"tags": {"type": "array", "items": {"type": "string"}},
+		"scores": {"type": "array", "items": {"type": ["integer", "null"]}},
+		"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+		"meta": {},
+		"user": {"$ref": "#/$defs/user"},
+		"manager": {"anyOf": [{"$ref": "#/$defs/user"}, {"type": "null"}]}
+	},
+	"$defs": {
+		"user": {
+			"type": "object",
+			"required": ["name"],
+			"properties": {
+				"name": {"type": "string"},
+				"email": {"type": "string"}
+			}
+		}
+	}
+}`
+
+const jsonSchemaTestPrint = `message Event {
+	required int64 id (INT(64,true));
+	optional int32 count (INT(32,true));
+	optional double score;
+	optional float ratio;
+	optional boolean valid;
+	optional binary name (STRING);
+	required binary kind (ENUM);
+	required binary payload;
+	required int64 time (TIMESTAMP(isAdjustedToUTC=true,unit=MICROS));
+	optional int32 day (DATE);
+	optional fixed_len_byte_array(16) uuid (UUID);
+	required group tags (LIST) {
+		repeated group list {
+			required binary element (STRING);
+		}
+	}
+	optional group scores (LIST) {
+		repeated group list {
+			optional int64 element (INT(64,true));
+		}
+	}
+	optional group labels (MAP) {
+		repeated group key_value {
+			required binary key (STRING);
+			required binary value (STRING);
+		}
+	}
+	optional binary meta (JSON);
+	required group user {
+		required binary name (STRING);
+		optional binary email (STRING);
+	}
+	optional group manager {
+		required binary name (STRING);
+		optional binary email (STRING);
+	}
+}`
+
+func TestSchemaFromJSONSchema(t *testing.T) {
+	schema, err := parquet.SchemaFromJSONSchema([]byte(jsonSchemaTest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := schema.String(); s != jsonSchemaTestPrint {
+		t.Errorf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", jsonSchemaTestPrint, s)
+	}
+}
+
+func TestSchemaToJSONSchema(t *testing.T) {
+	type Order struct {
+		ID       int64              `parquet:"id"`
+		Customer *string            `parquet:"customer,optional"`
+		Amounts  []float64          `parquet:"amounts,list"`
+		Labels   map[string]*string `parquet:"labels"`
+		Data     []byte             `parquet:"data"`
+		Price    int64              `parquet:"price,decimal(2:18)"`
+	}
+
+	schema, err := parquet.SchemaToJSONSchema(parquet.SchemaOf(Order{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const want = `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"Order","type":"object","properties":{` +
+		`"id":{"type":"integer","format":"int64"},` +
+		`"customer":{"type":["string","null"]},` +
+		`"amounts":{"type":"array","items":{"type":"number","format":"double"}},` +
+		`"labels":{"type":"object","additionalProperties":{"type":["string","null"]}},` +
+		`"data":{"type":"string","contentEncoding":"base64"},` +
+		`"price":{"type":"number"}},` +
+		`"required":["id","amounts","labels","data","price"]}`
+
+	if string(schema) != want {
+		t.Errorf("\nexpected:\n%s\nfound:\n%s", want, schema)
+	}
+}
+
+func TestSchemaJSONSchemaRoundTrip(t *testing.T) {
+	schema, err := parquet.SchemaFromJSONSchema([]byte(jsonSchemaTest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonSchema, err := parquet.SchemaToJSONSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip, err := parquet.SchemaFromJSONSchema(jsonSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ENUM columns are exported as strings since parquet does not retain
+	// the enum values.
+	want := strings.Replace(schema.String(), "kind (ENUM)", "kind (STRING)", 1)
+	if s := roundTrip.String(); s != want {
+		t.Errorf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", want, s)
+	}
+}
+
+func TestSchemaFromJSONSchemaErrors(t *testing.T) {
+	tests := []struct {
+		scenario string
+		schema   string
+		err      string
+	}{
+		{
+			scenario: "not an object",
+			schema:   `{"type": "string"}`,
+			err:      `json schema must describe an object with properties`,
+		},
+		{
+			scenario: "multiple types",
+			schema:   `{"type": "object", "properties": {"a": {"type": ["string", "integer"]}}}`,
+			err:      `a: json schema types with multiple values other than null are not supported: [string integer]`,
+		},
+		{
+			scenario: "union of multiple schemas",
+			schema:   `{"type": "object", "properties": {"a": {"oneOf": [{"type": "string"}, {"type": "integer"}]}}}`,
+			err:      `a: json schema oneOf must contain exactly one schema other than null`,
+		},
+		{
+			scenario: "recursive reference",
+			schema:   `{"type": "object", "properties": {"a": {"$ref": "#/$defs/node"}}, "$defs": {"node": {"type": "object", "properties": {"next": {"$ref": "#/$defs/node"}}}}}`,
+			err:      `a.next: recursive json schema reference "#/$defs/node" cannot be represented in parquet`,
+		},
+		{
+			scenario: "missing reference",
+			schema:   `{"type": "object", "properties": {"a": {"$ref": "#/$defs/missing"}}}`,
+			err:      `a: json schema reference "#/$defs/missing" not found`,
+		},
+		{
+			scenario: "remote reference",
+			schema:   `{"type": "object", "properties": {"a": {"$ref": "https://example.com/schema.json"}}}`,
+			err:      `a: json schema reference "https://example.com/schema.json" is not local to the document`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := parquet.SchemaFromJSONSchema([]byte(test.schema))
+			if err == nil {
+				t.Fatal("expected an error but the schema was converted")
+			}
+			if err.Error() != test.err {
+				t.Errorf("wrong error:\nwant = %s\ngot  = %s", test.err, err)
+			}
+		})
+	}
+}
+
+func TestSchemaFromJSONSchemaFieldOrder(t *testing.T) {
+	const doc = `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"event","type":"object","properties":{` +
+		`"time":{"type":"integer","format":"int64"},` +
+		`"id":{"type":"integer","format":"int64"},` +
+		`"location":{"type":"object","properties":{"lon":{"type":"number","format":"double"},"lat":{"type":"number","format":"double"}},"required":["lon","lat"]}},` +
+		`"required":["time","id","location"]}`
+
+	schema, err := parquet.SchemaFromJSONSchema([]byte(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := schema.ChildNames(); len(names) != 3 || names[0] != "time" || names[1] != "id" || names[2] != "location" {
+		t.Errorf("wrong order of the object properties: %q", names)
+	}
+	b, err := parquet.SchemaToJSONSchema(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != doc {
+		t.Errorf("exported json schema does not preserve the order of properties:\nwant = %s\ngot  = %s", doc, b)
+	}
+}
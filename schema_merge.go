@@ -0,0 +1,301 @@
+package parquet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/segmentio/parquet-go/encoding"
+	"github.com/segmentio/parquet-go/format"
+)
+
+// MergeSchemas returns a schema which is a superset of all the schemas passed
+// as arguments, intended to be used as target of conversions from each of the
+// input schemas with Convert.
+//
+// Columns are matched by path, and the merged schema contains the union of the
+// columns of all the input schemas. Required columns which are missing from
+// one of the inputs, or which are optional in another input, become optional
+// in the merged schema. Repeated columns must be repeated in all the schemas
+// where they exist.
+//
+// When leaf columns have different types, the function applies the following
+// widening rules to select the type of the merged column:
+//
+//   - signed or unsigned integers are promoted to the larger bit width, and
+//     unsigned integers can be promoted to wider signed integers
+//   - FLOAT is promoted to DOUBLE
+//   - STRING is promoted to BYTE_ARRAY
+//   - DATE is promoted to TIMESTAMP
+//   - TIME and TIMESTAMP are promoted to the finest time unit
+//
+// Promoted columns retain the field ID, encodings, and compression codecs that
+// they have in the first schema where they exist; encodings which cannot be
+// applied to the promoted type are dropped.
+//
+// The function returns an error describing the first column where the changes
+// between schemas are incompatible. The name of the merged schema is the name
+// of the first schema, which is returned unchanged if all other schemas are
+// subsets of it.
+func MergeSchemas(schemas ...*Schema) (*Schema, error) {
+	if len(schemas) == 0 {
+		return nil, fmt.Errorf("parquet.MergeSchemas: no schemas to merge")
+	}
+
+	merged := schemas[0]
+	for _, schema := range schemas[1:] {
+		node, changed, err := mergeNodes(nil, merged.root, schema.root)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			merged = NewSchema(merged.Name(), node)
+		}
+	}
+	return merged, nil
+}
+
+// mergeNodes merges node2 into node1, returning whether the merged node differs
+// from node1.
+func mergeNodes(path columnPath, node1, node2 Node) (Node, bool, error) {
+	if node1.Repeated() != node2.Repeated() {
+		return nil, false, mergeError(path, "cannot merge repeated and non-repeated columns")
+	}
+
+	var merged Node
+	var changed bool
+	var err error
+
+	switch {
+	case isLeaf(node1) && isLeaf(node2):
+		merged, changed, err = mergeLeafNodes(path, node1, node2)
+	case isLeaf(node1) || isLeaf(node2):
+		return nil, false, mergeError(path, "cannot merge leaf and group columns")
+	default:
+		merged, changed, err = mergeGroupNodes(path, node1, node2)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch {
+	case node1.Repeated():
+		if changed {
+			merged = Repeated(merged)
+		}
+	case node1.Optional() || node2.Optional():
+		if changed || !node1.Optional() {
+			merged, changed = Optional(merged), true
+		}
+	default:
+		if changed {
+			merged = Required(merged)
+		}
+	}
+
+	if changed && fieldIDOf(merged) == 0 {
+		id := fieldIDOf(node1)
+		if id == 0 {
+			id = fieldIDOf(node2)
+		}
+		if id != 0 {
+			merged = FieldID(merged, id)
+		}
+	}
+	return merged, changed, nil
+}
+
+func mergeLeafNodes(path columnPath, node1, node2 Node) (Node, bool, error) {
+	type1, type2 := node1.Type(), node2.Type()
+	if typesAreIdentical(type1, type2) {
+		return node1, false, nil
+	}
+	typ, ok := mergeTypes(type1, type2)
+	if !ok {
+		return nil, false, mergeError(path, fmt.Sprintf("incompatible types %s and %s", type1, type2))
+	}
+	if typesAreIdentical(typ, type1) {
+		return node1, false, nil
+	}
+	// The merged leaf retains the encodings and compression codecs of the node
+	// of the first schema, its field ID is set by mergeNodes.
+	return mergedLeaf(typ, node1), true, nil
+}
+
+// mergedLeaf returns a leaf node of type typ with the encodings and compression
+// codecs of node, omitting the encodings which cannot be applied to typ.
+func mergedLeaf(typ Type, node Node) Node {
+	kind := format.Type(typ.Kind())
+	encodings := make([]encoding.Encoding, 0, len(node.Encoding()))
+	for _, e := range node.Encoding() {
+		if e.CanEncode(kind) {
+			encodings = append(encodings, e)
+		}
+	}
+	return Compressed(Encoded(Leaf(typ), encodings...), node.Compression()...)
+}
+
+func mergeGroupNodes(path columnPath, node1, node2 Node) (Node, bool, error) {
+	logicalType1 := node1.Type().LogicalType()
+	logicalType2 := node2.Type().LogicalType()
+	if !logicalTypesAreEqual(logicalType1, logicalType2) {
+		return nil, false, mergeError(path, fmt.Sprintf("incompatible group types %s and %s", node1.Type(), node2.Type()))
+	}
+
+	only1, only2, both := comm(node1.ChildNames(), node2.ChildNames())
+	group := make(Group, len(only1)+len(only2)+len(both))
+	changed := len(only2) > 0
+
+	for _, name := range only1 {
+		child := node1.ChildByName(name)
+		if child.Required() {
+			child, changed = Optional(child), true
+		}
+		group[name] = child
+	}
+
+	for _, name := range only2 {
+		child := node2.ChildByName(name)
+		if child.Required() {
+			child = Optional(child)
+		}
+		group[name] = child
+	}
+
+	for _, name := range both {
+		child, childChanged, err := mergeNodes(path.append(name), node1.ChildByName(name), node2.ChildByName(name))
+		if err != nil {
+			return nil, false, err
+		}
+		group[name] = child
+		changed = changed || childChanged
+	}
+
+	if !changed {
+		return node1, false, nil
+	}
+	switch {
+	case logicalType1 != nil && logicalType1.List != nil:
+		return listNode{group}, true, nil
+	case logicalType1 != nil && logicalType1.Map != nil:
+		return mapNode{group}, true, nil
+	default:
+		return group, true, nil
+	}
+}
+
+func mergeError(path columnPath, reason string) error {
+	return fmt.Errorf("cannot merge parquet schemas: %s for column %q", reason, path)
+}
+
+// mergeTypes returns the type which values of both type1 and type2 can be
+// safely converted to, or false if no such type exists.
+func mergeTypes(type1, type2 Type) (Type, bool) {
+	if bitWidth1, signed1, ok := integerTypeOf(type1); ok {
+		bitWidth2, signed2, ok := integerTypeOf(type2)
+		switch {
+		case !ok:
+			return nil, false
+		case signed1 == signed2:
+			if bitWidth1 >= bitWidth2 {
+				return type1, true
+			}
+			return type2, true
+		case signed1 && bitWidth1 > bitWidth2:
+			return type1, true
+		case signed2 && bitWidth2 > bitWidth1:
+			return type2, true
+		default:
+			return nil, false
+		}
+	}
+
+	logicalType1 := type1.LogicalType()
+	logicalType2 := type2.LogicalType()
+
+	switch {
+	case logicalType1 == nil && logicalType2 == nil:
+		switch {
+		case type1.Kind() == Float && type2.Kind() == Double:
+			return type2, true
+		case type1.Kind() == Double && type2.Kind() == Float:
+			return type1, true
+		}
+
+	case logicalType1 == nil || logicalType2 == nil:
+		if logicalType1 == nil && type1.Kind() == ByteArray && logicalType2.UTF8 != nil {
+			return type1, true
+		}
+		if logicalType2 == nil && type2.Kind() == ByteArray && logicalType1.UTF8 != nil {
+			return type2, true
+		}
+
+	case logicalType1.Date != nil && logicalType2.Timestamp != nil:
+		return type2, true
+
+	case logicalType1.Timestamp != nil && logicalType2.Date != nil:
+		return type1, true
+
+	case logicalType1.Timestamp != nil && logicalType2.Timestamp != nil:
+		timestamp1, timestamp2 := logicalType1.Timestamp, logicalType2.Timestamp
+		if timestamp1.IsAdjustedToUTC == timestamp2.IsAdjustedToUTC {
+			if timeUnitDurationOf(timestamp1.Unit) <= timeUnitDurationOf(timestamp2.Unit) {
+				return type1, true
+			}
+			return type2, true
+		}
+
+	case logicalType1.Time != nil && logicalType2.Time != nil:
+		time1, time2 := logicalType1.Time, logicalType2.Time
+		if time1.IsAdjustedToUTC == time2.IsAdjustedToUTC {
+			if timeUnitDurationOf(time1.Unit) <= timeUnitDurationOf(time2.Unit) {
+				return type1, true
+			}
+			return type2, true
+		}
+	}
+
+	return nil, false
+}
+
+// integerTypeOf returns the bit width and signedness of integer types. INT32
+// and INT64 columns without a logical type are considered signed integers.
+func integerTypeOf(t Type) (bitWidth int, signed bool, ok bool) {
+	if logicalType := t.LogicalType(); logicalType != nil {
+		if logicalType.Integer == nil {
+			return 0, false, false
+		}
+		return int(logicalType.Integer.BitWidth), logicalType.Integer.IsSigned, true
+	}
+	switch t.Kind() {
+	case Int32:
+		return 32, true, true
+	case Int64:
+		return 64, true, true
+	default:
+		return 0, false, false
+	}
+}
+
+func timeUnitDurationOf(unit format.TimeUnit) time.Duration {
+	switch {
+	case unit.Millis != nil:
+		return time.Millisecond
+	case unit.Micros != nil:
+		return time.Microsecond
+	default:
+		return time.Nanosecond
+	}
+}
+
+func typesAreIdentical(type1, type2 Type) bool {
+	return type1.Kind() == type2.Kind() &&
+		type1.Length() == type2.Length() &&
+		logicalTypesAreEqual(type1.LogicalType(), type2.LogicalType())
+}
+
+func logicalTypesAreEqual(logicalType1, logicalType2 *format.LogicalType) bool {
+	if logicalType1 == nil || logicalType2 == nil {
+		return logicalType1 == logicalType2
+	}
+	return logicalType1.String() == logicalType2.String()
+}
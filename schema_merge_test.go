@@ -0,0 +1,328 @@
+package parquet_test
+
+import (
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+func TestMergeSchemas(t *testing.T) {
+	tests := []struct {
+		scenario string
+		schemas  []parquet.Node
+		print    string
+	}{
+		{
+			scenario: "identical schemas",
+			schemas: []parquet.Node{
+				parquet.Group{"id": parquet.Int(64), "name": parquet.String()},
+				parquet.Group{"id": parquet.Int(64), "name": parquet.String()},
+			},
+			print: `message test {
+	required int64 id (INT(64,true));
+	required binary name (STRING);
+}`,
+		},
+
+		{
+			scenario: "added optional column",
+			schemas: []parquet.Node{
+				parquet.Group{"id": parquet.Int(64)},
+				parquet.Group{"id": parquet.Int(64), "name": parquet.Optional(parquet.String())},
+			},
+			print: `message test {
+	required int64 id (INT(64,true));
+	optional binary name (STRING);
+}`,
+		},
+
+		{
+			scenario: "missing required columns become optional",
+			schemas: []parquet.Node{
+				parquet.Group{"id": parquet.Int(64), "name": parquet.String()},
+				parquet.Group{"id": parquet.Int(64), "email": parquet.String()},
+			},
+			print: `message test {
+	optional binary email (STRING);
+	required int64 id (INT(64,true));
+	optional binary name (STRING);
+}`,
+		},
+
+		{
+			scenario: "required and optional columns",
+			schemas: []parquet.Node{
+				parquet.Group{"id": parquet.Int(64)},
+				parquet.Group{"id": parquet.Optional(parquet.Int(64))},
+			},
+			print: `message test {
+	optional int64 id (INT(64,true));
+}`,
+		},
+
+		{
+			scenario: "widened types",
+			schemas: []parquet.Node{
+				parquet.Group{
+					"a": parquet.Int(32),
+					"b": parquet.Leaf(parquet.FloatType),
+					"c": parquet.String(),
+					"d": parquet.Date(),
+					"e": parquet.Timestamp(parquet.Millisecond),
+					"f": parquet.Uint(16),
+				},
+				parquet.Group{
+					"a": parquet.Int(64),
+					"b": parquet.Leaf(parquet.DoubleType),
+					"c": parquet.Leaf(parquet.ByteArrayType),
+					"d": parquet.Timestamp(parquet.Microsecond),
+					"e": parquet.Timestamp(parquet.Nanosecond),
+					"f": parquet.Int(32),
+				},
+			},
+			print: `message test {
+	required int64 a (INT(64,true));
+	required double b;
+	required binary c;
+	required int64 d (TIMESTAMP(isAdjustedToUTC=true,unit=MICROS));
+	required int64 e (TIMESTAMP(isAdjustedToUTC=true,unit=NANOS));
+	required int32 f (INT(32,true));
+}`,
+		},
+
+		{
+			scenario: "nested groups and lists",
+			schemas: []parquet.Node{
+				parquet.Group{
+					"user": parquet.Optional(parquet.Group{"name": parquet.String()}),
+					"tags": parquet.List(parquet.Int(32)),
+				},
+				parquet.Group{
+					"user": parquet.Group{"age": parquet.Int(32), "name": parquet.String()},
+					"tags": parquet.List(parquet.Int(64)),
+				},
+			},
+			print: `message test {
+	required group tags (LIST) {
+		repeated group list {
+			required int64 element (INT(64,true));
+		}
+	}
+	optional group user {
+		optional int32 age (INT(32,true));
+		required binary name (STRING);
+	}
+}`,
+		},
+
+		{
+			scenario: "more than two schemas",
+			schemas: []parquet.Node{
+				parquet.Group{"a": parquet.Int(8)},
+				parquet.Group{"a": parquet.Int(16), "b": parquet.Optional(parquet.String())},
+				parquet.Group{"a": parquet.Int(32), "c": parquet.Repeated(parquet.String())},
+			},
+			print: `message test {
+	required int32 a (INT(32,true));
+	optional binary b (STRING);
+	repeated binary c (STRING);
+}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			schemas := make([]*parquet.Schema, len(test.schemas))
+			for i, node := range test.schemas {
+				schemas[i] = parquet.NewSchema("test", node)
+			}
+
+			merged, err := parquet.MergeSchemas(schemas...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if print := merged.String(); print != test.print {
+				t.Errorf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", test.print, print)
+			}
+		})
+	}
+}
+
+func TestMergeSchemasError(t *testing.T) {
+	tests := []struct {
+		scenario string
+		schemas  []parquet.Node
+	}{
+		{
+			scenario: "incompatible types",
+			schemas: []parquet.Node{
+				parquet.Group{"id": parquet.Int(64)},
+				parquet.Group{"id": parquet.String()},
+			},
+		},
+
+		{
+			scenario: "narrowing unsigned to signed integer",
+			schemas: []parquet.Node{
+				parquet.Group{"id": parquet.Uint(64)},
+				parquet.Group{"id": parquet.Int(64)},
+			},
+		},
+
+		{
+			scenario: "repeated and optional columns",
+			schemas: []parquet.Node{
+				parquet.Group{"id": parquet.Repeated(parquet.Int(64))},
+				parquet.Group{"id": parquet.Optional(parquet.Int(64))},
+			},
+		},
+
+		{
+			scenario: "leaf and group columns",
+			schemas: []parquet.Node{
+				parquet.Group{"user": parquet.String()},
+				parquet.Group{"user": parquet.Group{"name": parquet.String()}},
+			},
+		},
+
+		{
+			scenario: "list and map columns",
+			schemas: []parquet.Node{
+				parquet.Group{"tags": parquet.List(parquet.String())},
+				parquet.Group{"tags": parquet.Map(parquet.String(), parquet.String())},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			schemas := make([]*parquet.Schema, len(test.schemas))
+			for i, node := range test.schemas {
+				schemas[i] = parquet.NewSchema("test", node)
+			}
+			if _, err := parquet.MergeSchemas(schemas...); err == nil {
+				t.Fatal("expected an error when merging incompatible schemas")
+			}
+		})
+	}
+}
+
+func TestMergeSchemasConvert(t *testing.T) {
+	schema1 := parquet.NewSchema("test", parquet.Group{
+		"id":   parquet.Int(64),
+		"name": parquet.String(),
+	})
+	schema2 := parquet.NewSchema("test", parquet.Group{
+		"email": parquet.Optional(parquet.String()),
+		"id":    parquet.Int(64),
+	})
+
+	merged, err := parquet.MergeSchemas(schema1, schema2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []struct {
+		schema *parquet.Schema
+		row    parquet.Row
+		want   parquet.Row
+	}{
+		{
+			schema: schema1,
+			row: parquet.Row{
+				parquet.ValueOf(1).Level(0, 0, 0),
+				parquet.ValueOf("Luke").Level(0, 0, 1),
+			},
+			want: parquet.Row{
+				parquet.Value{}.Level(0, 0, 0),
+				parquet.ValueOf(1).Level(0, 0, 1),
+				parquet.ValueOf("Luke").Level(0, 1, 2),
+			},
+		},
+		{
+			schema: schema2,
+			row: parquet.Row{
+				parquet.ValueOf("leia@example.com").Level(0, 1, 0),
+				parquet.ValueOf(2).Level(0, 0, 1),
+			},
+			want: parquet.Row{
+				parquet.ValueOf("leia@example.com").Level(0, 1, 0),
+				parquet.ValueOf(2).Level(0, 0, 1),
+				parquet.Value{}.Level(0, 0, 2),
+			},
+		},
+	}
+
+	for _, test := range rows {
+		conv, err := parquet.Convert(merged, test.schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		row, err := conv.Convert(nil, test.row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !convertedRowsAreEqual(row, test.want) {
+			t.Errorf("wrong converted row:\nwant = %+v\ngot  = %+v", test.want, row)
+		}
+	}
+}
+
+// convertedRowsAreEqual compares rows produced by conversions, where null
+// values of extra columns retain the kind of the column.
+func convertedRowsAreEqual(row1, row2 parquet.Row) bool {
+	if len(row1) != len(row2) {
+		return false
+	}
+	for i := range row1 {
+		v1, v2 := row1[i], row2[i]
+		if v1.Column() != v2.Column() || v1.RepetitionLevel() != v2.RepetitionLevel() || v1.DefinitionLevel() != v2.DefinitionLevel() {
+			return false
+		}
+		if !v1.IsNull() && !v2.IsNull() && !parquet.Equal(v1, v2) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMergeSchemasFieldID(t *testing.T) {
+	schema1 := parquet.NewSchema("test", parquet.Group{"id": parquet.FieldID(parquet.Int(32), 1)})
+	schema2 := parquet.NewSchema("test", parquet.Group{"id": parquet.FieldID(parquet.Optional(parquet.Int(64)), 1)})
+
+	merged, err := parquet.MergeSchemas(schema1, schema2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := fieldIDOf(merged.ChildByName("id")); id != 1 {
+		t.Errorf("wrong field id of merged column: want=1 got=%d", id)
+	}
+}
+
+func TestMergeSchemasEncodingAndCompression(t *testing.T) {
+	schema1 := parquet.NewSchema("test", parquet.Group{
+		"id": parquet.FieldID(parquet.Compressed(parquet.Encoded(parquet.Int(32), &parquet.DeltaBinaryPacked), &parquet.Zstd), 1),
+	})
+	schema2 := parquet.NewSchema("test", parquet.Group{
+		"id": parquet.Int(64),
+	})
+
+	merged, err := parquet.MergeSchemas(schema1, schema2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := merged.ChildByName("id")
+	if kind := id.Type().Kind(); kind != parquet.Int64 {
+		t.Errorf("wrong type of merged column: want=INT64 got=%s", kind)
+	}
+	if fieldID := fieldIDOf(id); fieldID != 1 {
+		t.Errorf("wrong field id of merged column: want=1 got=%d", fieldID)
+	}
+	if encodings := id.Encoding(); len(encodings) != 1 || encodings[0] != &parquet.DeltaBinaryPacked {
+		t.Errorf("wrong encodings of merged column: %v", encodings)
+	}
+	if codecs := id.Compression(); len(codecs) != 1 || codecs[0] != &parquet.Zstd {
+		t.Errorf("wrong compression codecs of merged column: %v", codecs)
+	}
+}
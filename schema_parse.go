@@ -0,0 +1,591 @@
+package parquet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseSchema parses the textual representation of a parquet schema, which is
+// the message format produced by Print and used by other parquet libraries:
+//
+//	message Event {
+//		required int64 id = 1;
+//		optional binary name (STRING);
+//		required int64 time (TIMESTAMP(MICROS,true));
+//		required fixed_len_byte_array(16) amount (DECIMAL(38,2));
+//		optional group tags (LIST) {
+//			repeated group list {
+//				required binary element (STRING);
+//			}
+//		}
+//	}
+//
+// Leaf columns may be annotated with the logical types STRING, ENUM, JSON,
+// BSON, UUID, FLOAT16, DATE, DECIMAL, INT, TIME and TIMESTAMP, in the format
+// produced by Print or by parquet-mr, as well as with the legacy converted
+// types (UTF8, INT_32, TIMESTAMP_MILLIS, INTERVAL, ...). Groups may be
+// annotated with the LIST and MAP logical types. Field IDs are declared with
+// "= N" after the field name and annotation.
+//
+// The parameters of DECIMAL annotations may be written in either order, since
+// the scale can never exceed the precision: Print writes DECIMAL(scale,precision)
+// while parquet-mr writes DECIMAL(precision,scale).
+//
+// The fields of groups retain the order in which they are declared, so printing
+// the returned schema reproduces the text it was parsed from, and files written
+// with the schema have their columns in the same order.
+//
+// Errors returned by the function are prefixed with the line and column where
+// the parsing failed, in the form "line:column: message".
+func ParseSchema(text string) (*Schema, error) {
+	p := &schemaParser{lexer: schemaLexer{text: text, line: 1, column: 1}}
+	p.token = p.lexer.next()
+
+	if tok := p.next(); !strings.EqualFold(tok.text, "message") {
+		return nil, p.errorf(tok, "expected \"message\" but found %s", tok)
+	}
+
+	name := ""
+	if tok := p.token; tok.text != "{" {
+		if !tok.isWord() {
+			return nil, p.errorf(tok, "expected message name but found %s", tok)
+		}
+		name = p.next().text
+	}
+
+	root, err := p.parseGroup()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.next(); !tok.isEOF() {
+		return nil, p.errorf(tok, "unexpected %s after the end of the message", tok)
+	}
+	return NewSchema(name, root), nil
+}
+
+type schemaToken struct {
+	text   string
+	line   int
+	column int
+}
+
+const schemaPunctuation = "{}();,="
+
+func (t schemaToken) isEOF() bool { return t.text == "" }
+
+func (t schemaToken) isWord() bool {
+	return !t.isEOF() && !(len(t.text) == 1 && strings.IndexByte(schemaPunctuation, t.text[0]) >= 0)
+}
+
+func (t schemaToken) String() string {
+	if t.isEOF() {
+		return "end of input"
+	}
+	return strconv.Quote(t.text)
+}
+
+type schemaLexer struct {
+	text   string
+	offset int
+	line   int
+	column int
+}
+
+func (l *schemaLexer) next() schemaToken {
+	for l.offset < len(l.text) {
+		r, size := utf8.DecodeRuneInString(l.text[l.offset:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		l.advance(r, size)
+	}
+
+	tok := schemaToken{line: l.line, column: l.column}
+	start := l.offset
+
+	for l.offset < len(l.text) {
+		r, size := utf8.DecodeRuneInString(l.text[l.offset:])
+		if unicode.IsSpace(r) {
+			break
+		}
+		if strings.ContainsRune(schemaPunctuation, r) {
+			if l.offset == start {
+				l.advance(r, size)
+			}
+			break
+		}
+		l.advance(r, size)
+	}
+
+	tok.text = l.text[start:l.offset]
+	return tok
+}
+
+func (l *schemaLexer) advance(r rune, size int) {
+	l.offset += size
+	if r == '\n' {
+		l.line++
+		l.column = 1
+	} else {
+		l.column++
+	}
+}
+
+type schemaParser struct {
+	lexer schemaLexer
+	token schemaToken
+}
+
+func (p *schemaParser) next() schemaToken {
+	tok := p.token
+	p.token = p.lexer.next()
+	return tok
+}
+
+func (p *schemaParser) errorf(tok schemaToken, msg string, args ...interface{}) error {
+	return fmt.Errorf("%d:%d: %s", tok.line, tok.column, fmt.Sprintf(msg, args...))
+}
+
+func (p *schemaParser) expect(text string) error {
+	if tok := p.next(); tok.text != text {
+		return p.errorf(tok, "expected %q but found %s", text, tok)
+	}
+	return nil
+}
+
+func (p *schemaParser) parseName() (schemaToken, error) {
+	tok := p.next()
+	if !tok.isWord() {
+		return tok, p.errorf(tok, "expected field name but found %s", tok)
+	}
+	return tok, nil
+}
+
+// parseGroup parses the fields of a group, which retain the order in which they
+// are declared.
+func (p *schemaParser) parseGroup() (*orderedGroup, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	group := new(orderedGroup)
+	for p.token.text != "}" {
+		if p.token.isEOF() {
+			return nil, p.errorf(p.token, "expected \"}\" but found %s", p.token)
+		}
+		name, node, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		if !group.add(name.text, node) {
+			return nil, p.errorf(name, "duplicate field %q", name.text)
+		}
+	}
+
+	p.next()
+	return group, nil
+}
+
+func (p *schemaParser) parseField() (name schemaToken, node Node, err error) {
+	repetition := p.next()
+	switch strings.ToLower(repetition.text) {
+	case "required", "optional", "repeated":
+	default:
+		return name, nil, p.errorf(repetition, "expected repetition type (required, optional or repeated) but found %s", repetition)
+	}
+
+	typ := p.next()
+	var physical Type
+	if !strings.EqualFold(typ.text, "group") {
+		if physical, err = p.parsePhysicalType(typ); err != nil {
+			return name, nil, err
+		}
+	}
+
+	if name, err = p.parseName(); err != nil {
+		return name, nil, err
+	}
+	annotation, err := p.parseAnnotation()
+	if err != nil {
+		return name, nil, err
+	}
+	id, err := p.parseFieldID()
+	if err != nil {
+		return name, nil, err
+	}
+
+	if physical == nil {
+		var group *orderedGroup
+		if group, err = p.parseGroup(); err != nil {
+			return name, nil, err
+		}
+		node, err = p.groupNodeOf(group, annotation)
+	} else {
+		if err := p.expect(";"); err != nil {
+			return name, nil, err
+		}
+		node, err = p.leafNodeOf(physical, annotation)
+	}
+	if err != nil {
+		return name, nil, err
+	}
+
+	switch strings.ToLower(repetition.text) {
+	case "optional":
+		node = Optional(node)
+	case "repeated":
+		node = Repeated(node)
+	}
+	if id != 0 {
+		node = FieldID(node, id)
+	}
+	return name, node, nil
+}
+
+func (p *schemaParser) parsePhysicalType(tok schemaToken) (Type, error) {
+	switch strings.ToLower(tok.text) {
+	case "boolean":
+		return BooleanType, nil
+	case "int32":
+		return Int32Type, nil
+	case "int64":
+		return Int64Type, nil
+	case "int96":
+		return Int96Type, nil
+	case "float":
+		return FloatType, nil
+	case "double":
+		return DoubleType, nil
+	case "binary":
+		return ByteArrayType, nil
+	case "fixed_len_byte_array":
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		length := p.next()
+		n, err := strconv.Atoi(length.text)
+		if err != nil || n <= 0 {
+			return nil, p.errorf(length, "invalid length of fixed_len_byte_array: %s", length)
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return FixedLenByteArrayType(n), nil
+	default:
+		return nil, p.errorf(tok, "expected group or physical type but found %s", tok)
+	}
+}
+
+func (p *schemaParser) parseFieldID() (int, error) {
+	if p.token.text != "=" {
+		return 0, nil
+	}
+	p.next()
+	tok := p.next()
+	id, err := strconv.ParseInt(tok.text, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, p.errorf(tok, "expected positive field id but found %s", tok)
+	}
+	return int(id), nil
+}
+
+// schemaAnnotation is the logical or converted type that annotates a field,
+// with its optional list of arguments which may be positional (e.g. MICROS) or
+// named (e.g. unit=MICROS).
+type schemaAnnotation struct {
+	token schemaToken
+	name  string
+	args  []schemaAnnotationArg
+}
+
+type schemaAnnotationArg struct {
+	token schemaToken
+	key   string
+	value string
+}
+
+func (p *schemaParser) parseAnnotation() (*schemaAnnotation, error) {
+	if p.token.text != "(" {
+		return nil, nil
+	}
+	p.next()
+
+	tok := p.next()
+	if !tok.isWord() {
+		return nil, p.errorf(tok, "expected logical type but found %s", tok)
+	}
+	a := &schemaAnnotation{token: tok, name: strings.ToUpper(tok.text)}
+
+	if p.token.text == "(" {
+		p.next()
+		for {
+			tok := p.next()
+			if !tok.isWord() {
+				return nil, p.errorf(tok, "expected argument of %s but found %s", a.name, tok)
+			}
+			arg := schemaAnnotationArg{token: tok, value: tok.text}
+			if p.token.text == "=" {
+				p.next()
+				value := p.next()
+				if !value.isWord() {
+					return nil, p.errorf(value, "expected value of argument %s but found %s", tok.text, value)
+				}
+				arg.key, arg.value = tok.text, value.text
+			}
+			a.args = append(a.args, arg)
+
+			if p.token.text != "," {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, p.expect(")")
+}
+
+func (p *schemaParser) groupNodeOf(group *orderedGroup, a *schemaAnnotation) (Node, error) {
+	if a == nil {
+		return group, nil
+	}
+	if err := p.checkArgs(a, 0); err != nil {
+		return nil, err
+	}
+
+	switch a.name {
+	case "LIST":
+		if list := group.ChildByName("list"); group.NumChildren() != 1 || list == nil || isLeaf(list) || !list.Repeated() || list.ChildByName("element") == nil {
+			return nil, p.errorf(a.token, "LIST group must contain a single repeated group named list with an element field")
+		}
+		return listNode{Group{"list": group.ChildByName("list")}}, nil
+	case "MAP":
+		keyValue := group.ChildByName("key_value")
+		if group.NumChildren() != 1 || keyValue == nil || isLeaf(keyValue) || !keyValue.Repeated() {
+			return nil, p.errorf(a.token, "MAP group must contain a single repeated group named key_value")
+		}
+		if key, value := keyValue.ChildByName("key"), keyValue.ChildByName("value"); key == nil || !key.Required() || value == nil {
+			return nil, p.errorf(a.token, "key_value group of MAP must contain a required key field and a value field")
+		}
+		return mapNode{Group{"key_value": keyValue}}, nil
+	case "MAP_KEY_VALUE":
+		return group, nil
+	default:
+		return nil, p.errorf(a.token, "%s cannot annotate groups", a.name)
+	}
+}
+
+func (p *schemaParser) leafNodeOf(physical Type, a *schemaAnnotation) (Node, error) {
+	if a == nil {
+		return Leaf(physical), nil
+	}
+
+	// Check that the annotation takes n arguments and applies to one of the
+	// given physical types.
+	check := func(n int, kinds ...Kind) error {
+		if err := p.checkArgs(a, n); err != nil {
+			return err
+		}
+		for _, kind := range kinds {
+			if physical.Kind() == kind {
+				return nil
+			}
+		}
+		return p.errorf(a.token, "%s cannot annotate columns of type %s", a.name, physical)
+	}
+
+	checkLength := func(n int) error {
+		if err := check(0, FixedLenByteArray); err != nil {
+			return err
+		}
+		if physical.Length() != n {
+			return p.errorf(a.token, "%s must annotate columns of type FIXED_LEN_BYTE_ARRAY(%d) but found %s", a.name, n, physical)
+		}
+		return nil
+	}
+
+	switch a.name {
+	case "STRING", "UTF8":
+		return String(), check(0, ByteArray)
+	case "ENUM":
+		return Enum(), check(0, ByteArray)
+	case "JSON":
+		return JSON(), check(0, ByteArray)
+	case "BSON":
+		return BSON(), check(0, ByteArray)
+	case "UUID":
+		return UUID(), checkLength(16)
+	case "FLOAT16":
+		return Float16(), checkLength(2)
+	case "INTERVAL":
+		return Leaf(IntervalType), checkLength(12)
+	case "DATE":
+		return Date(), check(0, Int32)
+	case "DECIMAL":
+		return p.decimalNodeOf(physical, a)
+	case "INT", "INTEGER":
+		return p.intNodeOf(physical, a)
+	case "INT_8", "INT_16", "INT_32", "INT_64", "UINT_8", "UINT_16", "UINT_32", "UINT_64":
+		if err := p.checkArgs(a, 0); err != nil {
+			return nil, err
+		}
+		signed := !strings.HasPrefix(a.name, "U")
+		bitWidth := a.name[strings.IndexByte(a.name, '_')+1:]
+		return p.intNodeOf(physical, &schemaAnnotation{
+			token: a.token,
+			name:  a.name,
+			args:  []schemaAnnotationArg{{value: bitWidth}, {value: strconv.FormatBool(signed)}},
+		})
+	case "TIME", "TIMESTAMP":
+		return p.timeNodeOf(physical, a)
+	case "TIME_MILLIS", "TIME_MICROS", "TIMESTAMP_MILLIS", "TIMESTAMP_MICROS":
+		if err := p.checkArgs(a, 0); err != nil {
+			return nil, err
+		}
+		i := strings.IndexByte(a.name, '_')
+		return p.timeNodeOf(physical, &schemaAnnotation{
+			token: a.token,
+			name:  a.name[:i],
+			args:  []schemaAnnotationArg{{value: a.name[i+1:]}, {value: "true"}},
+		})
+	default:
+		return nil, p.errorf(a.token, "unsupported logical type %s", a.name)
+	}
+}
+
+func (p *schemaParser) checkArgs(a *schemaAnnotation, n int) error {
+	if len(a.args) != n {
+		return p.errorf(a.token, "%s expects %d arguments but found %d", a.name, n, len(a.args))
+	}
+	return nil
+}
+
+func (p *schemaParser) intArgs(a *schemaAnnotation) ([2]int, error) {
+	var values [2]int
+	if err := p.checkArgs(a, 2); err != nil {
+		return values, err
+	}
+	for i, arg := range a.args {
+		v, err := strconv.Atoi(arg.value)
+		if err != nil || arg.key != "" {
+			return values, p.errorf(arg.token, "invalid argument of %s: %q", a.name, arg.value)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func (p *schemaParser) decimalNodeOf(physical Type, a *schemaAnnotation) (Node, error) {
+	args, err := p.intArgs(a)
+	if err != nil {
+		return nil, err
+	}
+	scale, precision := args[0], args[1]
+	if scale > precision {
+		scale, precision = precision, scale
+	}
+
+	switch physical.Kind() {
+	case Int32, Int64, FixedLenByteArray, ByteArray:
+	default:
+		return nil, p.errorf(a.token, "DECIMAL cannot annotate columns of type %s", physical)
+	}
+	if max := maxDecimalPrecision(physical); precision < 1 || (max != 0 && precision > max) {
+		return nil, p.errorf(a.token, "DECIMAL precision %d is out of range for the %s type", precision, physical)
+	}
+	if scale < 0 {
+		return nil, p.errorf(a.token, "DECIMAL scale %d is out of range for precision %d", scale, precision)
+	}
+	return Decimal(scale, precision, physical), nil
+}
+
+func (p *schemaParser) intNodeOf(physical Type, a *schemaAnnotation) (Node, error) {
+	if err := p.checkArgs(a, 2); err != nil {
+		return nil, err
+	}
+	bitWidth, err := strconv.Atoi(a.args[0].value)
+	if err != nil {
+		return nil, p.errorf(a.args[0].token, "invalid bit width of %s: %q", a.name, a.args[0].value)
+	}
+	signed, err := strconv.ParseBool(a.args[1].value)
+	if err != nil {
+		return nil, p.errorf(a.args[1].token, "invalid signedness of %s: %q", a.name, a.args[1].value)
+	}
+
+	switch {
+	case bitWidth == 8 || bitWidth == 16 || bitWidth == 32:
+		if physical.Kind() != Int32 {
+			return nil, p.errorf(a.token, "%d bits integers must annotate columns of type INT32 but found %s", bitWidth, physical)
+		}
+	case bitWidth == 64:
+		if physical.Kind() != Int64 {
+			return nil, p.errorf(a.token, "64 bits integers must annotate columns of type INT64 but found %s", physical)
+		}
+	default:
+		return nil, p.errorf(a.token, "invalid bit width of %s: %d", a.name, bitWidth)
+	}
+
+	if signed {
+		return Int(bitWidth), nil
+	}
+	return Uint(bitWidth), nil
+}
+
+func (p *schemaParser) timeNodeOf(physical Type, a *schemaAnnotation) (Node, error) {
+	if err := p.checkArgs(a, 2); err != nil {
+		return nil, err
+	}
+
+	var unit TimeUnit
+	var adjusted, hasAdjusted bool
+
+	for i, arg := range a.args {
+		key := strings.ToLower(arg.key)
+		if key == "" {
+			key = [2]string{"unit", "isadjustedtoutc"}[i]
+		}
+		switch key {
+		case "unit":
+			switch strings.ToUpper(arg.value) {
+			case "MILLIS":
+				unit = Millisecond
+			case "MICROS":
+				unit = Microsecond
+			case "NANOS":
+				unit = Nanosecond
+			default:
+				return nil, p.errorf(arg.token, "invalid time unit of %s: %q", a.name, arg.value)
+			}
+		case "isadjustedtoutc":
+			v, err := strconv.ParseBool(arg.value)
+			if err != nil {
+				return nil, p.errorf(arg.token, "invalid isAdjustedToUTC argument of %s: %q", a.name, arg.value)
+			}
+			adjusted, hasAdjusted = v, true
+		default:
+			return nil, p.errorf(arg.token, "unknown argument of %s: %s", a.name, arg.key)
+		}
+	}
+	if unit == nil || !hasAdjusted {
+		return nil, p.errorf(a.token, "%s requires a unit and isAdjustedToUTC argument", a.name)
+	}
+
+	kind := Int64
+	if a.name == "TIME" && unit == Millisecond {
+		kind = Int32
+	}
+	if physical.Kind() != kind {
+		timeUnit := unit.TimeUnit()
+		return nil, p.errorf(a.token, "%s(%s) must annotate columns of type %s but found %s", a.name, timeUnit.String(), kind, physical)
+	}
+
+	if a.name == "TIME" {
+		return Leaf(&timeType{IsAdjustedToUTC: adjusted, Unit: unit.TimeUnit()}), nil
+	}
+	return Leaf(&timestampType{IsAdjustedToUTC: adjusted, Unit: unit.TimeUnit()}), nil
+}
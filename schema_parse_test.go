@@ -0,0 +1,329 @@
+package parquet_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+func TestParseSchemaRoundTrip(t *testing.T) {
+	tests := []string{
+		`message Test {
+	required boolean on;
+}`,
+
+		`message Test {
+	required binary bson (BSON);
+	required binary enum (ENUM);
+	required fixed_len_byte_array(2) half (FLOAT16);
+	required binary json (JSON);
+	optional binary name (STRING);
+	repeated binary tags (STRING);
+	required fixed_len_byte_array(16) uuid (UUID);
+}`,
+
+		`message Test {
+	required int32 a (INT(8,true));
+	required int32 b (INT(16,false));
+	required int32 c (INT(32,true));
+	required int64 d (INT(64,false));
+	required float e;
+	required double f;
+	required int96 g;
+}`,
+
+		`message Test {
+	required int32 cost (DECIMAL(2,9));
+	required int64 price (DECIMAL(0,18));
+	required fixed_len_byte_array(16) total (DECIMAL(10,38));
+	required binary value (DECIMAL(3,60));
+}`,
+
+		`message Test {
+	required int32 date (DATE);
+	required int64 micros (TIME(isAdjustedToUTC=false,unit=MICROS));
+	required int32 millis (TIME(isAdjustedToUTC=true,unit=MILLIS));
+	required int64 nanos (TIME(isAdjustedToUTC=true,unit=NANOS));
+	optional int64 timestamp (TIMESTAMP(isAdjustedToUTC=false,unit=NANOS));
+}`,
+
+		`message Test {
+	required int64 id = 1;
+	optional group names (LIST) = 2 {
+		repeated group list {
+			required binary element (STRING);
+		}
+	}
+	required group pairs (MAP) = 3 {
+		repeated group key_value {
+			required binary key (STRING);
+			optional group value {
+				optional int64 count (INT(64,true)) = 5;
+			}
+		}
+	}
+}`,
+
+		`message {
+	required int64 id;
+}`,
+	}
+
+	for _, test := range tests {
+		t.Run("", func(t *testing.T) {
+			schema, err := parquet.ParseSchema(test)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := schema.String(); s != test {
+				t.Errorf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", test, s)
+			}
+		})
+	}
+}
+
+func TestParseSchemaCompatibility(t *testing.T) {
+	tests := []struct {
+		scenario string
+		input    string
+		print    string
+	}{
+		{
+			scenario: "parquet-mr annotations",
+			input: `message spark_schema {
+  required int64 time (TIMESTAMP(MICROS,true));
+  optional int64 local (TIMESTAMP(NANOS,false));
+  required int32 of_day (TIME(MILLIS,true));
+  required fixed_len_byte_array(16) amount (DECIMAL(38,2));
+}`,
+			print: `message spark_schema {
+	required int64 time (TIMESTAMP(isAdjustedToUTC=true,unit=MICROS));
+	optional int64 local (TIMESTAMP(isAdjustedToUTC=false,unit=NANOS));
+	required int32 of_day (TIME(isAdjustedToUTC=true,unit=MILLIS));
+	required fixed_len_byte_array(16) amount (DECIMAL(2,38));
+}`,
+		},
+
+		{
+			scenario: "converted types",
+			input: `message m {
+	required binary name (UTF8);
+	required int32 small (INT_8);
+	required int64 count (UINT_64);
+	required int64 created (TIMESTAMP_MILLIS);
+	required int32 at (TIME_MILLIS);
+	required group entries (MAP) {
+		repeated group key_value (MAP_KEY_VALUE) {
+			required binary key (UTF8);
+			required int32 value;
+		}
+	}
+}`,
+			print: `message m {
+	required binary name (STRING);
+	required int32 small (INT(8,true));
+	required int64 count (INT(64,false));
+	required int64 created (TIMESTAMP(isAdjustedToUTC=true,unit=MILLIS));
+	required int32 at (TIME(isAdjustedToUTC=true,unit=MILLIS));
+	required group entries (MAP) {
+		repeated group key_value {
+			required binary key (STRING);
+			required int32 value;
+		}
+	}
+}`,
+		},
+
+		{
+			scenario: "free formatting",
+			input:    "MESSAGE m{OPTIONAL BINARY a(STRING)=7;required group b{required INT64 c;}}",
+			print: `message m {
+	optional binary a (STRING) = 7;
+	required group b {
+		required int64 c;
+	}
+}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			schema, err := parquet.ParseSchema(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s := schema.String(); s != test.print {
+				t.Errorf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", test.print, s)
+			}
+		})
+	}
+}
+
+func TestParseSchemaErrors(t *testing.T) {
+	tests := []struct {
+		scenario string
+		input    string
+		err      string
+	}{
+		{
+			scenario: "empty input",
+			input:    "",
+			err:      `1:1: expected "message" but found end of input`,
+		},
+
+		{
+			scenario: "missing repetition",
+			input:    "message m {\n\tint64 id;\n}",
+			err:      `2:2: expected repetition type (required, optional or repeated) but found "int64"`,
+		},
+
+		{
+			scenario: "unknown physical type",
+			input:    "message m {\n\trequired int128 id;\n}",
+			err:      `2:11: expected group or physical type but found "int128"`,
+		},
+
+		{
+			scenario: "missing semicolon",
+			input:    "message m {\n\trequired int64 id\n}",
+			err:      `3:1: expected ";" but found "}"`,
+		},
+
+		{
+			scenario: "unterminated group",
+			input:    "message m {\n\trequired int64 id;\n",
+			err:      `3:1: expected "}" but found end of input`,
+		},
+
+		{
+			scenario: "trailing input",
+			input:    "message m {}\n}",
+			err:      `2:1: unexpected "}" after the end of the message`,
+		},
+
+		{
+			scenario: "duplicate field",
+			input:    "message m {\n\trequired int64 id;\n\toptional int32 id;\n}",
+			err:      `3:17: duplicate field "id"`,
+		},
+
+		{
+			scenario: "annotation on the wrong type",
+			input:    "message m {\n\trequired int64 name (STRING);\n}",
+			err:      `2:23: STRING cannot annotate columns of type INT64`,
+		},
+
+		{
+			scenario: "unsupported annotation",
+			input:    "message m {\n\trequired int64 name (VARIANT);\n}",
+			err:      `2:23: unsupported logical type VARIANT`,
+		},
+
+		{
+			scenario: "decimal precision out of range",
+			input:    "message m {\n\trequired int32 cost (DECIMAL(10,2));\n}",
+			err:      `2:23: DECIMAL precision 10 is out of range for the INT32 type`,
+		},
+
+		{
+			scenario: "invalid time unit",
+			input:    "message m {\n\trequired int64 t (TIMESTAMP(SECONDS,true));\n}",
+			err:      `2:30: invalid time unit of TIMESTAMP: "SECONDS"`,
+		},
+
+		{
+			scenario: "invalid field id",
+			input:    "message m {\n\trequired int64 id = -1;\n}",
+			err:      `2:22: expected positive field id but found "-1"`,
+		},
+
+		{
+			scenario: "invalid list",
+			input:    "message m {\n\trequired group l (LIST) {\n\t\trequired int64 x;\n\t}\n}",
+			err:      `2:20: LIST group must contain a single repeated group named list with an element field`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			_, err := parquet.ParseSchema(test.input)
+			if err == nil {
+				t.Fatal("expected an error but the schema was parsed")
+			}
+			if err.Error() != test.err {
+				t.Errorf("wrong error:\nwant = %s\ngot  = %s", test.err, err)
+			}
+		})
+	}
+}
+
+func TestParseSchemaWriteFile(t *testing.T) {
+	schema, err := parquet.ParseSchema(`message event {
+	required int64 id;
+	optional binary name (STRING);
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, schema)
+	if err := writer.WriteMap(map[string]interface{}{"id": int64(1), "name": "one"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := parquet.NewSchema("event", f.Root()).String(); s != schema.String() {
+		t.Errorf("wrong schema read from the file:\nwant:\n%s\ngot:\n%s", schema, s)
+	}
+}
+
+func TestParseSchemaFieldOrder(t *testing.T) {
+	text := `message event {
+	required int64 time;
+	required int64 id;
+	optional group location {
+		required double lon;
+		required double lat;
+	}
+	optional group attributes (MAP) {
+		repeated group key_value {
+			required binary key (STRING);
+			optional binary value (STRING);
+		}
+	}
+}`
+
+	schema, err := parquet.ParseSchema(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := schema.String(); s != text {
+		t.Errorf("parsed schema does not round-trip through Print:\nwant:\n%s\ngot:\n%s", text, s)
+	}
+
+	// The columns of the schema are in the order of declaration of the fields.
+	row := schema.Deconstruct(nil, map[string]interface{}{
+		"time":     int64(1),
+		"id":       int64(2),
+		"location": map[string]interface{}{"lon": 3.0, "lat": 4.0},
+	})
+	want := parquet.Row{
+		parquet.ValueOf(int64(1)).Level(0, 0, 0),
+		parquet.ValueOf(int64(2)).Level(0, 0, 1),
+		parquet.ValueOf(3.0).Level(0, 1, 2),
+		parquet.ValueOf(4.0).Level(0, 1, 3),
+		parquet.Value{}.Level(0, 0, 4),
+		parquet.Value{}.Level(0, 0, 5),
+	}
+	if !row.Equal(want) {
+		t.Errorf("wrong deconstructed row:\nwant = %+v\ngot  = %+v", want, row)
+	}
+}
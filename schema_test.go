@@ -1,6 +1,7 @@
 package parquet_test
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/segmentio/parquet-go"
@@ -52,6 +53,21 @@ func TestSchemaOf(t *testing.T) {
 		required binary first_name (STRING);
 		required binary last_name (STRING);
 	}
+}`,
+		},
+
+		{
+			value: new(struct {
+				ID    int64 `parquet:"id,id=1"`
+				Inner struct {
+					Name string `parquet:"name,id=3"`
+				} `parquet:"inner,optional,id=2"`
+			}),
+			print: `message {
+	required int64 id (INT(64,true)) = 1;
+	optional group inner = 2 {
+		required binary name (STRING) = 3;
+	}
 }`,
 		},
 	}
@@ -66,3 +82,49 @@ func TestSchemaOf(t *testing.T) {
 		})
 	}
 }
+
+func TestFieldIDRoundTrip(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,id=1"`
+		Name string `parquet:"name"`
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	if err := writer.Write(&Row{ID: 1, Name: "Luke"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := f.Root().Column("id").ID(); id != 1 {
+		t.Errorf("wrong field id of column id: want=1 got=%d", id)
+	}
+	if id := f.Root().Column("name").ID(); id != 0 {
+		t.Errorf("wrong field id of column name: want=0 got=%d", id)
+	}
+}
+
+// externalNode hides the optional ID method of the node it wraps, like
+// implementations of parquet.Node declared outside of the package.
+type externalNode struct{ parquet.Node }
+
+func TestFieldIDOfExternalNode(t *testing.T) {
+	schema := parquet.NewSchema("test", parquet.Group{
+		"a": parquet.FieldID(externalNode{parquet.Int(64)}, 1),
+		"b": externalNode{parquet.FieldID(parquet.Int(64), 2)},
+	})
+
+	const want = `message test {
+	required int64 a (INT(64,true)) = 1;
+	required int64 b (INT(64,true));
+}`
+	if s := schema.String(); s != want {
+		t.Errorf("\nexpected:\n\n%s\n\nfound:\n\n%s\n", want, s)
+	}
+}
@@ -0,0 +1,246 @@
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// SortingWriter is a type similar to Writer which ensures that the rows of the
+// output file are sorted, even when they do not all fit in memory.
+//
+// Rows written to a SortingWriter are buffered in memory until a configured
+// row count is reached, at which point they are sorted and spilled to a
+// temporary parquet file as a new row group. When the writer is closed, the
+// row groups of the temporary file are merged and the sorted rows are written
+// to the output, which cuts row groups at the limits set by MaxRowsPerRowGroup
+// and TargetRowGroupSize.
+//
+// By default, the temporary file is created in the default directory for
+// temporary files. Programs which configure a page buffer pool with the
+// ColumnPageBuffers option get the temporary file allocated from this pool
+// instead, for example:
+//
+//	writer := parquet.NewSortingWriter(output, 100e3,
+//		parquet.ColumnPageBuffers(parquet.NewFileBufferPool("/mnt/scratch", "sort.*")),
+//		parquet.SortingColumns(
+//			parquet.Ascending("timestamp"),
+//		),
+//	)
+//
+// The sorting order is defined by passing a SortingColumns option to the
+// constructor.
+type SortingWriter struct {
+	rows         *Buffer
+	output       *Writer
+	config       *WriterConfig
+	sortRowCount int64
+
+	spill struct {
+		pool   PageBufferPool
+		buffer io.ReadWriter
+		output offsetTrackingWriter
+		writer *Writer
+	}
+}
+
+// NewSortingWriter constructs a new sorting writer which writes a parquet file
+// where rows are sorted by the sorting columns configured in options.
+//
+// The sortRowCount argument defines the maximum number of rows buffered in
+// memory before they are sorted and spilled to temporary storage.
+//
+// The function panics if the writer configuration is invalid.
+func NewSortingWriter(output io.Writer, sortRowCount int64, options ...WriterOption) *SortingWriter {
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+	if sortRowCount <= 0 {
+		panic(errorInvalidOptionValue("parquet.NewSortingWriter.sortRowCount", sortRowCount))
+	}
+	w := &SortingWriter{
+		output:       NewWriter(output, config),
+		config:       config,
+		sortRowCount: sortRowCount,
+	}
+	if config.Schema != nil {
+		w.configure(config.Schema)
+	}
+	return w
+}
+
+func (w *SortingWriter) configure(schema *Schema) {
+	if schema != nil {
+		w.rows = NewBuffer(schema, SortingColumns(w.config.SortingColumns...))
+		if w.output.schema == nil {
+			w.output.configure(schema)
+		}
+		// All the rows written to the output are sorted, so every row group
+		// that it flushes is sorted as well.
+		w.output.writer.sortingColumns = w.rows.SortingColumns()
+	}
+}
+
+// Close must be called after all rows were written to the writer in order to
+// merge the sorted rows into the output and write the parquet footer.
+func (w *SortingWriter) Close() error {
+	defer w.release()
+
+	if w.rows != nil {
+		if w.spill.writer == nil {
+			// All the rows fit in memory, there is no need to go through the
+			// temporary file to sort them.
+			sort.Sort(w.rows)
+			if err := w.writeSorted(w.rows.Rows()); err != nil {
+				return err
+			}
+		} else {
+			if err := w.sortAndSpill(); err != nil {
+				return err
+			}
+			if err := w.merge(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.output.Close()
+}
+
+// Write writes a row held in a Go value to the writer.
+func (w *SortingWriter) Write(row interface{}) error {
+	if w.rows == nil {
+		w.configure(SchemaOf(row))
+	}
+	if err := w.rows.Write(row); err != nil {
+		return err
+	}
+	return w.spillIfFull()
+}
+
+// WriteRow writes a parquet row to the writer.
+func (w *SortingWriter) WriteRow(row Row) error {
+	if w.rows == nil {
+		return ErrRowGroupSchemaMissing
+	}
+	if err := w.rows.WriteRow(row); err != nil {
+		return err
+	}
+	return w.spillIfFull()
+}
+
+// WriteRows writes parquet rows to the writer, returning the number of rows
+// written.
+func (w *SortingWriter) WriteRows(rows []Row) (int, error) {
+	for i, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			return i, err
+		}
+	}
+	return len(rows), nil
+}
+
+// Schema returns the schema of rows written by w.
+//
+// The returned value will be nil if no schema has yet been configured on w.
+func (w *SortingWriter) Schema() *Schema { return w.output.Schema() }
+
+func (w *SortingWriter) spillIfFull() error {
+	if w.rows.NumRows() >= w.sortRowCount {
+		return w.sortAndSpill()
+	}
+	return nil
+}
+
+// sortAndSpill sorts the rows buffered in memory and writes them as a new row
+// group of the temporary file.
+func (w *SortingWriter) sortAndSpill() error {
+	if w.rows.NumRows() == 0 {
+		return nil
+	}
+	defer w.rows.Reset()
+
+	if w.spill.writer == nil {
+		config := *w.config
+		config.BloomFilters = nil
+		config.KeyValueMetadata = nil
+		config.Schema = w.rows.Schema()
+		w.spill.pool = config.ColumnPageBuffers
+		if w.spill.pool == PageBufferPool(&defaultPageBufferPool) {
+			w.spill.pool = NewFileBufferPool(os.TempDir(), "parquet-sort.*")
+		}
+		w.spill.buffer = w.spill.pool.GetPageBuffer()
+		w.spill.output.Reset(w.spill.buffer)
+		w.spill.writer = NewWriter(&w.spill.output, &config)
+	}
+
+	sort.Sort(w.rows)
+	_, err := w.spill.writer.WriteRowGroup(w.rows)
+	return err
+}
+
+// merge reads back the row groups of the temporary file and writes their rows
+// to the output in sorted order.
+func (w *SortingWriter) merge() error {
+	if err := w.spill.writer.Close(); err != nil {
+		return err
+	}
+
+	spill, err := spillReaderAt(w.spill.buffer)
+	if err != nil {
+		return err
+	}
+
+	file, err := OpenFile(spill, w.spill.output.offset)
+	if err != nil {
+		return fmt.Errorf("opening sorted rows spilled to temporary storage: %w", err)
+	}
+
+	rowGroups := make([]RowGroup, file.NumRowGroups())
+	for i := range rowGroups {
+		rowGroups[i] = file.RowGroup(i)
+	}
+
+	merged, err := MergeRowGroups(rowGroups, w.output.Schema(), SortingColumns(w.rows.SortingColumns()...))
+	if err != nil {
+		return err
+	}
+	return w.writeSorted(merged.Rows())
+}
+
+// writeSorted writes sorted rows to the output one batch at a time rather than
+// as a whole row group, so the writer cuts row groups at its configured limits.
+func (w *SortingWriter) writeSorted(rows Rows) error {
+	_, err := copyRowBatches(w.output, rows)
+	return err
+}
+
+func (w *SortingWriter) release() {
+	if w.spill.buffer != nil {
+		w.spill.pool.PutPageBuffer(w.spill.buffer)
+		w.spill.buffer = nil
+		w.spill.writer = nil
+	}
+}
+
+// spillReaderAt returns an io.ReaderAt exposing the content of a buffer
+// obtained from a PageBufferPool.
+func spillReaderAt(buffer io.ReadWriter) (io.ReaderAt, error) {
+	switch b := buffer.(type) {
+	case io.ReaderAt:
+		return b, nil
+	case *bytes.Buffer:
+		return bytes.NewReader(b.Bytes()), nil
+	default:
+		data, err := io.ReadAll(b)
+		return bytes.NewReader(data), err
+	}
+}
+
+var (
+	_ RowWriter      = (*SortingWriter)(nil)
+	_ RowBatchWriter = (*SortingWriter)(nil)
+)
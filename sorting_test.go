@@ -0,0 +1,138 @@
+package parquet_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/segmentio/parquet-go"
+)
+
+func TestSortingWriter(t *testing.T) {
+	type Row struct {
+		Key   int64  `parquet:"key"`
+		Value string `parquet:"value"`
+	}
+
+	prng := rand.New(rand.NewSource(0))
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{Key: prng.Int63n(100), Value: string(rune('a' + i%26))}
+	}
+
+	want := make([]Row, len(rows))
+	copy(want, rows)
+	sort.SliceStable(want, func(i, j int) bool { return want[i].Key < want[j].Key })
+
+	for _, sortRowCount := range []int64{1, 99, 100, 1000, 5000} {
+		tmpdir := t.TempDir()
+		buffer := new(bytes.Buffer)
+
+		writer := parquet.NewSortingWriter(buffer, sortRowCount,
+			parquet.ColumnPageBuffers(parquet.NewFileBufferPool(tmpdir, "sort.*")),
+			parquet.SortingColumns(parquet.Ascending("key")),
+			parquet.MaxRowsPerRowGroup(300),
+		)
+		for i := range rows {
+			if err := writer.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if entries, err := os.ReadDir(tmpdir); err != nil {
+			t.Fatal(err)
+		} else if len(entries) != 0 {
+			t.Errorf("sortRowCount=%d: %d temporary files were not removed", sortRowCount, len(entries))
+		}
+
+		f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := f.NumRowGroups(); n != 4 {
+			t.Fatalf("sortRowCount=%d: wrong number of row groups: want=4 got=%d", sortRowCount, n)
+		}
+		for i := 0; i < f.NumRowGroups(); i++ {
+			rowGroup := f.RowGroup(i)
+			if n := rowGroup.NumRows(); n > 300 {
+				t.Fatalf("sortRowCount=%d: row group %d has too many rows: %d", sortRowCount, i, n)
+			}
+			if sorting := rowGroup.SortingColumns(); len(sorting) != 1 || sorting[0].Path()[0] != "key" {
+				t.Fatalf("sortRowCount=%d: wrong sorting columns of row group %d: %v", sortRowCount, i, sorting)
+			}
+		}
+
+		reader := parquet.NewReader(bytes.NewReader(buffer.Bytes()))
+		found := make([]Row, 0, len(rows))
+		for {
+			row := Row{}
+			if err := reader.Read(&row); err != nil {
+				if err != io.EOF {
+					t.Fatal(err)
+				}
+				break
+			}
+			found = append(found, row)
+		}
+
+		if len(found) != len(want) {
+			t.Fatalf("sortRowCount=%d: wrong number of rows: want=%d got=%d", sortRowCount, len(want), len(found))
+		}
+		if !sort.SliceIsSorted(found, func(i, j int) bool { return found[i].Key < found[j].Key }) {
+			t.Fatalf("sortRowCount=%d: rows are not sorted", sortRowCount)
+		}
+		counts := make(map[Row]int, len(want))
+		for _, row := range want {
+			counts[row]++
+		}
+		for _, row := range found {
+			counts[row]--
+		}
+		for row, count := range counts {
+			if count != 0 {
+				t.Fatalf("sortRowCount=%d: row %+v was found %d times less than expected", sortRowCount, row, count)
+			}
+		}
+	}
+}
+
+func TestSortingWriterSpillToTemporaryFile(t *testing.T) {
+	type Row struct {
+		Key int64 `parquet:"key"`
+	}
+
+	tmpdir := t.TempDir()
+	t.Setenv("TMPDIR", tmpdir)
+
+	writer := parquet.NewSortingWriter(new(bytes.Buffer), 10,
+		parquet.SortingColumns(parquet.Descending("key")),
+	)
+	for i := 0; i < 25; i++ {
+		if err := writer.Write(&Row{Key: int64(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := os.ReadDir(tmpdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("wrong number of temporary files: want=1 got=%d", len(entries))
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if entries, err := os.ReadDir(tmpdir); err != nil {
+		t.Fatal(err)
+	} else if len(entries) != 0 {
+		t.Errorf("%d temporary files were not removed", len(entries))
+	}
+}
@@ -0,0 +1,218 @@
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/segmentio/parquet-go/deprecated"
+	"github.com/segmentio/parquet-go/format"
+)
+
+var (
+	timeTimeType     = reflect.TypeOf(time.Time{})
+	timeDurationType = reflect.TypeOf(time.Duration(0))
+	intervalGoType   = reflect.TypeOf(Interval{})
+)
+
+// Interval is the go representation of values of the INTERVAL type.
+type Interval struct {
+	Months uint32
+	Days   uint32
+	Millis uint32
+}
+
+func makeIntervalValue(i Interval) Value {
+	b := make([]byte, 12)
+	binary.LittleEndian.PutUint32(b[0:], i.Months)
+	binary.LittleEndian.PutUint32(b[4:], i.Days)
+	binary.LittleEndian.PutUint32(b[8:], i.Millis)
+	return makeValueBytes(FixedLenByteArray, b)
+}
+
+func intervalOf(b []byte) (Interval, error) {
+	if len(b) != 12 {
+		return Interval{}, fmt.Errorf("cannot decode INTERVAL value of length %d", len(b))
+	}
+	return Interval{
+		Months: binary.LittleEndian.Uint32(b[0:]),
+		Days:   binary.LittleEndian.Uint32(b[4:]),
+		Millis: binary.LittleEndian.Uint32(b[8:]),
+	}, nil
+}
+
+const secondsPerDay = 24 * 60 * 60
+
+// ErrTimestampOverflow is an error returned by writers when a time.Time value
+// is outside of the range of timestamps that a column can represent, which is
+// the years 1678 to 2262 for timestamps in nanoseconds. Conversions return it
+// as well when a time value does not fit in the unit of the target column.
+var ErrTimestampOverflow = errors.New("time value exceeds the range of the timestamp column")
+
+var (
+	minNanoTime  = time.Unix(0, math.MinInt64)
+	maxNanoTime  = time.Unix(0, math.MaxInt64)
+	minMicroTime = time.UnixMicro(math.MinInt64)
+	maxMicroTime = time.UnixMicro(math.MaxInt64)
+	minMilliTime = time.UnixMilli(math.MinInt64)
+	maxMilliTime = time.UnixMilli(math.MaxInt64)
+)
+
+// timestampRangeOf returns the range of times which can be represented by
+// timestamps in the given unit.
+func timestampRangeOf(unit format.TimeUnit) (min, max time.Time) {
+	switch {
+	case unit.Millis != nil:
+		return minMilliTime, maxMilliTime
+	case unit.Micros != nil:
+		return minMicroTime, maxMicroTime
+	default:
+		return minNanoTime, maxNanoTime
+	}
+}
+
+// wallClock returns a time in loc which has the same date and clock as t in its
+// own location.
+func wallClock(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return time.Date(year, month, day, hour, min, sec, t.Nanosecond(), loc)
+}
+
+func isTimestampType(t Type) bool {
+	lt := t.LogicalType()
+	return lt != nil && lt.Timestamp != nil
+}
+
+// unixTimestamp returns the number of units elapsed between the unix epoch and t.
+func unixTimestamp(t time.Time, unit format.TimeUnit) int64 {
+	switch {
+	case unit.Millis != nil:
+		return t.UnixMilli()
+	case unit.Micros != nil:
+		return t.UnixMicro()
+	default:
+		return t.UnixNano()
+	}
+}
+
+// unixTime is the inverse of unixTimestamp, the returned time is in UTC.
+func unixTime(v int64, unit format.TimeUnit) time.Time {
+	switch {
+	case unit.Millis != nil:
+		return time.UnixMilli(v).UTC()
+	case unit.Micros != nil:
+		return time.UnixMicro(v).UTC()
+	default:
+		return time.Unix(0, v).UTC()
+	}
+}
+
+// makeTimestampValue converts t to a value of the TIMESTAMP logical type. When
+// the timestamp is not adjusted to UTC, the value records the date and clock of
+// t regardless of its time zone.
+//
+// The function panics with a *valueError wrapping ErrTimestampOverflow if t
+// cannot be represented in nanoseconds.
+func makeTimestampValue(timestamp *format.TimestampType, t time.Time) Value {
+	if !timestamp.IsAdjustedToUTC {
+		t = wallClock(t, time.UTC)
+	}
+	if timestamp.Unit.Nanos != nil && (t.Before(minNanoTime) || t.After(maxNanoTime)) {
+		panic(&valueError{fmt.Errorf("%w: %s is not representable in nanoseconds", ErrTimestampOverflow, t)})
+	}
+	return makeValueInt64(unixTimestamp(t, timestamp.Unit))
+}
+
+// timestampOf is the inverse of makeTimestampValue. Timestamps are returned in
+// UTC; those which are not adjusted to UTC have the date and clock that were
+// written, the time zone to interpret them in is left to the program.
+func timestampOf(timestamp *format.TimestampType, v int64) time.Time {
+	return unixTime(v, timestamp.Unit)
+}
+
+// The julian day number of the unix epoch, INT96 timestamps are made of the
+// number of nanoseconds within the day, followed by the julian day.
+const julianDayOfUnixEpoch = 2440588
+
+func int96OfTime(t time.Time) deprecated.Int96 {
+	sec := t.Unix()
+	days := sec / secondsPerDay
+	if sec%secondsPerDay < 0 {
+		days--
+	}
+	nanos := uint64((sec-days*secondsPerDay)*int64(time.Second) + int64(t.Nanosecond()))
+	return deprecated.Int96{uint32(nanos), uint32(nanos >> 32), uint32(days + julianDayOfUnixEpoch)}
+}
+
+func timeOfInt96(i deprecated.Int96) time.Time {
+	days := int64(i[2]) - julianDayOfUnixEpoch
+	nanos := int64(uint64(i[1])<<32 | uint64(i[0]))
+	return time.Unix(days*secondsPerDay, nanos).UTC()
+}
+
+// makeDateValue converts the date of t in its own location to the number of days
+// since the unix epoch.
+func makeDateValue(t time.Time) Value {
+	t = wallClock(t, time.UTC).Truncate(secondsPerDay * time.Second)
+	return makeValueInt32(int32(t.Unix() / secondsPerDay))
+}
+
+func dateOf(v int32) time.Time {
+	return time.Unix(int64(v)*secondsPerDay, 0).UTC()
+}
+
+func makeTimeValue(typ *format.TimeType, d time.Duration) Value {
+	d /= timeUnitDurationOf(typ.Unit)
+	if typ.Unit.Millis != nil {
+		return makeValueInt32(int32(d))
+	}
+	return makeValueInt64(int64(d))
+}
+
+func timeOf(typ *format.TimeType, v Value) time.Duration {
+	d := time.Duration(v.Int64())
+	if v.Kind() == Int32 {
+		d = time.Duration(v.Int32())
+	}
+	return d * timeUnitDurationOf(typ.Unit)
+}
+
+// parseTimeArgs parses the arguments of the timestamp and time struct tags,
+// which are an optional unit and the "local" flag, for example (millisecond)
+// or (microsecond:local). The unit defaults to defaultUnit when the arguments
+// are empty.
+func parseTimeArgs(args string, defaultUnit TimeUnit) (unit TimeUnit, local bool, err error) {
+	if !strings.HasPrefix(args, "(") || !strings.HasSuffix(args, ")") {
+		return nil, false, fmt.Errorf("malformed time args: %s", args)
+	}
+	args = strings.TrimPrefix(args, "(")
+	args = strings.TrimSuffix(args, ")")
+	unit = defaultUnit
+	if args == "" {
+		return unit, false, nil
+	}
+	parts := strings.Split(args, ":")
+	switch parts[0] {
+	case "millisecond":
+		unit = Millisecond
+	case "microsecond":
+		unit = Microsecond
+	case "nanosecond":
+		unit = Nanosecond
+	default:
+		return nil, false, fmt.Errorf("unknown time unit: %s", parts[0])
+	}
+	switch {
+	case len(parts) == 1:
+	case len(parts) == 2 && parts[1] == "local":
+		local = true
+	default:
+		return nil, false, fmt.Errorf("malformed time args: (%s)", args)
+	}
+	return unit, local, nil
+}
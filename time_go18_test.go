@@ -0,0 +1,31 @@
+//go:build go1.18
+
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/parquet-go"
+)
+
+func TestGenericWriterTimestampOverflow(t *testing.T) {
+	type nanosRow struct {
+		Time time.Time `parquet:"time,timestamp(nanosecond)"`
+	}
+
+	writer := parquet.NewGenericWriter[nanosRow](new(bytes.Buffer))
+	if _, err := writer.Write([]nanosRow{{Time: time.Date(2022, time.June, 15, 0, 0, 0, 0, time.UTC)}}); err != nil {
+		t.Fatal(err)
+	}
+	for _, when := range []time.Time{{}, time.Date(3000, time.January, 1, 0, 0, 0, 0, time.UTC)} {
+		if n, err := writer.Write([]nanosRow{{Time: when}}); n != 0 || !errors.Is(err, parquet.ErrTimestampOverflow) {
+			t.Errorf("writing %s: want=(0, %v) got=(%d, %v)", when, parquet.ErrTimestampOverflow, n, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
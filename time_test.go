@@ -0,0 +1,315 @@
+package parquet_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/segmentio/parquet-go"
+)
+
+type timeRow struct {
+	Date     time.Time     `parquet:"date,date"`
+	Duration time.Duration `parquet:"duration,time(microsecond)"`
+	Elapsed  time.Duration `parquet:"elapsed"`
+	Local    time.Time     `parquet:"local,timestamp(microsecond:local)"`
+	Millis   time.Time     `parquet:"millis,timestamp(millisecond)"`
+	Nanos    time.Time     `parquet:"nanos,timestamp(nanosecond)"`
+	Optional *time.Time    `parquet:"optional,optional"`
+	Plain    time.Time     `parquet:"plain"`
+	Seconds  int64         `parquet:"seconds,timestamp(millisecond)"`
+}
+
+func TestTimeSchema(t *testing.T) {
+	schema := parquet.SchemaOf(timeRow{})
+
+	tests := []struct {
+		column string
+		typ    string
+	}{
+		{column: "date", typ: "DATE"},
+		{column: "duration", typ: "TIME(isAdjustedToUTC=true,unit=MICROS)"},
+		{column: "elapsed", typ: "INT(64,true)"},
+		{column: "local", typ: "TIMESTAMP(isAdjustedToUTC=false,unit=MICROS)"},
+		{column: "millis", typ: "TIMESTAMP(isAdjustedToUTC=true,unit=MILLIS)"},
+		{column: "nanos", typ: "TIMESTAMP(isAdjustedToUTC=true,unit=NANOS)"},
+		{column: "optional", typ: "TIMESTAMP(isAdjustedToUTC=true,unit=MICROS)"},
+		{column: "plain", typ: "TIMESTAMP(isAdjustedToUTC=true,unit=MICROS)"},
+		{column: "seconds", typ: "TIMESTAMP(isAdjustedToUTC=true,unit=MILLIS)"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.column, func(t *testing.T) {
+			if typ := schema.ChildByName(test.column).Type().String(); typ != test.typ {
+				t.Errorf("wrong type: want=%s got=%s", test.typ, typ)
+			}
+		})
+	}
+
+	if !schema.ChildByName("optional").Optional() {
+		t.Error("pointer to time.Time must be optional")
+	}
+}
+
+func TestTimeRoundTrip(t *testing.T) {
+	zone := time.FixedZone("UTC+5", 5*3600)
+	now := time.Date(2022, time.June, 15, 10, 30, 45, 123456789, zone)
+
+	rows := []timeRow{
+		{
+			Date:     now,
+			Duration: 90*time.Minute + 1500*time.Nanosecond,
+			Elapsed:  time.Hour + time.Nanosecond,
+			Local:    now,
+			Millis:   now,
+			Nanos:    now,
+			Optional: &now,
+			Plain:    now,
+			Seconds:  1234,
+		},
+		{
+			Date:   time.Date(1969, time.December, 31, 23, 0, 0, 0, time.UTC),
+			Local:  time.Date(1960, time.January, 1, 0, 0, 0, 1000, time.UTC),
+			Millis: time.Unix(0, 0),
+			Nanos:  time.Unix(-1, 0),
+		},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	for i := range rows {
+		if err := writer.Write(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := parquet.NewReader(f)
+
+	for i, want := range rows {
+		row := timeRow{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+
+		y, m, d := want.Date.Date()
+		if date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC); !row.Date.Equal(date) || row.Date.Location() != time.UTC {
+			t.Errorf("row %d: wrong date: want=%s got=%s", i, date, row.Date)
+		}
+		if duration := want.Duration.Truncate(time.Microsecond); row.Duration != duration {
+			t.Errorf("row %d: wrong duration: want=%s got=%s", i, duration, row.Duration)
+		}
+		if row.Elapsed != want.Elapsed {
+			t.Errorf("row %d: wrong elapsed duration: want=%s got=%s", i, want.Elapsed, row.Elapsed)
+		}
+		if row.Local.Location() != time.UTC || row.Local.Format(time.StampMicro) != want.Local.Format(time.StampMicro) {
+			t.Errorf("row %d: wrong local timestamp: want=%s got=%s", i, want.Local.Format(time.StampMicro), row.Local.Format(time.StampMicro))
+		}
+		if millis := want.Millis.Truncate(time.Millisecond); !row.Millis.Equal(millis) || row.Millis.Location() != time.UTC {
+			t.Errorf("row %d: wrong timestamp in milliseconds: want=%s got=%s", i, millis, row.Millis)
+		}
+		if !row.Nanos.Equal(want.Nanos) {
+			t.Errorf("row %d: wrong timestamp in nanoseconds: want=%s got=%s", i, want.Nanos, row.Nanos)
+		}
+		switch {
+		case want.Optional == nil:
+			if row.Optional != nil {
+				t.Errorf("row %d: wrong optional timestamp: want=<nil> got=%s", i, row.Optional)
+			}
+		case row.Optional == nil || !row.Optional.Equal(want.Optional.Truncate(time.Microsecond)):
+			t.Errorf("row %d: wrong optional timestamp: want=%s got=%v", i, want.Optional, row.Optional)
+		}
+		if plain := want.Plain.Truncate(time.Microsecond); !row.Plain.Equal(plain) {
+			t.Errorf("row %d: wrong timestamp: want=%s got=%s", i, plain, row.Plain)
+		}
+		if row.Seconds != want.Seconds {
+			t.Errorf("row %d: wrong integer timestamp: want=%d got=%d", i, want.Seconds, row.Seconds)
+		}
+	}
+}
+
+type int96Row struct {
+	ID   int64      `parquet:"id"`
+	Time time.Time  `parquet:"time,timestamp(millisecond)"`
+	When *time.Time `parquet:"when,optional"`
+}
+
+func TestInt96Timestamps(t *testing.T) {
+	when := time.Date(1969, time.July, 20, 20, 17, 40, 123456000, time.UTC)
+	rows := []int96Row{
+		{ID: 1, Time: time.Date(2022, time.March, 1, 12, 0, 0, 1e6, time.UTC), When: &when},
+		{ID: 2, Time: time.Unix(-1, 0)},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, parquet.Int96Timestamps(true))
+	for i := range rows {
+		if err := writer.Write(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"time", "when"} {
+		if typ := f.Root().Column(name).Type(); typ.Kind() != parquet.Int96 || typ.LogicalType() != nil {
+			t.Errorf("column %s was not written as INT96: %s", name, typ)
+		}
+	}
+
+	reader := parquet.NewReader(f)
+	for i, want := range rows {
+		row := int96Row{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		if row.ID != want.ID || !row.Time.Equal(want.Time) {
+			t.Errorf("row %d: wrong values:\nwant = %+v\ngot  = %+v", i, want, row)
+		}
+		if (row.When == nil) != (want.When == nil) || (row.When != nil && !row.When.Equal(*want.When)) {
+			t.Errorf("row %d: wrong optional timestamp: want=%v got=%v", i, want.When, row.When)
+		}
+	}
+}
+
+func TestTimestampZeroValue(t *testing.T) {
+	type timestampRow struct {
+		Time time.Time `parquet:"time,timestamp"`
+	}
+
+	schema := parquet.SchemaOf(timestampRow{})
+	if typ := schema.ChildByName("time").Type().String(); typ != "TIMESTAMP(isAdjustedToUTC=true,unit=MICROS)" {
+		t.Fatalf("wrong default unit of the timestamp tag: %s", typ)
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	if err := writer.Write(&timestampRow{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	row := timestampRow{}
+	reader := parquet.NewReader(bytes.NewReader(buffer.Bytes()))
+	if err := reader.Read(&row); err != nil {
+		t.Fatal(err)
+	}
+	if !row.Time.IsZero() {
+		t.Errorf("zero time read back as %s", row.Time)
+	}
+}
+
+func TestLocalTimestampWallClock(t *testing.T) {
+	type localRow struct {
+		Time time.Time `parquet:"time,timestamp(microsecond:local)"`
+	}
+
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer func(local *time.Location) { time.Local = local }(time.Local)
+	time.Local = loc
+
+	// 02:30 on the day of the DST transition does not exist in the time zone
+	// of the program, the wall clock must still be read back unchanged.
+	want := time.Date(2022, time.March, 13, 2, 30, 0, 0, time.UTC)
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	if err := writer.Write(&localRow{Time: want}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	row := localRow{}
+	if err := parquet.NewReader(bytes.NewReader(buffer.Bytes())).Read(&row); err != nil {
+		t.Fatal(err)
+	}
+	if !row.Time.Equal(want) || row.Time.Location() != time.UTC {
+		t.Errorf("wrong local timestamp: want=%s got=%s", want, row.Time)
+	}
+}
+
+func TestTimestampOverflow(t *testing.T) {
+	type nanosRow struct {
+		Time time.Time `parquet:"time,timestamp(nanosecond)"`
+	}
+
+	writer := parquet.NewWriter(new(bytes.Buffer))
+	if err := writer.Write(&nanosRow{Time: time.Date(2022, time.June, 15, 0, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatal(err)
+	}
+	for _, when := range []time.Time{{}, time.Date(2263, time.January, 1, 0, 0, 0, 0, time.UTC)} {
+		if err := writer.Write(&nanosRow{Time: when}); !errors.Is(err, parquet.ErrTimestampOverflow) {
+			t.Errorf("writing %s: want=%v got=%v", when, parquet.ErrTimestampOverflow, err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInterval(t *testing.T) {
+	type intervalRow struct {
+		Interval parquet.Interval  `parquet:"interval"`
+		Optional *parquet.Interval `parquet:"optional,optional"`
+	}
+
+	schema := parquet.SchemaOf(intervalRow{})
+	if typ := schema.ChildByName("interval").Type(); typ.String() != "INTERVAL" || typ.Length() != 12 {
+		t.Fatalf("wrong type of interval column: %s", typ)
+	}
+
+	rows := []intervalRow{
+		{Interval: parquet.Interval{Months: 1, Days: 2, Millis: 3}},
+		{Interval: parquet.Interval{Months: 14}, Optional: &parquet.Interval{Days: 30, Millis: 86399999}},
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	for i := range rows {
+		if err := writer.Write(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ := f.Root().Column("interval").Type(); typ != parquet.IntervalType {
+		t.Errorf("wrong type of interval column read from the file: %s", typ)
+	}
+
+	reader := parquet.NewReader(f)
+	for i, want := range rows {
+		row := intervalRow{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(row, want) {
+			t.Errorf("row %d: wrong values:\nwant = %+v\ngot  = %+v", i, want, row)
+		}
+	}
+}
@@ -316,12 +316,23 @@ func (t *intType) ConvertedType() *deprecated.ConvertedType {
 // Decimal constructs a leaf node of decimal logical type with the given
 // scale, precision, and underlying type.
 //
+// The underlying type must be INT32 (precision up to 9), INT64 (precision up to
+// 18), FIXED_LEN_BYTE_ARRAY (precision limited by the length of the array), or
+// BYTE_ARRAY (unlimited precision). Values of byte array types hold the
+// unscaled value as a big-endian two's complement integer.
+//
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#decimal
 func Decimal(scale, precision int, typ Type) Node {
-	switch typ {
-	case Int32Type, Int64Type:
+	switch typ.Kind() {
+	case Int32, Int64, FixedLenByteArray, ByteArray:
 	default:
-		panic("DECIMAL node must annotate the INT32 or INT64 types but got " + typ.String())
+		panic("DECIMAL node must annotate the INT32, INT64, FIXED_LEN_BYTE_ARRAY or BYTE_ARRAY types but got " + typ.String())
+	}
+	if max := maxDecimalPrecision(typ); precision < 1 || (max != 0 && precision > max) {
+		panic(fmt.Sprintf("DECIMAL precision %d is out of range for the %s type", precision, typ))
+	}
+	if scale < 0 || scale > precision {
+		panic(fmt.Sprintf("DECIMAL scale %d is out of range for precision %d", scale, precision))
 	}
 	return Leaf(&decimalType{
 		decimal: format.DecimalType{
@@ -459,6 +470,75 @@ func (t *uuidType) GoType() reflect.Type {
 	return reflect.TypeOf(uuid.UUID{})
 }
 
+// Float16 constructs a leaf node of FLOAT16 logical type, which holds IEEE 754
+// half-precision floating point numbers in a FIXED_LEN_BYTE_ARRAY of 2 bytes.
+//
+// Values of this type are converted to and from float32 in go. Column indexes
+// and sort functions order the values numerically; NaN values are ignored when
+// computing page bounds and are sorted after all other values.
+//
+// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#float16
+func Float16() Node { return Leaf(&float16Type{}) }
+
+type float16Type format.Float16Type
+
+func (t *float16Type) String() string { return (*format.Float16Type)(t).String() }
+
+func (t *float16Type) Kind() Kind { return FixedLenByteArray }
+
+func (t *float16Type) Length() int { return float16Length }
+
+func (t *float16Type) Compare(a, b Value) int {
+	return compareFloat16(a.ByteArray(), b.ByteArray())
+}
+
+func (t *float16Type) ColumnOrder() *format.ColumnOrder {
+	return &typeDefinedColumnOrder
+}
+
+func (t *float16Type) PhysicalType() *format.Type {
+	return &physicalTypes[FixedLenByteArray]
+}
+
+func (t *float16Type) LogicalType() *format.LogicalType {
+	return &format.LogicalType{Float16: (*format.Float16Type)(t)}
+}
+
+func (t *float16Type) ConvertedType() *deprecated.ConvertedType { return nil }
+
+func (t *float16Type) NewColumnIndexer(sizeLimit int) ColumnIndexer {
+	return newFloat16ColumnIndexer()
+}
+
+func (t *float16Type) NewDictionary(columnIndex, bufferSize int) Dictionary {
+	return float16Dictionary{newFixedLenByteArrayDictionary(t, makeColumnIndex(columnIndex), bufferSize)}
+}
+
+func (t *float16Type) NewColumnBuffer(columnIndex, bufferSize int) ColumnBuffer {
+	return newFloat16ColumnBuffer(t, makeColumnIndex(columnIndex), bufferSize)
+}
+
+func (t *float16Type) NewColumnReader(columnIndex, bufferSize int) ColumnReader {
+	return newFixedLenByteArrayColumnReader(t, makeColumnIndex(columnIndex), bufferSize)
+}
+
+func (t *float16Type) ReadDictionary(columnIndex, numValues int, decoder encoding.Decoder) (Dictionary, error) {
+	d, err := readFixedLenByteArrayDictionary(t, makeColumnIndex(columnIndex), numValues, decoder)
+	if err != nil {
+		return nil, err
+	}
+	return float16Dictionary{d.(*fixedLenByteArrayDictionary)}, nil
+}
+
+func (t *float16Type) GoType() reflect.Type {
+	return reflect.TypeOf(float32(0))
+}
+
+func isFloat16Type(t Type) bool {
+	lt := t.LogicalType()
+	return lt != nil && lt.Float16 != nil
+}
+
 // Enum constructs a leaf node with a logical type representing enumerations.
 //
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#enum
@@ -795,6 +875,29 @@ func (t *timestampType) ConvertedType() *deprecated.ConvertedType {
 	}
 }
 
+// IntervalType is the type of INTERVAL columns, which hold a number of months,
+// days and milliseconds as three little-endian unsigned 32 bits integers in a
+// FIXED_LEN_BYTE_ARRAY of 12 bytes. Values of this type are converted to and
+// from the parquet.Interval go type.
+//
+// INTERVAL only exists as a converted type, there is no equivalent logical type.
+//
+// https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#interval
+var IntervalType Type = &intervalType{FixedLenByteArrayType(12)}
+
+type intervalType struct{ Type }
+
+func (t *intervalType) String() string { return "INTERVAL" }
+
+func (t *intervalType) ConvertedType() *deprecated.ConvertedType {
+	return &convertedTypes[deprecated.Interval]
+}
+
+func isIntervalType(t Type) bool {
+	ct := t.ConvertedType()
+	return ct != nil && *ct == deprecated.Interval
+}
+
 // List constructs a node of LIST logical type.
 //
 // https://github.com/apache/parquet-format/blob/master/LogicalTypes.md#lists
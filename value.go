@@ -8,6 +8,7 @@ import (
 	"math"
 	"reflect"
 	"strconv"
+	"time"
 	"unsafe"
 
 	"github.com/google/uuid"
@@ -193,6 +194,65 @@ func ValueOf(v interface{}) Value {
 	return makeValue(k, reflect.ValueOf(v))
 }
 
+// makeValueFuncOf returns a function which converts go values to parquet values
+// of type t. In addition to the conversions supported by makeValue, the function
+// handles the go types representing logical types, such as time.Time for the
+// TIMESTAMP, DATE and INT96 types, or Interval for the INTERVAL type.
+func makeValueFuncOf(t Type) func(reflect.Value) Value {
+	kind := t.Kind()
+	lt := t.LogicalType()
+	switch {
+	case isIntervalType(t):
+		return func(v reflect.Value) Value {
+			if v.Type() == intervalGoType {
+				return makeIntervalValue(v.Interface().(Interval))
+			}
+			return makeValue(kind, v)
+		}
+	case kind == Int96:
+		return func(v reflect.Value) Value {
+			if v.Type() == timeTimeType {
+				return makeValueInt96(int96OfTime(v.Interface().(time.Time)))
+			}
+			return makeValue(kind, v)
+		}
+	case lt == nil:
+	case lt.Decimal != nil:
+		scale := int(lt.Decimal.Scale)
+		return func(v reflect.Value) Value { return makeDecimalValue(t, scale, v) }
+	case lt.Timestamp != nil:
+		return func(v reflect.Value) Value {
+			if v.Type() == timeTimeType {
+				return makeTimestampValue(lt.Timestamp, v.Interface().(time.Time))
+			}
+			return makeValue(kind, v)
+		}
+	case lt.Date != nil:
+		return func(v reflect.Value) Value {
+			if v.Type() == timeTimeType {
+				return makeDateValue(v.Interface().(time.Time))
+			}
+			return makeValue(kind, v)
+		}
+	case lt.Time != nil:
+		return func(v reflect.Value) Value {
+			if v.Type() == timeDurationType {
+				return makeTimeValue(lt.Time, time.Duration(v.Int()))
+			}
+			return makeValue(kind, v)
+		}
+	case lt.Float16 != nil:
+		return func(v reflect.Value) Value {
+			switch v.Kind() {
+			case reflect.Float32, reflect.Float64:
+				return makeFloat16Value(float32(v.Float()))
+			}
+			return makeValue(kind, v)
+		}
+	}
+	return func(v reflect.Value) Value { return makeValue(kind, v) }
+}
+
 func makeValue(k Kind, v reflect.Value) Value {
 	switch k {
 	case Boolean:
@@ -557,6 +617,76 @@ func makeInt96(bits []byte) (i96 deprecated.Int96) {
 	}
 }
 
+// assignValueFuncOf returns a function which assigns parquet values of type t
+// to go values, it is the inverse of makeValueFuncOf.
+func assignValueFuncOf(t Type) func(reflect.Value, Value) error {
+	lt := t.LogicalType()
+	switch {
+	case isIntervalType(t):
+		return func(dst reflect.Value, src Value) error {
+			if dst.Type() == intervalGoType && !src.IsNull() {
+				i, err := intervalOf(src.ByteArray())
+				dst.Set(reflect.ValueOf(i))
+				return err
+			}
+			return assignValue(dst, src)
+		}
+	case t.Kind() == Int96:
+		return func(dst reflect.Value, src Value) error {
+			if dst.Type() == timeTimeType && !src.IsNull() {
+				dst.Set(reflect.ValueOf(timeOfInt96(src.Int96())))
+				return nil
+			}
+			return assignValue(dst, src)
+		}
+	case lt == nil:
+	case lt.Decimal != nil:
+		scale := int(lt.Decimal.Scale)
+		return func(dst reflect.Value, src Value) error { return assignDecimalValue(dst, scale, src) }
+	case lt.Timestamp != nil:
+		return func(dst reflect.Value, src Value) error {
+			if dst.Type() == timeTimeType && !src.IsNull() {
+				dst.Set(reflect.ValueOf(timestampOf(lt.Timestamp, src.Int64())))
+				return nil
+			}
+			return assignValue(dst, src)
+		}
+	case lt.Date != nil:
+		return func(dst reflect.Value, src Value) error {
+			if dst.Type() == timeTimeType && !src.IsNull() {
+				dst.Set(reflect.ValueOf(dateOf(src.Int32())))
+				return nil
+			}
+			return assignValue(dst, src)
+		}
+	case lt.Time != nil:
+		return func(dst reflect.Value, src Value) error {
+			if dst.Type() == timeDurationType && !src.IsNull() {
+				dst.SetInt(int64(timeOf(lt.Time, src)))
+				return nil
+			}
+			return assignValue(dst, src)
+		}
+	case lt.Float16 != nil:
+		return func(dst reflect.Value, src Value) error {
+			switch dst.Kind() {
+			case reflect.Float32, reflect.Float64:
+				if src.IsNull() {
+					break
+				}
+				b := src.ByteArray()
+				if len(b) != float16Length {
+					return fmt.Errorf("cannot assign FLOAT16 value of length %d to go value of type %s", len(b), dst.Type())
+				}
+				dst.SetFloat(float64(float16Float32(float16Of(b))))
+				return nil
+			}
+			return assignValue(dst, src)
+		}
+	}
+	return assignValue
+}
+
 func assignValue(dst reflect.Value, src Value) error {
 	if src.IsNull() {
 		dst.Set(reflect.Zero(dst.Type()))
@@ -7,6 +7,7 @@ import (
 	"hash/crc32"
 	"io"
 	"sort"
+	"sync"
 
 	"github.com/segmentio/encoding/thrift"
 	"github.com/segmentio/parquet-go/compress"
@@ -99,8 +100,9 @@ func (w *Writer) Close() error {
 // Flush flushes all buffers into a row group to the underlying io.Writer.
 //
 // Flush is called automatically on Close, it is only useful to call explicitly
-// if the application needs to limit the size of row groups or wants to produce
-// multiple row groups per file.
+// if the application needs to control where row groups are cut in the file.
+// The MaxRowsPerRowGroup and TargetRowGroupSize options can also be used to
+// have the writer flush automatically to limit the size of row groups.
 func (w *Writer) Flush() error {
 	if w.writer != nil {
 		return w.writer.flush()
@@ -132,10 +134,65 @@ func (w *Writer) Write(row interface{}) error {
 	defer func() {
 		clearValues(w.values)
 	}()
-	w.values = w.schema.Deconstruct(w.values[:0], row)
+	var err error
+	if w.values, err = deconstructRow(w.schema, w.values[:0], row); err != nil {
+		return err
+	}
+	return w.WriteRow(w.values)
+}
+
+// WriteMap is called to write a row represented as a map of column names to
+// values to the parquet file. It is intended for programs which only know the
+// schema at runtime, and must use the Schema option of NewWriter to declare
+// the structure of the file.
+//
+// Nested groups may be represented by maps, repeated columns by slices, and
+// null values by nil interfaces or missing map entries; see Reader.ReadMap for
+// the representation of rows read from parquet files, which can be passed to
+// this method.
+//
+// The method returns an error if a value of the map has a go type which cannot
+// be converted to the type of its column, or if the value of a required column
+// is missing.
+func (w *Writer) WriteMap(row map[string]interface{}) error {
+	if w.schema == nil {
+		return ErrRowGroupSchemaMissing
+	}
+	defer func() {
+		clearValues(w.values)
+	}()
+	if err := w.deconstructMap(row); err != nil {
+		return err
+	}
 	return w.WriteRow(w.values)
 }
 
+// deconstructMap deconstructs row into w.values. The go types of the map values
+// are only known at runtime, so the panics raised when they do not match the
+// schema are recovered into errors, and missing values of required columns are
+// rejected instead of being written as zero values.
+func (w *Writer) deconstructMap(row map[string]interface{}) (err error) {
+	defer func() {
+		switch e := recover().(type) {
+		case nil:
+		case *valueError:
+			err = e.err
+		default:
+			err = fmt.Errorf("writing map row: %v", e)
+		}
+	}()
+
+	w.values = w.schema.Deconstruct(w.values[:0], row)
+
+	for _, v := range w.values {
+		c := w.writer.columns[v.Column()]
+		if v.IsNull() && int8(v.DefinitionLevel()) == c.maxDefinitionLevel {
+			return fmt.Errorf("writing map row: missing value of required column %s", c.columnPath)
+		}
+	}
+	return nil
+}
+
 // WriteRow is called to write another row to the parquet file.
 //
 // The Writer must have been given a schema when NewWriter was called, otherwise
@@ -145,6 +202,13 @@ func (w *Writer) Write(row interface{}) error {
 // in the order produced by the parquet.(*Schema).Deconstruct method.
 func (w *Writer) WriteRow(row Row) error { return w.writer.WriteRow(row) }
 
+// WriteRows is called to write rows to the parquet file, returning the number
+// of rows written.
+//
+// This is similar to calling WriteRow repeatedly, with the same requirements
+// on the layout of each row.
+func (w *Writer) WriteRows(rows []Row) (int, error) { return w.writer.WriteRows(rows) }
+
 // WriteRowGroup writes a row group to the parquet file.
 //
 // Buffered rows will be flushed prior to writing rows from the group, unless
@@ -152,6 +216,15 @@ func (w *Writer) WriteRow(row Row) error { return w.writer.WriteRow(row) }
 //
 // The content of the row group is flushed to the writer; after the method
 // returns successfully, the row group will be empty and in ready to be reused.
+//
+// The row group is written as a single row group of the file, it is not split
+// when it exceeds the limits set by MaxRowsPerRowGroup or TargetRowGroupSize.
+//
+// When the row group was read from a parquet file with the same schema, and the
+// file has a page index, its column chunks are copied without decoding the
+// pages; they retain the encoding and compression of the original file. This
+// is not possible when the writer or the file use encryption, or when values
+// must be converted, in which case the rows are re-encoded.
 func (w *Writer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	rowGroupSchema := rowGroup.Schema()
 	switch {
@@ -165,8 +238,13 @@ func (w *Writer) WriteRowGroup(rowGroup RowGroup) (int64, error) {
 	if err := w.writer.flush(); err != nil {
 		return 0, err
 	}
+	if g, ok := rowGroup.(*fileRowGroup); ok && w.writer.canCopyFileRowGroup(g) {
+		return w.writer.writeFileRowGroup(g)
+	}
 	w.writer.configureBloomFilters(rowGroup)
+	w.writer.copyingRowGroup = true
 	n, err := CopyRows(w.writer, rowGroup.Rows())
+	w.writer.copyingRowGroup = false
 	if err != nil {
 		return n, err
 	}
@@ -204,6 +282,32 @@ type writer struct {
 		page   bytes.Buffer
 	}
 
+	concurrency int
+	// Bounds the number of goroutines encoding and compressing pages when the
+	// concurrency is greater than one.
+	sem chan struct{}
+
+	// Limits applied to the row groups that the writer produces when rows are
+	// written with WriteRow; the writer flushes automatically when the number
+	// of buffered rows or their estimated size reaches the limits, unless it
+	// is copying a row group, in which case the row group is written as-is.
+	maxRowsPerRowGroup int64
+	targetRowGroupSize int64
+	copyingRowGroup    bool
+	// The last estimate of the row group size, and the number of pages that
+	// were flushed when it was made, see estimateRowGroupSize.
+	estimate struct {
+		numRows     int64
+		size        int64
+		pageFlushes int64
+	}
+	pageFlushes int64
+
+	// Sorting columns recorded on the row groups flushed by the writer, which
+	// is only set when the rows are known to be written in this order.
+	schema         *Schema
+	sortingColumns []SortingColumn
+
 	columns       []*writerColumn
 	columnChunk   []format.ColumnChunk
 	columnIndex   []format.ColumnIndex
@@ -215,20 +319,47 @@ type writer struct {
 	rowGroups      []format.RowGroup
 	columnIndexes  [][]format.ColumnIndex
 	offsetIndexes  [][]format.OffsetIndex
+
+	encryption *fileEncryptor
+
+	// Set when the schema has DECIMAL columns, in which case the precision of
+	// their values is verified before the rows are written.
+	checkDecimals bool
+
+	// Set when some columns are written with a different type than the one
+	// declared in the schema, the rows are then converted into the row buffer
+	// before being written.
+	convertValues bool
+	row           Row
 }
 
 func newWriter(output io.Writer, config *WriterConfig) *writer {
 	w := new(writer)
 	w.writer.Reset(output)
 	w.createdBy = config.CreatedBy
+	w.concurrency = config.WriteConcurrency
+	if w.concurrency > 1 {
+		w.sem = make(chan struct{}, w.concurrency)
+	}
+	w.maxRowsPerRowGroup = config.MaxRowsPerRowGroup
+	w.targetRowGroupSize = config.TargetRowGroupSize
+	w.schema = config.Schema
 	w.metadata = make([]format.KeyValue, 0, len(config.KeyValueMetadata))
 	for k, v := range config.KeyValueMetadata {
 		w.metadata = append(w.metadata, format.KeyValue{Key: k, Value: v})
 	}
 	sortKeyValueMetadata(w.metadata)
 
+	if config.Encryption != nil {
+		w.encryption = newFileEncryptor(config.Encryption, config.KeyRetriever)
+		w.encryption.checkColumnKeys(config.Schema)
+	}
+
 	config.Schema.forEachNode(func(name string, node Node) {
 		nodeType := node.Type()
+		if config.Int96Timestamps && isTimestampType(nodeType) {
+			nodeType = Int96Type
+		}
 
 		repetitionType := (*format.FieldRepetitionType)(nil)
 		if node != config.Schema { // the root has no repetition type
@@ -260,6 +391,7 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 			Scale:          scale,
 			Precision:      precision,
 			LogicalType:    logicalType,
+			FieldID:        int32(fieldIDOf(node)),
 		})
 	})
 
@@ -274,12 +406,25 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		columnType := leaf.node.Type()
 		columnIndex := int(leaf.columnIndex)
 
+		// Timestamps written in the INT96 representation are converted when
+		// they are written to the column, the encoding of the column must then
+		// support the INT96 type.
+		var convert func(Value) Value
+		if config.Int96Timestamps && isTimestampType(columnType) {
+			convert = convertTimestampToInt96(columnType.LogicalType().Timestamp.Unit)
+			columnType = Int96Type
+			if !encoding.CanEncode(format.Int96) {
+				encoding = &Plain
+			}
+		}
+
 		if isDictionaryEncoding(encoding) {
 			dictionary = columnType.NewDictionary(columnIndex, defaultDictBufferSize)
 			columnType = dictionary.Type()
 		}
 
 		c := &writerColumn{
+			pageFlushes:        &w.pageFlushes,
 			pool:               config.ColumnPageBuffers,
 			columnPath:         leaf.path,
 			columnType:         columnType,
@@ -305,10 +450,30 @@ func newWriter(output io.Writer, config *WriterConfig) *writer {
 		// Those buffers are scratch space used to generate the page header and
 		// content, they are shared by all column chunks because they are only
 		// used during calls to writeDictionaryPage or writeDataPage, which are
-		// not done concurrently.
-		c.header.buffer, c.page.buffer = &w.buffers.header, &w.buffers.page
+		// not done concurrently, unless the writer is configured to flush the
+		// columns in parallel.
+		if w.concurrency > 1 {
+			c.header.buffer, c.page.buffer = new(bytes.Buffer), new(bytes.Buffer)
+			c.sem, c.done = w.sem, make(chan error, 1)
+		} else {
+			c.header.buffer, c.page.buffer = &w.buffers.header, &w.buffers.page
+		}
 		c.header.encoder.Reset(c.header.protocol.NewWriter(c.header.buffer))
 
+		if w.encryption != nil {
+			c.encryption = w.encryption.columnEncryption(leaf.path, config.KeyRetriever)
+		}
+
+		if lt := leaf.node.Type().LogicalType(); lt != nil && lt.Decimal != nil {
+			c.decimal = newDecimalChecker(lt.Decimal)
+			w.checkDecimals = true
+		}
+
+		if convert != nil {
+			c.convert = convert
+			w.convertValues = true
+		}
+
 		if leaf.maxRepetitionLevel > 0 {
 			c.insert = (*writerColumn).insertRepeated
 			c.commit = (*writerColumn).commitRepeated
@@ -368,6 +533,14 @@ func (w *writer) reset(writer io.Writer) {
 	w.writer.Reset(writer)
 	for _, c := range w.columns {
 		c.reset()
+		c.stats.nullCount = 0
+		c.stats.min, c.stats.max = Value{}, Value{}
+		if c.encryption != nil {
+			c.encryption.rowGroup = 0
+		}
+	}
+	if w.encryption != nil {
+		w.encryption.reset()
 	}
 	for i := range w.rowGroups {
 		w.rowGroups[i] = format.RowGroup{}
@@ -395,7 +568,7 @@ func (w *writer) close() error {
 }
 
 func (w *writer) flush() error {
-	_, err := w.writeRowGroup(nil, nil)
+	_, err := w.writeRowGroup(w.schema, w.sortingColumns)
 	return err
 }
 
@@ -404,7 +577,14 @@ func (w *writer) writeFileHeader() error {
 		return io.ErrClosedPipe
 	}
 	if w.writer.offset == 0 {
-		_, err := w.writer.WriteString("PAR1")
+		magic := "PAR1"
+		if w.encryption != nil {
+			if w.encryption.err != nil {
+				return w.encryption.err
+			}
+			magic = w.encryption.magic()
+		}
+		_, err := w.writer.WriteString(magic)
 		return err
 	}
 	return nil
@@ -439,7 +619,7 @@ func (w *writer) writeFileFooter() error {
 		for j := range columnIndexes {
 			column := &rowGroup.Columns[j]
 			column.ColumnIndexOffset = w.writer.offset
-			if err := encoder.Encode(&columnIndexes[j]); err != nil {
+			if err := w.writeIndex(encoder, &columnIndexes[j], module{kind: columnIndexModule, rowGroup: i, column: j}); err != nil {
 				return err
 			}
 			column.ColumnIndexLength = int32(w.writer.offset - column.ColumnIndexOffset)
@@ -451,19 +631,32 @@ func (w *writer) writeFileFooter() error {
 		for j := range offsetIndexes {
 			column := &rowGroup.Columns[j]
 			column.OffsetIndexOffset = w.writer.offset
-			if err := encoder.Encode(&offsetIndexes[j]); err != nil {
+			if err := w.writeIndex(encoder, &offsetIndexes[j], module{kind: offsetIndexModule, rowGroup: i, column: j}); err != nil {
 				return err
 			}
 			column.OffsetIndexLength = int32(w.writer.offset - column.OffsetIndexOffset)
 		}
 	}
 
+	if w.encryption != nil {
+		for i := range w.rowGroups {
+			rowGroup := &w.rowGroups[i]
+			for j, c := range w.columns {
+				if c.encryption != nil {
+					if err := w.encryption.encryptColumnMetaData(&rowGroup.Columns[j], c.encryption, i, j); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
 	numRows := int64(0)
 	for rowGroupIndex := range w.rowGroups {
 		numRows += w.rowGroups[rowGroupIndex].NumRows
 	}
 
-	footer, err := thrift.Marshal(new(thrift.CompactProtocol), &format.FileMetaData{
+	metadata := &format.FileMetaData{
 		Version:          1,
 		Schema:           w.schemaElements,
 		NumRows:          numRows,
@@ -471,7 +664,18 @@ func (w *writer) writeFileFooter() error {
 		KeyValueMetadata: w.metadata,
 		CreatedBy:        w.createdBy,
 		ColumnOrders:     w.columnOrders,
-	})
+	}
+
+	if w.encryption != nil {
+		footer, err := w.encryption.encryptFooter(metadata)
+		if err != nil {
+			return fmt.Errorf("encrypting parquet footer: %w", err)
+		}
+		_, err = w.writer.Write(footer)
+		return err
+	}
+
+	footer, err := thrift.Marshal(new(thrift.CompactProtocol), metadata)
 	if err != nil {
 		return err
 	}
@@ -485,6 +689,27 @@ func (w *writer) writeFileFooter() error {
 	return err
 }
 
+// writeIndex writes a column or offset index of the page index section,
+// encrypting it if the column is encrypted.
+func (w *writer) writeIndex(encoder *thrift.Encoder, index interface{}, m module) error {
+	c := w.columns[m.column].encryption
+	if c == nil {
+		return encoder.Encode(index)
+	}
+	b, err := thrift.Marshal(new(thrift.CompactProtocol), index)
+	if err != nil {
+		return err
+	}
+	if c.err != nil {
+		return c.err
+	}
+	if b, err = c.cipher.encrypt(b[:0:0], b, m); err != nil {
+		return err
+	}
+	_, err = w.writer.Write(b)
+	return err
+}
+
 func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []SortingColumn) (int64, error) {
 	numRows := w.columns[0].totalRowCount()
 	if numRows == 0 {
@@ -494,19 +719,18 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	defer func() {
 		for _, c := range w.columns {
 			c.reset()
+			if c.encryption != nil {
+				c.encryption.rowGroup = len(w.rowGroups)
+			}
 		}
+		w.estimate.numRows, w.estimate.size = 0, 0
 		for i := range w.columnIndex {
 			w.columnIndex[i] = format.ColumnIndex{}
 		}
 	}()
 
-	for _, c := range w.columns {
-		if err := c.flush(); err != nil {
-			return 0, err
-		}
-		if err := c.flushFilterPages(); err != nil {
-			return 0, err
-		}
+	if err := w.flushColumns(); err != nil {
+		return 0, err
 	}
 
 	if err := w.writeFileHeader(); err != nil {
@@ -528,8 +752,13 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 
 		if c.dictionary != nil {
 			c.columnChunk.MetaData.DictionaryPageOffset = w.writer.offset
-			if err := c.writeDictionaryPage(&w.writer, c.dictionary); err != nil {
-				return 0, fmt.Errorf("writing dictionary page of row group colum %d: %w", i, err)
+			if w.concurrency == 1 {
+				if err := c.encodeDictionaryPage(c.dictionary); err != nil {
+					return 0, fmt.Errorf("writing dictionary page of row group column %d: %w", i, err)
+				}
+			}
+			if err := c.writeDictionaryPage(&w.writer); err != nil {
+				return 0, fmt.Errorf("writing dictionary page of row group column %d: %w", i, err)
 			}
 		}
 
@@ -556,20 +785,6 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 		totalCompressedSize += int64(c.TotalCompressedSize)
 	}
 
-	sortingColumns := ([]format.SortingColumn)(nil)
-	if len(rowGroupSortingColumns) > 0 {
-		sortingColumns = make([]format.SortingColumn, 0, len(rowGroupSortingColumns))
-		forEachLeafColumnOf(rowGroupSchema, func(leaf leafColumn) {
-			if sortingIndex := searchSortingColumn(rowGroupSortingColumns, leaf.path); sortingIndex < len(sortingColumns) {
-				sortingColumns[sortingIndex] = format.SortingColumn{
-					ColumnIdx:  int32(leaf.columnIndex),
-					Descending: rowGroupSortingColumns[sortingIndex].Descending(),
-					NullsFirst: rowGroupSortingColumns[sortingIndex].NullsFirst(),
-				}
-			}
-		})
-	}
-
 	columns := make([]format.ColumnChunk, len(w.columnChunk))
 	copy(columns, w.columnChunk)
 
@@ -583,7 +798,7 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 		Columns:             columns,
 		TotalByteSize:       totalByteSize,
 		NumRows:             numRows,
-		SortingColumns:      sortingColumns,
+		SortingColumns:      sortingColumnsOf(rowGroupSchema, rowGroupSortingColumns),
 		FileOffset:          fileOffset,
 		TotalCompressedSize: totalCompressedSize,
 		Ordinal:             int16(len(w.rowGroups)),
@@ -594,7 +809,198 @@ func (w *writer) writeRowGroup(rowGroupSchema *Schema, rowGroupSortingColumns []
 	return numRows, nil
 }
 
+// canCopyFileRowGroup returns true if the column chunks of a row group read
+// from a parquet file can be copied to the output without decoding their pages.
+//
+// The pages are copied as-is, which requires the writer not to transform the
+// values, and the page index of the source file to be available since it
+// cannot be rebuilt without reading the pages. Column chunks stored in other
+// files than the one holding the metadata are not copied since their pages
+// cannot be read from the source file.
+func (w *writer) canCopyFileRowGroup(rowGroup *fileRowGroup) bool {
+	if w.encryption != nil || w.convertValues || len(rowGroup.columns) != len(w.columns) {
+		return false
+	}
+	for i := range rowGroup.columns {
+		c := &rowGroup.columns[i]
+		switch {
+		case c.decryption.cipher != nil || c.decryption.err != nil:
+			return false
+		case c.chunk.FilePath != "":
+			return false
+		case c.columnIndex == nil || c.offsetIndex == nil:
+			return false
+		case w.columns[i].columnFilter != nil && c.chunk.MetaData.BloomFilterOffset <= 0:
+			return false
+		}
+	}
+	return true
+}
+
+// writeFileRowGroup copies the column chunks of a row group read from a parquet
+// file to the output. The dictionary and data pages, and the bloom filters are
+// copied verbatim, only the offsets recorded in the metadata and page index of
+// the file are adjusted to their new location.
+func (w *writer) writeFileRowGroup(rowGroup *fileRowGroup) (int64, error) {
+	if rowGroup.NumRows() == 0 {
+		return 0, nil
+	}
+	if err := w.writeFileHeader(); err != nil {
+		return 0, err
+	}
+	fileOffset := w.writer.offset
+
+	columns := make([]format.ColumnChunk, len(rowGroup.columns))
+	columnIndex := make([]format.ColumnIndex, len(rowGroup.columns))
+	offsetIndex := make([]format.OffsetIndex, len(rowGroup.columns))
+
+	for i := range rowGroup.columns {
+		c := &rowGroup.columns[i]
+		columns[i].MetaData = c.chunk.MetaData
+		columns[i].MetaData.BloomFilterOffset = 0
+
+		if offset := c.chunk.MetaData.BloomFilterOffset; offset > 0 {
+			bloomFilter, err := c.bloomFilterSection()
+			if err != nil {
+				return 0, fmt.Errorf("reading bloom filter of row group column %d: %w", i, err)
+			}
+			columns[i].MetaData.BloomFilterOffset = w.writer.offset
+			if _, err := io.Copy(&w.writer, bloomFilter); err != nil {
+				return 0, fmt.Errorf("copying bloom filter of row group column %d: %w", i, err)
+			}
+		}
+	}
+
+	totalByteSize := int64(0)
+	totalCompressedSize := int64(0)
+
+	for i := range rowGroup.columns {
+		c := &rowGroup.columns[i]
+		metadata := &columns[i].MetaData
+
+		baseOffset := metadata.DataPageOffset
+		if metadata.DictionaryPageOffset != 0 && metadata.DictionaryPageOffset < baseOffset {
+			baseOffset = metadata.DictionaryPageOffset
+		}
+		delta := w.writer.offset - baseOffset
+
+		chunk := io.NewSectionReader(c.file, baseOffset, metadata.TotalCompressedSize)
+		if _, err := io.Copy(&w.writer, chunk); err != nil {
+			return 0, fmt.Errorf("copying row group column %d: %w", i, err)
+		}
+
+		metadata.DataPageOffset += delta
+		if metadata.DictionaryPageOffset != 0 {
+			metadata.DictionaryPageOffset += delta
+		}
+		if metadata.IndexPageOffset != 0 {
+			metadata.IndexPageOffset += delta
+		}
+
+		columnIndex[i] = *c.columnIndex
+		offsetIndex[i].PageLocations = make([]format.PageLocation, len(c.offsetIndex.PageLocations))
+		for j, page := range c.offsetIndex.PageLocations {
+			page.Offset += delta
+			offsetIndex[i].PageLocations[j] = page
+		}
+
+		totalByteSize += metadata.TotalUncompressedSize
+		totalCompressedSize += metadata.TotalCompressedSize
+	}
+
+	numRows := rowGroup.NumRows()
+	w.rowGroups = append(w.rowGroups, format.RowGroup{
+		Columns:             columns,
+		TotalByteSize:       totalByteSize,
+		NumRows:             numRows,
+		SortingColumns:      sortingColumnsOf(rowGroup.Schema(), rowGroup.SortingColumns()),
+		FileOffset:          fileOffset,
+		TotalCompressedSize: totalCompressedSize,
+		Ordinal:             int16(len(w.rowGroups)),
+	})
+
+	w.columnIndexes = append(w.columnIndexes, columnIndex)
+	w.offsetIndexes = append(w.offsetIndexes, offsetIndex)
+	return numRows, nil
+}
+
+// sortingColumnsOf returns the representation of sorting columns in the
+// metadata of parquet files, the columns are identified by their index in
+// the schema.
+func sortingColumnsOf(schema *Schema, sortingColumns []SortingColumn) []format.SortingColumn {
+	if len(sortingColumns) == 0 {
+		return nil
+	}
+	columns := make([]format.SortingColumn, len(sortingColumns))
+	forEachLeafColumnOf(schema, func(leaf leafColumn) {
+		if sortingIndex := searchSortingColumn(sortingColumns, leaf.path); sortingIndex < len(columns) {
+			columns[sortingIndex] = format.SortingColumn{
+				ColumnIdx:  int32(leaf.columnIndex),
+				Descending: sortingColumns[sortingIndex].Descending(),
+				NullsFirst: sortingColumns[sortingIndex].NullsFirst(),
+			}
+		}
+	})
+	return columns
+}
+
+// flushColumns encodes the values remaining in the column buffers into pages.
+//
+// When the writer is configured with a concurrency greater than one, columns
+// are flushed in parallel, and the dictionary pages are encoded as well since
+// each column has its own scratch buffers in this case.
+func (w *writer) flushColumns() error {
+	if w.concurrency == 1 {
+		for _, c := range w.columns {
+			if err := c.flush(); err != nil {
+				return err
+			}
+			if err := c.flushFilterPages(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make([]error, len(w.columns))
+	wg := sync.WaitGroup{}
+
+	for i, c := range w.columns {
+		wg.Add(1)
+		w.sem <- struct{}{}
+		go func(i int, c *writerColumn) {
+			defer func() { <-w.sem; wg.Done() }()
+			errs[i] = c.flushConcurrently()
+		}(i, c)
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("flushing row group column %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
 func (w *writer) WriteRow(row Row) error {
+	if w.checkDecimals {
+		// The values are checked before being inserted, otherwise an error
+		// would leave the columns with a partially written row.
+		for i := range row {
+			if err := w.columns[row[i].Column()].checkDecimal(row[i : i+1]); err != nil {
+				return err
+			}
+		}
+	}
+	if w.convertValues {
+		w.row = w.row[:0]
+		for _, v := range row {
+			w.row = append(w.row, w.columns[v.Column()].convertValue(v))
+		}
+		row = w.row
+	}
 	for i := range row {
 		c := w.columns[row[i].Column()]
 		if err := c.insert(c, row[i:i+1]); err != nil {
@@ -606,9 +1012,141 @@ func (w *writer) WriteRow(row Row) error {
 			return err
 		}
 	}
+	if w.rowGroupIsFull() {
+		return w.flush()
+	}
 	return nil
 }
 
+// rowGroupIsFull returns true if the rows buffered by w have reached one of
+// the row group limits configured on the writer.
+//
+// Estimating the size of the row group requires visiting all the columns, so
+// it is not done after each row, see estimateRowGroupSize.
+func (w *writer) rowGroupIsFull() bool {
+	if w.copyingRowGroup {
+		return false
+	}
+	numRows := w.columns[0].totalRowCount()
+	if numRows >= w.maxRowsPerRowGroup {
+		return true
+	}
+	if w.targetRowGroupSize == DefaultTargetRowGroupSize {
+		return false
+	}
+	return w.estimateRowGroupSize(w.targetRowGroupSize) >= w.targetRowGroupSize
+}
+
+// estimateRowGroupSize returns an estimate of the size of the buffered row
+// group which is cheap enough to be called after each row: the size is
+// extrapolated from the average size of rows at the last estimate, which is
+// only refreshed when a page was flushed since it was made, or when the
+// extrapolated size reaches limit.
+func (w *writer) estimateRowGroupSize(limit int64) int64 {
+	numRows := w.columns[0].totalRowCount()
+	if w.estimate.pageFlushes == w.pageFlushes && w.estimate.numRows > 0 && numRows >= w.estimate.numRows {
+		rowSize := w.estimate.size / w.estimate.numRows
+		if size := w.estimate.size + (numRows-w.estimate.numRows)*rowSize; size < limit {
+			return size
+		}
+	}
+	w.estimate.pageFlushes = w.pageFlushes
+	w.estimate.numRows = numRows
+	w.estimate.size = w.estimatedRowGroupSize()
+	return w.estimate.size
+}
+
+// estimatedRowGroupSize returns an estimate of the size of the row group that
+// would be written if w was flushed.
+func (w *writer) estimatedRowGroupSize() int64 {
+	size := int64(0)
+	for _, c := range w.columns {
+		size += c.estimatedSize()
+	}
+	return size
+}
+
+// WriteRows writes rows in batches: the values of each batch are grouped by
+// column, and each column writes the values of all the rows of the batch at
+// once. Batches are cut at the row group limits so flushed row groups never
+// hold more than the maximum number of rows.
+func (w *writer) WriteRows(rows []Row) (int, error) {
+	written := 0
+
+	for written < len(rows) {
+		batch := rows[written:]
+		if !w.copyingRowGroup {
+			limit := w.maxRowsPerRowGroup - w.columns[0].totalRowCount()
+			if limit < int64(len(batch)) {
+				batch = batch[:limit]
+			}
+		}
+
+		n, err := w.writeRows(batch)
+		written += n
+		if err != nil {
+			return written, err
+		}
+
+		if w.rowGroupIsFull() {
+			if err := w.flush(); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+func (w *writer) writeRows(rows []Row) (int, error) {
+	var err error
+
+	if w.checkDecimals {
+		// Only the rows preceding the first invalid value are written, the
+		// columns are never left with a partially written row.
+	checkRows:
+		for i, row := range rows {
+			for j := range row {
+				if err = w.columns[row[j].Column()].checkDecimal(row[j : j+1]); err != nil {
+					rows = rows[:i]
+					break checkRows
+				}
+			}
+		}
+	}
+
+	defer func() {
+		for _, c := range w.columns {
+			clearValues(c.batch)
+			c.batch = c.batch[:0]
+		}
+	}()
+
+	for _, row := range rows {
+		for _, v := range row {
+			c := w.columns[v.Column()]
+			if w.convertValues {
+				v = c.convertValue(v)
+			}
+			c.batch = append(c.batch, v)
+		}
+	}
+
+	for _, c := range w.columns {
+		if err := c.checkRows(c.batch, len(rows)); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, c := range w.columns {
+		if err := c.writeRows(c.batch); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(rows), err
+}
+
 // The WriteValues method is intended to work in pair with WritePage to allow
 // programs to target writing values to specific columns of of the writer.
 func (w *writer) WriteValues(values []Value) (numValues int, err error) {
@@ -627,11 +1165,16 @@ type writerColumn struct {
 	insert func(*writerColumn, Row) error
 	commit func(*writerColumn) error
 	values []Value
+	batch  []Value
 	filter []BufferedPage
 
 	pool  PageBufferPool
 	pages []io.ReadWriter
 
+	// Incremented when the column flushes a page while rows are written, which
+	// tells the writer to refresh its estimate of the row group size.
+	pageFlushes *int64
+
 	columnPath   columnPath
 	columnType   Type
 	columnIndex  ColumnIndexer
@@ -687,9 +1230,34 @@ type writerColumn struct {
 
 	columnChunk *format.ColumnChunk
 	offsetIndex *format.OffsetIndex
+
+	// Statistics of the pages written to the file since the writer was
+	// created or reset, across all its row groups.
+	stats struct {
+		nullCount int64
+		min, max  Value
+	}
+
+	encryption *columnEncryption
+	decimal    *decimalChecker
+	convert    func(Value) Value
+	converted  []Value
+
+	// When the writer has a concurrency greater than one, pages cut while rows
+	// are written are encoded and compressed in the background while values
+	// are written to a spare column buffer, see flushPage. The fields of the
+	// column updated by writeBufferedPage must not be accessed until wait is
+	// called.
+	sem         chan struct{}
+	done        chan error
+	spare       ColumnBuffer
+	flushing    bool
+	flushedRows int64
+	flushedSize int64
 }
 
 func (c *writerColumn) reset() {
+	c.wait()
 	if c.columnBuffer != nil {
 		c.columnBuffer.Reset()
 	}
@@ -734,18 +1302,42 @@ func (c *writerColumn) reset() {
 }
 
 func (c *writerColumn) totalRowCount() int64 {
-	n := c.numRows
+	n := c.flushedRows
+	if !c.flushing {
+		n = c.numRows
+	}
 	if c.columnBuffer != nil {
 		n += int64(c.columnBuffer.Len())
 	}
 	return n
 }
 
+// estimatedSize returns the size of the pages already written by the column,
+// plus the size of the values that are still buffered; those are not encoded
+// nor compressed yet so the estimate is usually larger than the final size of
+// the column chunk.
+func (c *writerColumn) estimatedSize() int64 {
+	size := c.flushedSize
+	if !c.flushing {
+		size = c.columnChunk.MetaData.TotalCompressedSize
+	}
+	if c.columnBuffer != nil {
+		size += c.columnBuffer.Size()
+	}
+	if c.dictionary != nil {
+		size += c.dictionary.Page().Size()
+	}
+	return size
+}
+
 func (c *writerColumn) canFlush() bool {
 	return c.columnBuffer.Size() >= int64(c.bufferSize/2)
 }
 
 func (c *writerColumn) flush() (err error) {
+	if err := c.wait(); err != nil {
+		return err
+	}
 	if c.numValues != 0 {
 		c.numValues = 0
 		defer c.columnBuffer.Reset()
@@ -754,6 +1346,72 @@ func (c *writerColumn) flush() (err error) {
 	return err
 }
 
+// flushPage is called to flush the buffered values to a page when the column
+// buffer is full.
+//
+// When the writer has a concurrency greater than one, the page is encoded and
+// compressed by a background goroutine while the program continues writing
+// values to a spare column buffer; errors are then reported by the next call
+// to wait. Columns with a dictionary are always flushed synchronously because
+// the dictionary is updated as values are written.
+func (c *writerColumn) flushPage() error {
+	*c.pageFlushes++
+	if c.sem == nil || c.dictionary != nil {
+		return c.flush()
+	}
+	if c.numValues == 0 {
+		return nil
+	}
+	if err := c.wait(); err != nil {
+		return err
+	}
+
+	buffer := c.columnBuffer
+	if c.spare == nil {
+		c.spare = c.newColumnBuffer()
+	}
+	c.columnBuffer, c.spare = c.spare, nil
+	c.numValues = 0
+	// The estimated size assumes that the page does not get smaller when it
+	// is encoded and compressed, like the size of buffered values does.
+	c.flushedRows = c.numRows + int64(buffer.Len())
+	c.flushedSize = c.columnChunk.MetaData.TotalCompressedSize + buffer.Size()
+	c.flushing = true
+
+	c.sem <- struct{}{}
+	go func() {
+		_, err := c.writeBufferedPage(buffer.Page())
+		buffer.Reset()
+		c.spare = buffer
+		<-c.sem
+		c.done <- err
+	}()
+	return nil
+}
+
+// wait waits for the page flushed in the background by flushPage, if any, and
+// returns the error that occurred while writing it.
+func (c *writerColumn) wait() error {
+	if !c.flushing {
+		return nil
+	}
+	c.flushing = false
+	return <-c.done
+}
+
+func (c *writerColumn) flushConcurrently() error {
+	if err := c.flush(); err != nil {
+		return err
+	}
+	if err := c.flushFilterPages(); err != nil {
+		return err
+	}
+	if c.dictionary != nil {
+		return c.encodeDictionaryPage(c.dictionary)
+	}
+	return nil
+}
+
 func (c *writerColumn) flushFilterPages() error {
 	if c.columnFilter != nil {
 		numValues := int64(0)
@@ -810,6 +1468,27 @@ func (c *writerColumn) newBloomFilterEncoder(numRows int64) *bloomFilterEncoder
 	)
 }
 
+// checkDecimal returns an error if one of the values exceeds the precision of
+// the column, which is only the case for columns of DECIMAL logical type.
+func (c *writerColumn) checkDecimal(values []Value) error {
+	if c.decimal != nil {
+		for _, v := range values {
+			if !c.decimal.check(v) {
+				return fmt.Errorf("%w: %s does not fit in DECIMAL(%d) column %s", ErrDecimalOverflow, decimalOf(v), c.decimal.precision, c.columnPath)
+			}
+		}
+	}
+	return nil
+}
+
+// convertValue converts v to the type of the column, retaining its levels.
+func (c *writerColumn) convertValue(v Value) Value {
+	if c.convert == nil || v.IsNull() {
+		return v
+	}
+	return c.convert(v).Level(int(v.repetitionLevel), int(v.definitionLevel), v.Column())
+}
+
 func (c *writerColumn) WriteRow(row Row) error {
 	if c.columnBuffer == nil {
 		// Lazily create the row group column so we don't need to allocate it if
@@ -819,7 +1498,7 @@ func (c *writerColumn) WriteRow(row Row) error {
 	}
 
 	if c.numValues > 0 && c.numValues > (c.maxValues-int32(len(row))) {
-		if err := c.flush(); err != nil {
+		if err := c.flushPage(); err != nil {
 			return err
 		}
 	}
@@ -831,7 +1510,79 @@ func (c *writerColumn) WriteRow(row Row) error {
 	return nil
 }
 
+// checkRows returns an error if values are not the values of numRows rows of
+// the column.
+func (c *writerColumn) checkRows(values []Value, numRows int) error {
+	n := 0
+	for _, v := range values {
+		if v.repetitionLevel == 0 {
+			n++
+		}
+	}
+	switch {
+	case n < numRows:
+		return errRowHasTooFewValues(int64(n))
+	case n > numRows:
+		return errRowHasTooManyValues(int64(n))
+	case len(values) > 0 && values[0].repetitionLevel != 0:
+		return fmt.Errorf("values of column %s do not start at a row boundary", c.columnPath)
+	}
+	return nil
+}
+
+// writeRows writes the values of consecutive rows to the column buffer, as
+// many rows at a time as the buffer can hold before a page must be flushed.
+func (c *writerColumn) writeRows(values []Value) error {
+	if c.columnBuffer == nil {
+		c.columnBuffer = c.newColumnBuffer()
+		c.maxValues = int32(c.columnBuffer.Cap())
+	}
+
+	for len(values) > 0 {
+		n := len(values)
+		if free := int(c.maxValues - c.numValues); free < n {
+			// Only whole rows are written to a page, move back to the start
+			// of the last row that fits.
+			for n = free; n > 0 && values[n].repetitionLevel != 0; {
+				n--
+			}
+		}
+
+		if n <= 0 {
+			if c.numValues > 0 {
+				if err := c.flushPage(); err != nil {
+					return err
+				}
+				continue
+			}
+			// The row is larger than the buffer capacity, it is written to
+			// a page of its own.
+			for n = 1; n < len(values) && values[n].repetitionLevel != 0; {
+				n++
+			}
+		}
+
+		if _, err := c.columnBuffer.WriteValues(values[:n]); err != nil {
+			return err
+		}
+		c.numValues += int32(n)
+		values = values[n:]
+	}
+
+	return nil
+}
+
 func (c *writerColumn) WriteValues(values []Value) (numValues int, err error) {
+	if err := c.checkDecimal(values); err != nil {
+		return 0, err
+	}
+	if c.convert != nil {
+		c.converted = c.converted[:0]
+		for _, v := range values {
+			c.converted = append(c.converted, c.convertValue(v))
+		}
+		values = c.converted
+	}
 	if c.columnBuffer == nil {
 		c.columnBuffer = c.newColumnBuffer()
 		c.maxValues = int32(c.columnBuffer.Cap())
@@ -842,10 +1593,14 @@ func (c *writerColumn) WriteValues(values []Value) (numValues int, err error) {
 }
 
 func (c *writerColumn) WritePage(page Page) (numValues int64, err error) {
+	if err := c.wait(); err != nil {
+		return 0, err
+	}
 	// Page write optimizations are only available the column is not reindexing
 	// the values. If a dictionary is present, the column needs to see each
-	// individual value in order to re-index them in the dictionary.
-	if c.dictionary == nil || c.dictionary == page.Dictionary() {
+	// individual value in order to re-index them in the dictionary. Values
+	// also need to be seen individually when they must be converted.
+	if c.convert == nil && (c.dictionary == nil || c.dictionary == page.Dictionary()) {
 		// If the column had buffered values, we continue writing values from
 		// the page into the column buffer if it would have caused producing a
 		// page less than half the size of the target; if there were enough
@@ -904,10 +1659,13 @@ func (c *writerColumn) writePageValues(page ValueReader) (numValues int64, err e
 }
 
 func (c *writerColumn) writeBloomFilter(w io.Writer) error {
-	e := thrift.NewEncoder(c.header.protocol.NewWriter(w))
 	h := bloomFilterHeader(c.columnFilter)
 	b := c.page.filter.Bytes()
 	h.NumBytes = int32(len(b))
+	if c.encryption != nil {
+		return c.writeEncryptedBloomFilter(w, &h, b)
+	}
+	e := thrift.NewEncoder(c.header.protocol.NewWriter(w))
 	if err := e.Encode(&h); err != nil {
 		return err
 	}
@@ -915,6 +1673,42 @@ func (c *writerColumn) writeBloomFilter(w io.Writer) error {
 	return err
 }
 
+func (c *writerColumn) writeEncryptedBloomFilter(w io.Writer, h *format.BloomFilterHeader, b []byte) error {
+	header, err := thrift.Marshal(&c.header.protocol, h)
+	if err != nil {
+		return err
+	}
+	buffer := bytes.NewBuffer(header)
+	if err := c.encrypt(buffer, bloomFilterHeaderModule); err != nil {
+		return err
+	}
+	if _, err := w.Write(buffer.Bytes()); err != nil {
+		return err
+	}
+	buffer.Reset()
+	buffer.Write(b)
+	if err := c.encrypt(buffer, bloomFilterBitsetModule); err != nil {
+		return err
+	}
+	_, err = w.Write(buffer.Bytes())
+	return err
+}
+
+// encrypt replaces the content of b with its encrypted module if the column is
+// encrypted. The page ordinal of data pages is the number of pages already
+// written to the column chunk.
+func (c *writerColumn) encrypt(b *bytes.Buffer, kind byte) error {
+	if c.encryption == nil {
+		return nil
+	}
+	return c.encryption.encrypt(b, module{
+		kind:     kind,
+		rowGroup: c.encryption.rowGroup,
+		column:   int(c.bufferIndex),
+		page:     len(c.offsetIndex.PageLocations),
+	})
+}
+
 func (c *writerColumn) writeBufferedPage(page BufferedPage) (int64, error) {
 	numValues := page.NumValues()
 	if numValues == 0 {
@@ -992,6 +1786,9 @@ func (c *writerColumn) writeBufferedPage(page BufferedPage) (int64, error) {
 			return 0, err
 		}
 	}
+	if err := c.encrypt(c.page.buffer, dataPageModule); err != nil {
+		return 0, err
+	}
 
 	c.header.buffer.Reset()
 	levelsByteLength := repetitionLevelsByteLength + definitionLevelsByteLength
@@ -1032,6 +1829,9 @@ func (c *writerColumn) writeBufferedPage(page BufferedPage) (int64, error) {
 	if err := c.header.encoder.Encode(pageHeader); err != nil {
 		return 0, err
 	}
+	if err := c.encrypt(c.header.buffer, dataPageHeaderModule); err != nil {
+		return 0, err
+	}
 	headerSize := int32(c.header.buffer.Len())
 	compressedSize := int64(headerSize) + int64(compressedPageSize)
 	if err := c.writePage(compressedSize, c.header.buffer, c.page.buffer); err != nil {
@@ -1071,20 +1871,41 @@ func (c *writerColumn) writeCompressedPage(page CompressedPage) (int64, error) {
 
 	switch h := page.PageHeader().(type) {
 	case DataPageHeaderV1:
+		pageHeader.Type = format.DataPage
 		pageHeader.DataPageHeader = h.header
 	case DataPageHeaderV2:
+		pageHeader.Type = format.DataPageV2
 		pageHeader.DataPageHeaderV2 = h.header
 	default:
 		return 0, fmt.Errorf("writing compressed page type of unknown type: %s", h.PageType())
 	}
 
+	pageData := page.PageData()
+	if c.encryption != nil {
+		c.page.buffer.Reset()
+		if _, err := c.page.buffer.ReadFrom(pageData); err != nil {
+			return 0, err
+		}
+		if err := c.encrypt(c.page.buffer, dataPageModule); err != nil {
+			return 0, err
+		}
+		pageHeader.CompressedPageSize = int32(c.page.buffer.Len())
+		if pageHeader.CRC != 0 {
+			pageHeader.CRC = int32(crc32.ChecksumIEEE(c.page.buffer.Bytes()))
+		}
+		pageData = c.page.buffer
+	}
+
 	c.header.buffer.Reset()
 	if err := c.header.encoder.Encode(pageHeader); err != nil {
 		return 0, err
 	}
+	if err := c.encrypt(c.header.buffer, dataPageHeaderModule); err != nil {
+		return 0, err
+	}
 	headerSize := int32(c.header.buffer.Len())
 	compressedSize := int64(headerSize + pageHeader.CompressedPageSize)
-	if err := c.writePage(compressedSize, c.header.buffer, page.PageData()); err != nil {
+	if err := c.writePage(compressedSize, c.header.buffer, pageData); err != nil {
 		return 0, err
 	}
 	c.recordPageStats(headerSize, pageHeader, page)
@@ -1108,14 +1929,16 @@ func (c *writerColumn) writePage(size int64, header, data io.Reader) error {
 	}
 	written := headerSize + dataSize
 	if size != written {
-		return fmt.Errorf("writing parquet colum page expected %dB but got %dB: %w", size, written, io.ErrShortWrite)
+		return fmt.Errorf("writing parquet column page expected %dB but got %dB: %w", size, written, io.ErrShortWrite)
 	}
 	c.pages = append(c.pages, buffer)
 	buffer = nil
 	return nil
 }
 
-func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) error {
+// encodeDictionaryPage encodes the dictionary page in the header and page
+// buffers of c, which are then written to the output by writeDictionaryPage.
+func (c *writerColumn) encodeDictionaryPage(dict Dictionary) error {
 	c.page.buffer.Reset()
 
 	p, err := c.compressedPage(c.page.buffer)
@@ -1132,6 +1955,9 @@ func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) er
 	if err := p.Close(); err != nil {
 		return fmt.Errorf("flushing compressed parquet dictionary page: %w", err)
 	}
+	if err := c.encrypt(c.page.buffer, dictionaryPageModule); err != nil {
+		return err
+	}
 
 	pageHeader := &format.PageHeader{
 		Type:                 format.DictionaryPage,
@@ -1149,16 +1975,21 @@ func (c *writerColumn) writeDictionaryPage(output io.Writer, dict Dictionary) er
 	if err := c.header.encoder.Encode(pageHeader); err != nil {
 		return err
 	}
-	if _, err := output.Write(c.header.buffer.Bytes()); err != nil {
-		return err
-	}
-	if _, err := output.Write(c.page.buffer.Bytes()); err != nil {
+	if err := c.encrypt(c.header.buffer, dictionaryPageHeaderModule); err != nil {
 		return err
 	}
 	c.recordPageStats(int32(c.header.buffer.Len()), pageHeader, nil)
 	return nil
 }
 
+func (c *writerColumn) writeDictionaryPage(output io.Writer) error {
+	if _, err := output.Write(c.header.buffer.Bytes()); err != nil {
+		return err
+	}
+	_, err := output.Write(c.page.buffer.Bytes())
+	return err
+}
+
 func (c *writerColumn) compressedPage(w io.Writer) (compress.Writer, error) {
 	if c.page.compressed == nil {
 		z, err := c.compression.NewWriter(w)
@@ -1197,6 +2028,7 @@ func (c *writerColumn) recordPageStats(headerSize int32, header *format.PageHead
 		numValues := page.NumValues()
 		minValue, maxValue := page.Bounds()
 		c.columnIndex.IndexPage(numValues, numNulls, minValue, maxValue)
+		c.recordFileStats(numNulls, minValue, maxValue)
 		c.columnChunk.MetaData.NumValues += numValues
 
 		c.offsetIndex.PageLocations = append(c.offsetIndex.PageLocations, format.PageLocation{
@@ -1228,6 +2060,19 @@ func (c *writerColumn) recordPageStats(headerSize int32, header *format.PageHead
 	})
 }
 
+func (c *writerColumn) recordFileStats(numNulls int64, minValue, maxValue Value) {
+	c.stats.nullCount += numNulls
+	if minValue.IsNull() {
+		return
+	}
+	if c.stats.min.IsNull() || c.columnType.Compare(minValue, c.stats.min) < 0 {
+		c.stats.min = minValue.Clone()
+	}
+	if c.stats.max.IsNull() || c.columnType.Compare(maxValue, c.stats.max) > 0 {
+		c.stats.max = maxValue.Clone()
+	}
+}
+
 func addEncoding(encodings []format.Encoding, add format.Encoding) []format.Encoding {
 	for _, enc := range encodings {
 		if enc == add {
@@ -1317,12 +2162,14 @@ func (w *offsetTrackingWriter) WriteString(s string) (int, error) {
 
 var (
 	_ RowWriterWithSchema = (*Writer)(nil)
+	_ RowBatchWriter      = (*Writer)(nil)
 	_ RowReaderFrom       = (*Writer)(nil)
 	_ RowGroupWriter      = (*Writer)(nil)
 
-	_ RowWriter   = (*writer)(nil)
-	_ PageWriter  = (*writer)(nil)
-	_ ValueWriter = (*writer)(nil)
+	_ RowWriter      = (*writer)(nil)
+	_ RowBatchWriter = (*writer)(nil)
+	_ PageWriter     = (*writer)(nil)
+	_ ValueWriter    = (*writer)(nil)
 
 	_ RowWriter   = (*writerColumn)(nil)
 	_ PageWriter  = (*writerColumn)(nil)
@@ -0,0 +1,148 @@
+//go:build go1.18
+
+package parquet
+
+import (
+	"io"
+	"reflect"
+)
+
+// GenericWriter is similar to a Writer but uses a type parameter to define the
+// Go type representing the schema of rows being written.
+//
+// This example showcases a typical use of generic parquet writers:
+//
+//	writer := parquet.NewGenericWriter[RowType](output)
+//
+//	if _, err := writer.Write(rows); err != nil {
+//		...
+//	}
+//
+//	if err := writer.Close(); err != nil {
+//		...
+//	}
+//
+// The schema of rows is derived once from the type parameter T, which must be a
+// struct type or a pointer to a struct type, and the functions used to
+// deconstruct Go values into parquet rows are cached with the schema.
+type GenericWriter[T any] struct {
+	base   *Writer
+	schema *Schema
+	conv   Conversion
+	buffer []Value
+	values []Value
+}
+
+// NewGenericWriter is like NewWriter but returns a GenericWriter[T] suited to
+// write rows of Go type T.
+//
+// If the writer configuration does not have a schema, the schema of the file
+// is derived from T. Otherwise, the rows are converted from the schema of T to
+// the configured schema before being written.
+//
+// The function panics if the writer configuration is invalid, or if the schema
+// of T cannot be converted to the configured schema.
+func NewGenericWriter[T any](output io.Writer, options ...WriterOption) *GenericWriter[T] {
+	config, err := NewWriterConfig(options...)
+	if err != nil {
+		panic(err)
+	}
+
+	schema := schemaOf(dereference(typeOf[T]()))
+	if config.Schema == nil {
+		config.Schema = schema
+	}
+
+	w := &GenericWriter[T]{
+		base:   NewWriter(output, config),
+		schema: schema,
+	}
+
+	if !nodesAreEqual(config.Schema, schema) {
+		if w.conv, err = Convert(config.Schema, schema); err != nil {
+			panic(err)
+		}
+	}
+
+	return w
+}
+
+// Close must be called after all values were produced to the writer in order to
+// flush all buffers and write the parquet footer.
+func (w *GenericWriter[T]) Close() error { return w.base.Close() }
+
+// Flush flushes all buffers into a row group to the underlying io.Writer.
+func (w *GenericWriter[T]) Flush() error { return w.base.Flush() }
+
+// Reset clears the state of the writer without flushing any of the buffers,
+// and setting the output to the io.Writer passed as argument, allowing the
+// writer to be reused to produce another parquet file.
+func (w *GenericWriter[T]) Reset(output io.Writer) { w.base.Reset(output) }
+
+// Write writes the rows passed as argument to w, returning the number of rows
+// written.
+func (w *GenericWriter[T]) Write(rows []T) (int, error) {
+	values := reflect.ValueOf(rows)
+
+	for i := range rows {
+		v := values.Index(i)
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v = reflect.Value{}
+			} else {
+				v = v.Elem()
+			}
+		}
+
+		if err := w.writeRow(v); err != nil {
+			return i, err
+		}
+	}
+
+	return len(rows), nil
+}
+
+func (w *GenericWriter[T]) writeRow(value reflect.Value) error {
+	defer clearValues(w.values)
+	if err := w.deconstruct(value); err != nil {
+		return err
+	}
+
+	if w.conv == nil {
+		return w.base.WriteRow(w.values)
+	}
+
+	defer clearValues(w.buffer)
+	var err error
+	if w.buffer, err = w.conv.Convert(w.buffer[:0], w.values); err != nil {
+		return err
+	}
+	return w.base.WriteRow(w.buffer)
+}
+
+func (w *GenericWriter[T]) deconstruct(value reflect.Value) (err error) {
+	w.values = w.values[:0]
+	defer recoverValueError(&err)
+	w.values = w.schema.deconstruct(w.values, levels{}, value)
+	return nil
+}
+
+// WriteRowGroup writes a row group to the parquet file.
+//
+// See Writer.WriteRowGroup for details.
+func (w *GenericWriter[T]) WriteRowGroup(rowGroup RowGroup) (int64, error) {
+	return w.base.WriteRowGroup(rowGroup)
+}
+
+// ReadRowsFrom reads rows from the reader passed as arguments and writes them
+// to w.
+func (w *GenericWriter[T]) ReadRowsFrom(rows RowReader) (int64, error) {
+	return w.base.ReadRowsFrom(rows)
+}
+
+// Schema returns the schema of rows written by w.
+func (w *GenericWriter[T]) Schema() *Schema { return w.base.Schema() }
+
+var (
+	_ RowReaderFrom = (*GenericWriter[struct{}])(nil)
+)
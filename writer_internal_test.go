@@ -0,0 +1,159 @@
+package parquet
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriterFlushSortingColumns(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	write := func(sorting []SortingColumn) *File {
+		buffer := new(bytes.Buffer)
+		writer := NewWriter(buffer, SchemaOf(Row{}), MaxRowsPerRowGroup(10))
+		writer.writer.sortingColumns = sorting
+		for i := 0; i < 25; i++ {
+			if err := writer.Write(&Row{ID: int64(i)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		f, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := f.NumRowGroups(); n != 3 {
+			t.Fatalf("wrong number of row groups: want=3 got=%d", n)
+		}
+		return f
+	}
+
+	f := write(nil)
+	for i := 0; i < f.NumRowGroups(); i++ {
+		if sorting := f.RowGroup(i).SortingColumns(); len(sorting) != 0 {
+			t.Errorf("row group %d has unexpected sorting columns: %v", i, sorting)
+		}
+	}
+
+	f = write([]SortingColumn{Descending("id")})
+	for i := 0; i < f.NumRowGroups(); i++ {
+		sorting := f.RowGroup(i).SortingColumns()
+		if len(sorting) != 1 || sorting[0].Path()[0] != "id" || !sorting[0].Descending() {
+			t.Errorf("row group %d has wrong sorting columns: %v", i, sorting)
+		}
+	}
+}
+
+func TestWriterFlushPagesConcurrently(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,zstd"`
+		Name string `parquet:"name,dict"`
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := NewWriter(buffer, SchemaOf(Row{}), WriterConcurrency(2), PageBufferSize(256))
+
+	id, name := writer.writer.columns[0], writer.writer.columns[1]
+	if id.columnPath[0] != "id" {
+		id, name = name, id
+	}
+
+	numRows := 0
+	for !id.flushing {
+		if numRows == 1000 {
+			t.Fatal("no page was flushed in the background after writing 1000 rows")
+		}
+		if err := writer.Write(&Row{ID: int64(numRows), Name: "name"}); err != nil {
+			t.Fatal(err)
+		}
+		numRows++
+		if name.flushing {
+			t.Fatal("a page of the dictionary encoded column was flushed in the background")
+		}
+	}
+
+	if n := id.totalRowCount(); n != int64(numRows) {
+		t.Fatalf("wrong row count while a page is being flushed: want=%d got=%d", numRows, n)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if id.flushing {
+		t.Fatal("closing the writer did not wait for the page flushed in the background")
+	}
+
+	f, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := f.RowGroup(0).NumRows(); n != int64(numRows) {
+		t.Fatalf("wrong number of rows written: want=%d got=%d", numRows, n)
+	}
+}
+
+func TestWriterRowGroupSizeEstimate(t *testing.T) {
+	type Row struct {
+		ID int64 `parquet:"id"`
+	}
+
+	for _, test := range []struct {
+		scenario string
+		options  []WriterOption
+		numRows  int64
+	}{
+		{scenario: "default target", numRows: 0},
+		{scenario: "large target", options: []WriterOption{TargetRowGroupSize(1 << 30)}, numRows: 1},
+	} {
+		t.Run(test.scenario, func(t *testing.T) {
+			writer := NewWriter(new(bytes.Buffer), append(test.options, SchemaOf(Row{}))...)
+			for i := 0; i < 100; i++ {
+				if err := writer.Write(&Row{ID: int64(i)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if n := writer.writer.estimate.numRows; n != test.numRows {
+				t.Errorf("row group size estimated at the wrong row: want=%d got=%d", test.numRows, n)
+			}
+		})
+	}
+}
+
+func TestWriterCanCopyFileRowGroup(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id"`
+		Name string `parquet:"name"`
+	}
+
+	buffer := new(bytes.Buffer)
+	writer := NewWriter(buffer)
+	if err := writer.Write(&Row{ID: 1, Name: "one"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rowGroup := f.RowGroup(0).(*fileRowGroup)
+	output := NewWriter(new(bytes.Buffer), SchemaOf(Row{}))
+
+	if !output.writer.canCopyFileRowGroup(rowGroup) {
+		t.Fatal("the column chunks of the row group cannot be copied")
+	}
+
+	// Column chunks stored in external files cannot be read from the source
+	// file, they must not be copied.
+	chunk := *rowGroup.columns[1].chunk
+	chunk.FilePath = "name.parquet"
+	rowGroup.columns[1].chunk = &chunk
+	if output.writer.canCopyFileRowGroup(rowGroup) {
+		t.Error("the column chunks of the row group are copied when one of them is stored in another file")
+	}
+}
@@ -3,8 +3,10 @@ package parquet_test
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"reflect"
 	"strings"
 	"testing"
 	"testing/quick"
@@ -14,6 +16,7 @@ import (
 	"github.com/hexops/gotextdiff/myers"
 	"github.com/hexops/gotextdiff/span"
 	"github.com/segmentio/parquet-go"
+	"github.com/segmentio/parquet-go/format"
 )
 
 const (
@@ -603,3 +606,341 @@ func TestWriterRepeatedUUIDDict(t *testing.T) {
 		t.Errorf("expected to get UUID %q back out, got %q", inputID, row[0].Bytes())
 	}
 }
+
+func TestWriterSchemaOfFile(t *testing.T) {
+	type Row struct {
+		Name  *string `parquet:"name,optional"`
+		Email string  `parquet:"email,dict"`
+		Tags  []int64 `parquet:"tags"`
+	}
+
+	name := "Luke"
+	f, err := createParquetFile(makeRows([]Row{
+		{Name: &name, Email: "luke@example.com", Tags: []int64{1, 2}},
+		{Email: "leia@example.com"},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The columns of the file also list the encodings of their repetition
+	// and definition levels, which must not be used to encode the values.
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer, f.RowGroup(0).Schema())
+	rows := f.RowGroup(0).Rows()
+	for {
+		row, err := rows.ReadRow(nil)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatal(err)
+		}
+		if err := writer.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(buffer.Bytes()))
+	for i := 0; i < 2; i++ {
+		row := make(map[string]interface{})
+		if err := reader.ReadMap(row); err != nil {
+			t.Fatalf("reading row %d: %v", i, err)
+		}
+	}
+	if err := reader.ReadMap(make(map[string]interface{})); err != io.EOF {
+		t.Errorf("expected io.EOF after the last row but got %v", err)
+	}
+}
+
+func TestWriterWriteFileRowGroup(t *testing.T) {
+	type Row struct {
+		ID    int64  `parquet:"id"`
+		Value string `parquet:"value"`
+	}
+
+	rows := make([]Row, 100)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Value: fmt.Sprint("value-", i)}
+	}
+	f, err := createParquetFile(makeRows(rows))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Pages of file row groups are copied without being decoded, the writer
+	// must still produce valid headers for them.
+	buffer := new(bytes.Buffer)
+	writer := parquet.NewWriter(buffer)
+	if _, err := writer.WriteRowGroup(f.RowGroup(0)); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reader := parquet.NewReader(bytes.NewReader(buffer.Bytes()))
+	for i := range rows {
+		row := Row{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatalf("reading row %d: %v", i, err)
+		}
+		if row != rows[i] {
+			t.Fatalf("row %d mismatch: want=%+v got=%+v", i, rows[i], row)
+		}
+	}
+	if err := reader.Read(new(Row)); err != io.EOF {
+		t.Errorf("expected io.EOF after the last row but got %v", err)
+	}
+}
+
+func TestWriterConcurrency(t *testing.T) {
+	type wideRow struct {
+		Email  string  `parquet:"email,snappy"`
+		ID     int64   `parquet:"id,zstd"`
+		Name   string  `parquet:"name,dict,zstd"`
+		Score  *int32  `parquet:"score,optional,gzip"`
+		Tags   []int32 `parquet:"tags,snappy"`
+		Weight float64 `parquet:"weight"`
+	}
+
+	rows := make([]wideRow, 1000)
+	for i := range rows {
+		rows[i] = wideRow{
+			ID:     int64(i),
+			Name:   fmt.Sprintf("name-%d", i%13),
+			Email:  fmt.Sprintf("user-%d@example.com", i),
+			Weight: float64(i) / 3,
+		}
+		if i%4 != 0 {
+			score := int32(i)
+			rows[i].Score = &score
+		}
+		for j := 0; j < i%3; j++ {
+			rows[i].Tags = append(rows[i].Tags, int32(i+j))
+		}
+	}
+
+	write := func(options ...parquet.WriterOption) []byte {
+		buffer := new(bytes.Buffer)
+		writer := parquet.NewWriter(buffer, append(options,
+			parquet.PageBufferSize(512),
+			parquet.BloomFilters(parquet.SplitBlockFilter("email")),
+		)...)
+		for i := range rows {
+			if err := writer.Write(&rows[i]); err != nil {
+				t.Fatal(err)
+			}
+			if (i+1)%300 == 0 {
+				if err := writer.Flush(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return buffer.Bytes()
+	}
+
+	want := write()
+
+	for _, concurrency := range []int{2, 4, 16} {
+		got := write(parquet.WriterConcurrency(concurrency))
+		if !bytes.Equal(want, got) {
+			t.Fatalf("concurrency=%d: output differs from the sequential writer", concurrency)
+		}
+
+		reader := parquet.NewReader(bytes.NewReader(got))
+		for i := range rows {
+			row := wideRow{}
+			if err := reader.Read(&row); err != nil {
+				t.Fatalf("concurrency=%d: reading row %d: %v", concurrency, i, err)
+			}
+			if len(row.Tags) == 0 {
+				row.Tags = nil
+			}
+			if !reflect.DeepEqual(row, rows[i]) {
+				t.Fatalf("concurrency=%d: row %d mismatch:\nwant = %+v\ngot  = %+v", concurrency, i, rows[i], row)
+			}
+		}
+	}
+}
+
+func TestWriterRowGroupLimits(t *testing.T) {
+	type Row struct {
+		ID    int64  `parquet:"id"`
+		Value string `parquet:"value"`
+	}
+
+	tests := []struct {
+		scenario string
+		options  []parquet.WriterOption
+		check    func(*testing.T, []int64)
+	}{
+		{
+			scenario: "max rows per row group",
+			options:  []parquet.WriterOption{parquet.MaxRowsPerRowGroup(300)},
+			check: func(t *testing.T, numRows []int64) {
+				if want := []int64{300, 300, 300, 100}; !reflect.DeepEqual(numRows, want) {
+					t.Fatalf("wrong number of rows in row groups: want=%v got=%v", want, numRows)
+				}
+			},
+		},
+
+		{
+			scenario: "target row group size",
+			options:  []parquet.WriterOption{parquet.TargetRowGroupSize(4096)},
+			check: func(t *testing.T, numRows []int64) {
+				if len(numRows) < 2 {
+					t.Fatalf("expected the rows to be split in multiple row groups, got %v", numRows)
+				}
+			},
+		},
+
+		{
+			scenario: "both limits",
+			options: []parquet.WriterOption{
+				parquet.MaxRowsPerRowGroup(400),
+				parquet.TargetRowGroupSize(1 << 20),
+			},
+			check: func(t *testing.T, numRows []int64) {
+				if want := []int64{400, 400, 200}; !reflect.DeepEqual(numRows, want) {
+					t.Fatalf("wrong number of rows in row groups: want=%v got=%v", want, numRows)
+				}
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.scenario, func(t *testing.T) {
+			buffer := new(bytes.Buffer)
+			writer := parquet.NewWriter(buffer, test.options...)
+			for i := 0; i < 1000; i++ {
+				if err := writer.Write(&Row{ID: int64(i), Value: fmt.Sprintf("value-%d", i)}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := writer.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := parquet.OpenFile(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			numRows := make([]int64, f.NumRowGroups())
+			totalRows := int64(0)
+			for i := range numRows {
+				numRows[i] = f.RowGroup(i).NumRows()
+				totalRows += numRows[i]
+			}
+			if totalRows != 1000 {
+				t.Fatalf("wrong number of rows in the file: want=1000 got=%d", totalRows)
+			}
+			test.check(t, numRows)
+		})
+	}
+}
+
+func TestWriterCopyFileRowGroup(t *testing.T) {
+	type Row struct {
+		ID   int64  `parquet:"id,snappy"`
+		Name string `parquet:"name,dict"`
+	}
+
+	rows := make([]Row, 1000)
+	for i := range rows {
+		rows[i] = Row{ID: int64(i), Name: fmt.Sprintf("name-%d", i%10)}
+	}
+
+	source := new(bytes.Buffer)
+	writer := parquet.NewWriter(source,
+		parquet.DataPageVersion(v1),
+		parquet.PageBufferSize(1024),
+		parquet.BloomFilters(parquet.SplitBlockFilter("name")),
+	)
+	for i := range rows {
+		if err := writer.Write(&rows[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := parquet.OpenFile(bytes.NewReader(source.Bytes()), int64(source.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output := new(bytes.Buffer)
+	writer = parquet.NewWriter(output)
+	for i := 0; i < 2; i++ {
+		if _, err := writer.WriteRowGroup(src.RowGroup(0)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := f.NumRowGroups(); n != 2 {
+		t.Fatalf("wrong number of row groups: want=2 got=%d", n)
+	}
+
+	srcColumns := src.Metadata().RowGroups[0].Columns
+	offsetIndexes := f.OffsetIndexes()
+
+	for i, rowGroup := range f.Metadata().RowGroups {
+		for j, column := range rowGroup.Columns {
+			// The pages are written in data page v1, which the writer would
+			// not produce by default if it re-encoded them.
+			srcChunk := chunkBytes(source.Bytes(), &srcColumns[j].MetaData)
+			dstChunk := chunkBytes(output.Bytes(), &column.MetaData)
+			if !bytes.Equal(srcChunk, dstChunk) {
+				t.Errorf("row group %d, column %d: the column chunk was not copied", i, j)
+			}
+
+			locations := offsetIndexes[i*len(rowGroup.Columns)+j].PageLocations
+			if len(locations) < 2 || locations[0].Offset != column.MetaData.DataPageOffset {
+				t.Errorf("row group %d, column %d: the offset index was not relocated: %+v", i, j, locations)
+			}
+		}
+
+		bloomFilter := f.RowGroup(i).Column(1).BloomFilter()
+		if bloomFilter == nil {
+			t.Fatalf("row group %d: missing bloom filter", i)
+		}
+		if ok, err := bloomFilter.Check(parquet.ValueOf("name-3")); err != nil || !ok {
+			t.Errorf("row group %d: bloom filter does not contain the value (err=%v)", i, err)
+		}
+	}
+
+	reader := parquet.NewReader(f)
+	for i := 0; i < 2*len(rows); i++ {
+		row := Row{}
+		if err := reader.Read(&row); err != nil {
+			t.Fatalf("reading row %d: %v", i, err)
+		}
+		if want := rows[i%len(rows)]; row != want {
+			t.Fatalf("row %d mismatch: want=%+v got=%+v", i, want, row)
+		}
+	}
+}
+
+func chunkBytes(file []byte, metadata *format.ColumnMetaData) []byte {
+	offset := metadata.DataPageOffset
+	if metadata.DictionaryPageOffset != 0 && metadata.DictionaryPageOffset < offset {
+		offset = metadata.DictionaryPageOffset
+	}
+	return file[offset : offset+metadata.TotalCompressedSize]
+}